@@ -6,27 +6,66 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	_ "time/tzdata" // quiet hours are in the user's timezone, the runtime image has no zoneinfo
 
 	"github.com/gin-gonic/gin"
 	_ "github.com/ocenb/geo-alerts/docs"
 	"github.com/ocenb/geo-alerts/internal/config"
+	alerthandler "github.com/ocenb/geo-alerts/internal/handlers/alert"
+	broadcasthandler "github.com/ocenb/geo-alerts/internal/handlers/broadcast"
+	checkinhandler "github.com/ocenb/geo-alerts/internal/handlers/checkin"
+	devicehandler "github.com/ocenb/geo-alerts/internal/handlers/device"
+	escalationhandler "github.com/ocenb/geo-alerts/internal/handlers/escalation"
+	guardianhandler "github.com/ocenb/geo-alerts/internal/handlers/guardian"
 	incidenthandler "github.com/ocenb/geo-alerts/internal/handlers/incident"
 	locationhandler "github.com/ocenb/geo-alerts/internal/handlers/location"
+	placehandler "github.com/ocenb/geo-alerts/internal/handlers/place"
+	preferenceshandler "github.com/ocenb/geo-alerts/internal/handlers/preferences"
+	queuehandler "github.com/ocenb/geo-alerts/internal/handlers/queue"
 	systemhandler "github.com/ocenb/geo-alerts/internal/handlers/system"
+	webhookhandler "github.com/ocenb/geo-alerts/internal/handlers/webhook"
 	"github.com/ocenb/geo-alerts/internal/http/server"
 	"github.com/ocenb/geo-alerts/internal/logger"
 	"github.com/ocenb/geo-alerts/internal/logger/logattr"
 	"github.com/ocenb/geo-alerts/internal/middlewares"
 	"github.com/ocenb/geo-alerts/internal/queue"
+	alertrepo "github.com/ocenb/geo-alerts/internal/repos/alert"
+	broadcastrepo "github.com/ocenb/geo-alerts/internal/repos/broadcast"
 	cacherepo "github.com/ocenb/geo-alerts/internal/repos/cache"
+	checkinrepo "github.com/ocenb/geo-alerts/internal/repos/checkin"
+	devicerepo "github.com/ocenb/geo-alerts/internal/repos/device"
+	digestrepo "github.com/ocenb/geo-alerts/internal/repos/digest"
+	escalationrepo "github.com/ocenb/geo-alerts/internal/repos/escalation"
+	guardianrepo "github.com/ocenb/geo-alerts/internal/repos/guardian"
 	incidentrepo "github.com/ocenb/geo-alerts/internal/repos/incident"
 	locationrepo "github.com/ocenb/geo-alerts/internal/repos/location"
+	placerepo "github.com/ocenb/geo-alerts/internal/repos/place"
+	preferencesrepo "github.com/ocenb/geo-alerts/internal/repos/preferences"
+	webhookrepo "github.com/ocenb/geo-alerts/internal/repos/webhook"
+	alertsvc "github.com/ocenb/geo-alerts/internal/services/alert"
+	broadcastsvc "github.com/ocenb/geo-alerts/internal/services/broadcast"
+	checkinsvc "github.com/ocenb/geo-alerts/internal/services/checkin"
+	devicesvc "github.com/ocenb/geo-alerts/internal/services/device"
+	escalationsvc "github.com/ocenb/geo-alerts/internal/services/escalation"
+	guardiansvc "github.com/ocenb/geo-alerts/internal/services/guardian"
 	incidentsvc "github.com/ocenb/geo-alerts/internal/services/incident"
 	locationsvc "github.com/ocenb/geo-alerts/internal/services/location"
+	placesvc "github.com/ocenb/geo-alerts/internal/services/place"
+	preferencessvc "github.com/ocenb/geo-alerts/internal/services/preferences"
+	queuesvc "github.com/ocenb/geo-alerts/internal/services/queue"
+	webhooksvc "github.com/ocenb/geo-alerts/internal/services/webhook"
 	"github.com/ocenb/geo-alerts/internal/storage/cache"
 	"github.com/ocenb/geo-alerts/internal/storage/migrator"
 	"github.com/ocenb/geo-alerts/internal/storage/postgres"
 	"github.com/ocenb/geo-alerts/internal/storage/transactor"
+	"github.com/ocenb/geo-alerts/internal/workers/ack"
+	"github.com/ocenb/geo-alerts/internal/workers/broadcast"
+	"github.com/ocenb/geo-alerts/internal/workers/digest"
+	"github.com/ocenb/geo-alerts/internal/workers/escalation"
+	"github.com/ocenb/geo-alerts/internal/workers/notify"
+	"github.com/ocenb/geo-alerts/internal/workers/places"
+	"github.com/ocenb/geo-alerts/internal/workers/reminder"
+	"github.com/ocenb/geo-alerts/internal/workers/retroactive"
 	"github.com/ocenb/geo-alerts/internal/workers/webhook"
 	"github.com/ocenb/geo-alerts/migrations"
 	swaggerFiles "github.com/swaggo/files"
@@ -110,7 +149,18 @@ func run() int {
 		}
 	}()
 
-	queueClient, err := queue.NewClient(cfg.Redis, cfg.Queue)
+	webhookRepo := webhookrepo.New(tm)
+	digestRepo := digestrepo.New(cacheClient)
+	deviceRepo := devicerepo.New(tm)
+	broadcastRepo := broadcastrepo.New(tm)
+	checkinRepo := checkinrepo.New(tm)
+	guardianRepo := guardianrepo.New(tm)
+	prefsRepo := preferencesrepo.New(tm)
+	placeRepo := placerepo.New(tm)
+	escalationRepo := escalationrepo.New(tm)
+	alertRepo := alertrepo.New(tm)
+
+	queueClient, err := queue.NewClient(log, cfg.Redis, cfg.Queue, cfg.Webhook, cfg.Notify, webhookRepo, digestRepo, deviceRepo, guardianRepo, alertRepo, prefsRepo)
 	if err != nil {
 		log.Error("initialization failed", logattr.Err(err))
 		return 1
@@ -121,15 +171,56 @@ func run() int {
 		}
 	}()
 
+	queueInspector := queue.NewInspector(cfg.Redis)
+	defer func() {
+		if err := queueInspector.Close(); err != nil {
+			log.Error("failed to close redis queue inspector", logattr.Err(err))
+		}
+	}()
+
 	cacheRepo := cacherepo.New(cfg.Cache, cacheClient)
 	incRepo := incidentrepo.New(tm)
 	locationRepo := locationrepo.New(tm)
 
-	incService := incidentsvc.New(log, cfg.App, incRepo, cacheRepo)
-	locationService := locationsvc.New(log, cfg.AsyncJobTimeout, locationRepo, incRepo, cacheRepo, queueClient)
+	webhookWorker := webhook.New(log, cfg.Webhook, webhookRepo, webhookRepo)
+	digestWorker := digest.New(log, cfg.Webhook.DigestSampleUsers, webhookRepo, digestRepo, queueClient)
+	webhookNotifyWorker := notify.New(log, notify.NewWebhookChannel(cfg.Notify), broadcastRepo)
+	emailNotifyWorker := notify.New(log, notify.NewSMTPChannel(cfg.Notify), broadcastRepo)
+	smsNotifyWorker := notify.New(log, notify.NewSMSChannel(cfg.Notify), broadcastRepo)
+	pushNotifyWorker := notify.New(log, notify.NewPushChannel(cfg.Notify, deviceRepo), broadcastRepo)
+	retroactiveWorker := retroactive.New(log, incRepo, locationRepo, queueClient)
+	placesWorker := places.New(log, placeRepo, queueClient)
+	escalationWorker := escalation.New(log, incRepo, escalationRepo, queueClient)
+	escalationCancelWorker := escalation.NewCancelHandler(log, escalationRepo, queueClient)
+	ackWorker := ack.New(log, cfg.Notify.AckAction, alertRepo, queueClient)
+	reminderWorker := reminder.New(log, cfg.App, incRepo, checkinRepo, deviceRepo, queueClient)
+	broadcastWorker := broadcast.New(log, queue.PushPlatforms(cfg.Notify), broadcastRepo, locationRepo, deviceRepo, queueClient)
+
+	incService := incidentsvc.New(log, cfg.App, incRepo, cacheRepo, queueClient)
+	locationService := locationsvc.New(log, cfg.Location, cfg.AsyncJobTimeout, locationRepo, incRepo, cacheRepo, placeRepo, escalationRepo, queueClient)
+	webhookService := webhooksvc.New(log, cfg.Webhook.SecretRotationGrace, webhookRepo, webhookRepo, queueClient, webhookWorker)
+	queueService := queuesvc.New(log, queueInspector)
+	deviceService := devicesvc.New(log, deviceRepo)
+	checkinService := checkinsvc.New(log, cfg.App, checkinRepo, incRepo, queueClient)
+	guardianService := guardiansvc.New(log, guardianRepo)
+	prefsService := preferencessvc.New(log, prefsRepo)
+	placeService := placesvc.New(log, placeRepo, queueClient)
+	escalationService := escalationsvc.New(log, escalationRepo)
+	alertService := alertsvc.New(log, alertRepo, incRepo)
+	broadcastService := broadcastsvc.New(log, cfg.App.BroadcastRecency, broadcastRepo, incRepo, queueClient)
 
 	incHandler := incidenthandler.New(incService)
 	locationHandler := locationhandler.New(locationService)
+	webhookHandler := webhookhandler.New(webhookService)
+	queueHandler := queuehandler.New(queueService)
+	deviceHandler := devicehandler.New(deviceService)
+	broadcastHandler := broadcasthandler.New(broadcastService)
+	checkinHandler := checkinhandler.New(checkinService)
+	guardianHandler := guardianhandler.New(guardianService)
+	prefsHandler := preferenceshandler.New(prefsService)
+	placeHandler := placehandler.New(placeService)
+	escalationHandler := escalationhandler.New(escalationService)
+	alertHandler := alerthandler.New(alertService)
 	systemHandler := systemhandler.New()
 
 	if cfg.Environment == "prod" {
@@ -145,12 +236,38 @@ func run() int {
 	apiWithAuth.Use(middlewares.Auth(cfg.App.APIKey))
 
 	incHandler.RegisterRoutes(apiWithAuth)
+	webhookHandler.RegisterRoutes(apiWithAuth)
+	queueHandler.RegisterRoutes(apiWithAuth)
+	broadcastHandler.RegisterRoutes(apiWithAuth)
+	checkinHandler.RegisterRoutes(apiWithAuth)
+	guardianHandler.RegisterRoutes(apiWithAuth)
+	escalationHandler.RegisterRoutes(apiWithAuth)
+	alertHandler.RegisterRoutes(apiWithAuth)
 	locationHandler.RegisterRoutes(api)
+	deviceHandler.RegisterRoutes(api)
+	checkinHandler.RegisterPublicRoutes(api)
+	guardianHandler.RegisterPublicRoutes(api)
+	alertHandler.RegisterPublicRoutes(api)
+	prefsHandler.RegisterRoutes(api)
+	placeHandler.RegisterRoutes(api)
 	systemHandler.RegisterRoutes(api)
 	api.GET("/docs/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-	webhookWorker := webhook.New(log, cfg.Webhook)
-	queueServer := queue.NewServer(log, logger.NewAsynqAdapter(log), cfg.Redis, cfg.Queue, webhookWorker)
+	queueServer := queue.NewServer(log, logger.NewAsynqAdapter(log), cfg.Redis, cfg.Queue, queue.Workers{
+		Webhook:             webhookWorker,
+		Digest:              digestWorker,
+		WebhookNotification: webhookNotifyWorker,
+		EmailNotification:   emailNotifyWorker,
+		SMSNotification:     smsNotifyWorker,
+		PushNotification:    pushNotifyWorker,
+		Broadcast:           broadcastWorker,
+		RetroactiveAlert:    retroactiveWorker,
+		PlaceAlert:          placesWorker,
+		Escalation:          escalationWorker,
+		EscalationCancel:    escalationCancelWorker,
+		AlertAck:            ackWorker,
+		CheckinReminder:     reminderWorker,
+	})
 	queueServerErrors := make(chan error, 1)
 	go func() {
 		queueServerErrors <- queueServer.Run()
@@ -7,6 +7,8 @@ import (
 	"net/http"
 	"os"
 	"time"
+
+	"github.com/ocenb/geo-alerts/pkg/webhooksig"
 )
 
 func main() {
@@ -14,6 +16,20 @@ func main() {
 	if port == "" {
 		port = "9090"
 	}
+
+	var secrets []string
+	for _, env := range []string{"WEBHOOK_SECRET", "WEBHOOK_PREVIOUS_SECRET"} {
+		if secret := os.Getenv(env); secret != "" {
+			secrets = append(secrets, secret)
+		}
+	}
+	var verifier *webhooksig.Verifier
+	if len(secrets) > 0 {
+		verifier = webhooksig.NewVerifier(webhooksig.DefaultTolerance, secrets...)
+	} else {
+		log.Printf("WEBHOOK_SECRET is not set, signatures will not be checked")
+	}
+
 	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -27,6 +43,14 @@ func main() {
 			return
 		}
 
+		if verifier != nil {
+			if err := verifier.Verify(r.Header, body); err != nil {
+				log.Printf("Rejected webhook: %v", err)
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+		}
+
 		log.Printf("\n--- NEW WEBHOOK RECEIVED [%s] ---", time.Now().Format(time.RFC3339))
 		log.Printf("Headers: %v", r.Header)
 		log.Printf("Body: %s", string(body))
@@ -15,21 +15,63 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/incidents": {
-            "get": {
-                "security": [
+        "/alerts/{id}/ack": {
+            "post": {
+                "description": "Called by webhook receivers or the user's app with the alert_id the alert came with. Stores the time\nto acknowledge and stops the alert from being resent or escalated. Only the first acknowledgement counts.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "alerts"
+                ],
+                "summary": "Acknowledge an alert",
+                "parameters": [
                     {
-                        "ApiKeyAuth": []
+                        "type": "string",
+                        "description": "Alert ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
-                "description": "Get a paginated list of incidents.",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Alert"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Alert not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/broadcasts": {
+            "get": {
+                "description": "Newest first.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "incidents"
+                    "broadcasts"
                 ],
-                "summary": "List incidents",
+                "summary": "List broadcasts",
                 "parameters": [
                     {
                         "type": "integer",
@@ -50,7 +92,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Incident"
+                                "$ref": "#/definitions/models.Broadcast"
                             }
                         }
                     },
@@ -66,15 +108,15 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     }
-                }
-            },
-            "post": {
+                },
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
-                ],
-                "description": "Creates a dangerous zone incident. Returns the created incident.",
+                ]
+            },
+            "post": {
+                "description": "Pushes the message to the registered devices of every user whose last location, no older than recency_minutes,\nis inside the incident zone or the given area (bbox, polygon or circle). Recipients are looked up in the background,\nfollow the progress with GET /broadcasts/{id}.",
                 "consumes": [
                     "application/json"
                 ],
@@ -82,25 +124,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "incidents"
+                    "broadcasts"
                 ],
-                "summary": "Create a new incident",
+                "summary": "Broadcast a message to an area",
                 "parameters": [
                     {
-                        "description": "Incident parameters",
+                        "description": "Broadcast",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/incident.CreateReq"
+                            "$ref": "#/definitions/broadcast.CreateReq"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
-                            "$ref": "#/definitions/models.Incident"
+                            "$ref": "#/definitions/models.Broadcast"
                         }
                     },
                     "400": {
@@ -109,8 +151,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Incident already exists in this area",
+                    "404": {
+                        "description": "Incident not found",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -121,32 +163,50 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     }
-                }
-            }
-        },
-        "/incidents/stats": {
-            "get": {
+                },
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
-                ],
-                "description": "Returns statistics regarding unique users near dangerous zones.",
+                ]
+            }
+        },
+        "/broadcasts/{id}": {
+            "get": {
+                "description": "Returns the broadcast with the number of users found, of those without a device, and of notifications sent and failed.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "incidents"
+                    "broadcasts"
+                ],
+                "summary": "Get broadcast progress",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Broadcast ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get incident statistics",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Stats"
-                            }
+                            "$ref": "#/definitions/models.Broadcast"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Broadcast not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
                     "500": {
@@ -155,48 +215,74 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     }
-                }
-            }
-        },
-        "/incidents/{id}": {
-            "get": {
+                },
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
-                ],
-                "description": "Returns detailed information about a specific incident.",
+                ]
+            }
+        },
+        "/broadcasts/{id}/recipients": {
+            "get": {
+                "description": "Lists the devices the broadcast was pushed to with the outcome of each notification.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "incidents"
+                    "broadcasts"
                 ],
-                "summary": "Get incident by ID",
+                "summary": "Broadcast delivery report",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Incident ID",
+                        "description": "Broadcast ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "sent",
+                            "failed"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit (default 10)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset (default 0)",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Incident"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.BroadcastRecipient"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid ID format",
+                        "description": "Invalid query parameters or ID",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Incident not found",
+                        "description": "Broadcast not found",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -207,15 +293,17 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     }
-                }
-            },
-            "put": {
+                },
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
-                ],
-                "description": "Updates location or radius of an existing incident.",
+                ]
+            }
+        },
+        "/devices": {
+            "post": {
+                "description": "Registers a device the user's danger alerts are pushed to. Registering a known token again\nupdates its platform and locale and moves it to the given user.",
                 "consumes": [
                     "application/json"
                 ],
@@ -223,24 +311,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "incidents"
+                    "devices"
                 ],
-                "summary": "Update incident",
+                "summary": "Register a device",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Incident ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Update parameters",
+                        "description": "Device",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/incident.UpdateReq"
+                            "$ref": "#/definitions/device.RegisterReq"
                         }
                     }
                 ],
@@ -248,23 +329,11 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Incident"
+                            "$ref": "#/definitions/models.Device"
                         }
                     },
                     "400": {
-                        "description": "Invalid input or ID",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Incident not found",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
-                        }
-                    },
-                    "409": {
-                        "description": "Conflict with another incident",
+                        "description": "Invalid input",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -276,25 +345,32 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/devices/{token}": {
             "delete": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
+                "description": "Stops pushing alerts to the device, e.g. when the user signs out.",
+                "produces": [
+                    "application/json"
                 ],
-                "description": "Soft deletes (deactivates) an incident by ID.",
                 "tags": [
-                    "incidents"
+                    "devices"
                 ],
-                "summary": "Deactivate incident",
+                "summary": "Unregister a device",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Incident ID",
-                        "name": "id",
+                        "type": "string",
+                        "description": "Push token",
+                        "name": "token",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User the device is registered for",
+                        "name": "user_id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -302,13 +378,13 @@ const docTemplate = `{
                         "description": "No Content"
                     },
                     "400": {
-                        "description": "Invalid ID format",
+                        "description": "Invalid input",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Incident not found",
+                        "description": "Device not found",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -322,9 +398,43 @@ const docTemplate = `{
                 }
             }
         },
-        "/location/check": {
-            "post": {
-                "description": "Checks if the user's coordinates are within any active dangerous zone.",
+        "/escalation-policies": {
+            "get": {
+                "description": "Returns the policy of each severity that has one. Users in zones of other severities are alerted once.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "escalation"
+                ],
+                "summary": "List escalation policies",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.EscalationPolicy"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/escalation-policies/{severity}": {
+            "put": {
+                "description": "Sets how long a user may stay inside the zone of an incident of the severity, as told by consecutive checks,\nbefore they are alerted again and before duty officers are told over their channels. The follow-ups are dropped\nwhen the user leaves the zone or the incident is deactivated. The policy applies to users entering a zone from now on.",
                 "consumes": [
                     "application/json"
                 ],
@@ -332,29 +442,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "location"
+                    "escalation"
                 ],
-                "summary": "Check user location",
+                "summary": "Set the escalation policy of a severity",
                 "parameters": [
                     {
-                        "description": "Location parameters",
+                        "type": "integer",
+                        "description": "Incident severity (1-5)",
+                        "name": "severity",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Policy",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/location.CheckReq"
+                            "$ref": "#/definitions/escalation.UpdateReq"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.CheckLocationResult"
+                            "$ref": "#/definitions/models.EscalationPolicy"
                         }
                     },
                     "400": {
-                        "description": "Invalid input",
+                        "description": "Invalid input or severity",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -365,56 +482,3601 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     }
-                }
-            }
-        },
-        "/system/health": {
-            "get": {
-                "description": "Checks if the API service is running.",
-                "produces": [
-                    "application/json"
-                ],
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Users entering zones of the severity are alerted once again. Follow-ups already scheduled still run.",
                 "tags": [
-                    "system"
+                    "escalation"
                 ],
-                "summary": "Health check",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                "summary": "Delete the escalation policy of a severity",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Incident severity (1-5)",
+                        "name": "severity",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Invalid severity",
                         "schema": {
-                            "$ref": "#/definitions/models.HealthCheckResult"
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Policy not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/guardian-groups": {
+            "get": {
+                "description": "Newest first, with guardians but without members.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "guardians"
+                ],
+                "summary": "List guardian groups",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Limit (default 10)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset (default 0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.GuardianGroup"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Links guardians, such as parents or a school, to the users they look after. When a member is detected\nin a danger zone, every guardian of the group is alerted over their channel, naming the member and the incidents.\nMembers are added pending and are alerted on only once they consent.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "guardians"
+                ],
+                "summary": "Create a guardian group",
+                "parameters": [
+                    {
+                        "description": "Group",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/guardian.CreateReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.GuardianGroup"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/guardian-groups/memberships": {
+            "get": {
+                "description": "Returns the groups the user was added to with their consent, so that they can review pending invitations.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "guardians"
+                ],
+                "summary": "List a user's guardian groups",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.GroupMember"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/guardian-groups/{id}": {
+            "get": {
+                "description": "Returns the group with its guardians and members and the consent of each.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "guardians"
+                ],
+                "summary": "Get a guardian group",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.GuardianGroup"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Group not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Stops alerting the guardians of the group.",
+                "tags": [
+                    "guardians"
+                ],
+                "summary": "Delete a guardian group",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Group not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/guardian-groups/{id}/consent": {
+            "post": {
+                "description": "Opts the member in to the guardians of the group being alerted when they are in a danger zone.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "guardians"
+                ],
+                "summary": "Consent to guardian alerts",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Member",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/guardian.ConsentReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.GroupMember"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input or ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User isn't a member of the group",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Opts the member out. The member stays in the group and can consent again.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "guardians"
+                ],
+                "summary": "Revoke consent to guardian alerts",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Member",
+                        "name": "user_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.GroupMember"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input or ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User isn't a member of the group",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/guardian-groups/{id}/members": {
+            "post": {
+                "description": "Adds the users pending their consent. Users already in the group keep theirs.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "guardians"
+                ],
+                "summary": "Add members to a guardian group",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Members",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/guardian.AddMembersReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.GuardianGroup"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input or ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Group not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/guardian-groups/{id}/members/{user_id}": {
+            "delete": {
+                "tags": [
+                    "guardians"
+                ],
+                "summary": "Remove a member from a guardian group",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Member not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/incidents": {
+            "get": {
+                "description": "Get a paginated list of incidents.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "incidents"
+                ],
+                "summary": "List incidents",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Limit (default 10)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset (default 0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Incident"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Creates a dangerous zone incident. Returns the created incident.\nWith alert_recent_users, users whose last check within the look-back window is inside the zone are alerted right away.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "incidents"
+                ],
+                "summary": "Create a new incident",
+                "parameters": [
+                    {
+                        "description": "Incident parameters",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/incident.CreateReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Incident"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Incident already exists in this area",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/incidents/stats": {
+            "get": {
+                "description": "Returns statistics regarding unique users near dangerous zones.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "incidents"
+                ],
+                "summary": "Get incident statistics",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Stats"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/incidents/{id}": {
+            "get": {
+                "description": "Returns detailed information about a specific incident.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "incidents"
+                ],
+                "summary": "Get incident by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Incident ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Incident"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Incident not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "Updates location, radius or approach buffer of an existing incident. Omitted approach buffer, severity\nand category are kept, clear_approach_buffer drops the buffer.\nWith alert_recent_users, users whose last check within the look-back window is inside the new zone\nand who haven't been alerted about the incident yet are alerted right away.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "incidents"
+                ],
+                "summary": "Update incident",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Incident ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update parameters",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/incident.UpdateReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Incident"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input or ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Incident not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict with another incident",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Soft deletes (deactivates) an incident by ID.",
+                "tags": [
+                    "incidents"
+                ],
+                "summary": "Deactivate incident",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Incident ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Incident not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/incidents/{id}/checkin": {
+            "post": {
+                "description": "Lets a user report that they are safe, need help or have evacuated. A later check-in replaces an earlier one.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "checkins"
+                ],
+                "summary": "Check in during an incident",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Incident ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Check-in",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/checkin.CheckinReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Checkin"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input or ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Incident not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Incident is not active",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/incidents/{id}/checkin-reminders": {
+            "post": {
+                "description": "Queues a push to the devices of the users seen inside the zone who haven't checked in.",
+                "tags": [
+                    "checkins"
+                ],
+                "summary": "Remind unaccounted users to check in",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Incident ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted"
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Incident not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Incident is not active or a reminder is already queued",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/incidents/{id}/unaccounted": {
+            "get": {
+                "description": "Counts check-ins by status and lists the users seen inside the zone within the stats window\nwho haven't checked in, most recently seen first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "checkins"
+                ],
+                "summary": "Unaccounted users of an incident",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Incident ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit (default 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset (default 0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.UnaccountedReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters or ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Incident not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/incidents/{id}/unacknowledged-alerts": {
+            "get": {
+                "description": "Counts the alerts sent about the incident and the acknowledged ones, with the average time to acknowledge,\nand lists the alerts nobody acknowledged, oldest first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "alerts"
+                ],
+                "summary": "Unacknowledged alerts of an incident",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Incident ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit (default 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset (default 0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.AckReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters or ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Incident not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/location/check": {
+            "post": {
+                "description": "Checks if the user's coordinates are within any active dangerous zone.\nWith accuracy, zones overlapping the GPS uncertainty circle are reported as possibly inside.\nWith speed and heading, also reports zones the user is expected to enter soon.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "location"
+                ],
+                "summary": "Check user location",
+                "parameters": [
+                    {
+                        "description": "Location parameters",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/location.CheckReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.CheckLocationResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/queues": {
+            "get": {
+                "description": "Returns every queue with task counts per state.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "queues"
+                ],
+                "summary": "List task queues",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.QueueInfo"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/queues/{queue}/archived/replay": {
+            "post": {
+                "description": "Moves archived tasks that match the filter back to pending. An empty filter replays the whole archive.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "queues"
+                ],
+                "summary": "Replay archived tasks",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Queue name",
+                        "name": "queue",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Filter by the last failure",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/queue.ReplayReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReplayResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Queue not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/queues/{queue}/tasks": {
+            "get": {
+                "description": "Returns pending, retry or archived tasks with decoded webhook payloads.\nError and time filters apply to the last failure of a task.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "queues"
+                ],
+                "summary": "List tasks in a queue",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Queue name",
+                        "name": "queue",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "retry",
+                            "archived"
+                        ],
+                        "type": "string",
+                        "description": "Task state (default archived)",
+                        "name": "state",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Substring of the last error",
+                        "name": "error",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last failure at or after this time (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last failure before this time (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit (default 10)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset (default 0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.QueueTask"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Queue not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/queues/{queue}/tasks/{id}": {
+            "delete": {
+                "tags": [
+                    "queues"
+                ],
+                "summary": "Delete a task",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Queue name",
+                        "name": "queue",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Queue or task not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Task is being processed",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/queues/{queue}/tasks/{id}/run": {
+            "post": {
+                "description": "Moves a retry or archived task to pending so a worker picks it up immediately.",
+                "tags": [
+                    "queues"
+                ],
+                "summary": "Run a task now",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Queue name",
+                        "name": "queue",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Queue or task not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Task is already pending or being processed",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/system/health": {
+            "get": {
+                "description": "Checks if the API service is running.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "system"
+                ],
+                "summary": "Health check",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.HealthCheckResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{user_id}/places": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "places"
+                ],
+                "summary": "List saved places",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.SavedPlace"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Saves a named place, e.g. home or school, whose owner is alerted when an active incident zone\nreaches within its radius, wherever the user is themselves. A zone already reaching it alerts right away.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "places"
+                ],
+                "summary": "Save a place",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Place",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/place.SaveReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.SavedPlace"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "User already has a place with this name",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{user_id}/places/{id}": {
+            "put": {
+                "description": "Renames, moves or resizes the place. Zones reaching it at its new extent are alerted about again.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "places"
+                ],
+                "summary": "Update a saved place",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Place ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Place",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/place.SaveReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SavedPlace"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input or ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Place not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "User already has a place with this name",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "places"
+                ],
+                "summary": "Delete a saved place",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Place ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Place not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{user_id}/preferences": {
+            "get": {
+                "description": "Returns the preferences of the user, or the defaults (every alert, any time) if they never set any.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "preferences"
+                ],
+                "summary": "Get notification preferences",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.UserPreferences"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replaces the preferences of the user. Alerts the user opted out of, about incidents below min_severity\nor in a muted category, or falling within quiet hours in the user's timezone aren't sent, and the reason is recorded.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "preferences"
+                ],
+                "summary": "Update notification preferences",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Preferences",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/preferences.UpdateReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.UserPreferences"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/deliveries": {
+            "get": {
+                "description": "Returns logged delivery attempts, newest first. All filters are optional.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "List webhook delivery attempts",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Subscription ID (0 = WEBHOOK_URL receiver)",
+                        "name": "subscription_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID from the payload",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Task ID, shared by all attempts of a delivery",
+                        "name": "task_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID, shared by all deliveries of an event",
+                        "name": "event_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Hex SHA-256 of the request body",
+                        "name": "payload_hash",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "success",
+                            "failed"
+                        ],
+                        "type": "string",
+                        "description": "Attempt outcome",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attempts at or after this time (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attempts before this time (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit (default 10)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset (default 0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.WebhookDelivery"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/webhooks/deliveries/{id}/redeliver": {
+            "post": {
+                "description": "Enqueues the payload of a logged delivery to the same subscriber again, regardless of its filters.\nAttempts of the new task are logged with redelivery_of set to this delivery.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Resend a webhook delivery",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Delivery ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/models.Redelivery"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Delivery not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/webhooks/outbound": {
+            "get": {
+                "description": "Returns the circuit breaker of every destination host and the rate limiter of every subscriber\nthe webhook worker of this instance has sent to. Deliveries are put off without using up retries\nwhile a breaker is open or a subscriber's rate limit is reached.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get outbound throttling state",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.OutboundStats"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/webhooks/subscriptions": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "List webhook subscriptions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Limit (default 10)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset (default 0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.WebhookSubscription"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Registers a receiver URL for alerts. Empty events list subscribes to all alert events,\nincident lifecycle events (incident.created, incident.updated, incident.deactivated) have to be listed.\nArea (bbox, polygon or circle), min_severity and categories narrow down the alerts delivered.\nrate_limit_rps caps requests per second to the receiver, omit it for the server default.\npayload_format picks the bare event data (legacy) or a CloudEvents 1.0 structured or binary mode message.\ndigest batches alerts into one delivery every interval seconds or max_alerts alerts, whichever comes first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Create a webhook subscription",
+                "parameters": [
+                    {
+                        "description": "Subscription parameters",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/webhook.CreateReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookSubscription"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/webhooks/subscriptions/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get webhook subscription by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookSubscription"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "Updates URL, secret, enabled flag or filters of a subscription.\nA new secret replaces the current one, which keeps signing deliveries alongside it for the rotation grace period.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Update webhook subscription",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update parameters",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/webhook.UpdateReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookSubscription"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input or ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Delete webhook subscription",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        }
+    },
+    "definitions": {
+        "broadcast.CreateReq": {
+            "type": "object",
+            "required": [
+                "message",
+                "title"
+            ],
+            "properties": {
+                "area": {
+                    "description": "or an area of your own",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/utils.AreaReq"
+                        }
+                    ]
+                },
+                "incident_id": {
+                    "description": "target the incident zone",
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "message": {
+                    "type": "string",
+                    "maxLength": 2000
+                },
+                "recency_minutes": {
+                    "description": "omit for the server default",
+                    "type": "integer",
+                    "maximum": 10080,
+                    "minimum": 1
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "checkin.CheckinReq": {
+            "type": "object",
+            "required": [
+                "status",
+                "user_id"
+            ],
+            "properties": {
+                "note": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "safe",
+                        "need_help",
+                        "evacuated"
+                    ]
+                },
+                "user_id": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                }
+            }
+        },
+        "device.RegisterReq": {
+            "type": "object",
+            "required": [
+                "platform",
+                "token",
+                "user_id"
+            ],
+            "properties": {
+                "locale": {
+                    "description": "default en",
+                    "type": "string"
+                },
+                "platform": {
+                    "type": "string",
+                    "enum": [
+                        "android",
+                        "ios"
+                    ]
+                },
+                "token": {
+                    "type": "string",
+                    "maxLength": 4096,
+                    "minLength": 1
+                },
+                "user_id": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                }
+            }
+        },
+        "escalation.UpdateReq": {
+            "type": "object",
+            "properties": {
+                "escalate_after": {
+                    "description": "seconds inside before duty officers are told, 0 = never",
+                    "type": "integer",
+                    "maximum": 86400,
+                    "minimum": 0,
+                    "example": 1800
+                },
+                "realert_after": {
+                    "description": "seconds inside before the user is alerted again, 0 = never",
+                    "type": "integer",
+                    "maximum": 86400,
+                    "minimum": 0,
+                    "example": 600
+                }
+            }
+        },
+        "guardian.AddMembersReq": {
+            "type": "object",
+            "required": [
+                "user_ids"
+            ],
+            "properties": {
+                "user_ids": {
+                    "type": "array",
+                    "maxItems": 1000,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "guardian.ConsentReq": {
+            "type": "object",
+            "required": [
+                "user_id"
+            ],
+            "properties": {
+                "user_id": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                }
+            }
+        },
+        "guardian.CreateReq": {
+            "type": "object",
+            "required": [
+                "guardians",
+                "name"
+            ],
+            "properties": {
+                "guardians": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/guardian.GuardianReq"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "user_ids": {
+                    "description": "added pending their consent",
+                    "type": "array",
+                    "maxItems": 1000,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "guardian.GuardianReq": {
+            "type": "object",
+            "required": [
+                "address",
+                "channel"
+            ],
+            "properties": {
+                "address": {
+                    "description": "URL, email address or E.164 phone number, depending on the channel",
+                    "type": "string",
+                    "maxLength": 2048
+                },
+                "channel": {
+                    "type": "string",
+                    "enum": [
+                        "webhook",
+                        "email",
+                        "sms"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "incident.CreateReq": {
+            "type": "object",
+            "required": [
+                "latitude",
+                "longitude",
+                "radius"
+            ],
+            "properties": {
+                "alert_recent_users": {
+                    "description": "alert users last seen in the zone within the look-back window",
+                    "type": "boolean"
+                },
+                "approach_buffer": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "category": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "radius": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "severity": {
+                    "description": "default 1",
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1
+                }
+            }
+        },
+        "incident.UpdateReq": {
+            "type": "object",
+            "required": [
+                "latitude",
+                "longitude",
+                "radius"
+            ],
+            "properties": {
+                "alert_recent_users": {
+                    "description": "alert users last seen in the new zone within the look-back window, except those already alerted",
+                    "type": "boolean"
+                },
+                "approach_buffer": {
+                    "description": "omit to keep the current buffer",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "category": {
+                    "description": "omit to keep the current category",
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "clear_approach_buffer": {
+                    "description": "drop the buffer to use the default approach distance",
+                    "type": "boolean"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "radius": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "severity": {
+                    "description": "omit to keep the current severity",
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1
+                }
+            }
+        },
+        "location.CheckReq": {
+            "type": "object",
+            "required": [
+                "latitude",
+                "longitude",
+                "user_id"
+            ],
+            "properties": {
+                "accuracy_meters": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "heading": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "speed": {
+                    "description": "meters per second",
+                    "type": "number",
+                    "minimum": 0
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                }
+            }
+        },
+        "models.AckReport": {
+            "type": "object",
+            "properties": {
+                "acknowledged": {
+                    "type": "integer"
+                },
+                "avg_time_to_ack": {
+                    "description": "seconds",
+                    "type": "number"
+                },
+                "incident_id": {
+                    "type": "integer"
+                },
+                "sent": {
+                    "type": "integer"
+                },
+                "unacknowledged": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Alert"
+                    }
+                }
+            }
+        },
+        "models.Alert": {
+            "type": "object",
+            "properties": {
+                "acknowledged_at": {
+                    "description": "first acknowledgement",
+                    "type": "string"
+                },
+                "alert_type": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "followed_up_at": {
+                    "description": "when it was resent or escalated for being unacknowledged",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "incident_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "time_to_ack": {
+                    "description": "seconds from sending to the first acknowledgement",
+                    "type": "number"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Area": {
+            "type": "object",
+            "properties": {
+                "center": {
+                    "description": "circle",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.GeoPoint"
+                        }
+                    ]
+                },
+                "north_east": {
+                    "description": "bbox, may have a lower longitude than south_west across the antimeridian",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.GeoPoint"
+                        }
+                    ]
+                },
+                "points": {
+                    "description": "polygon ring, closing point is implied",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.GeoPoint"
+                    }
+                },
+                "radius": {
+                    "description": "circle, meters",
+                    "type": "integer"
+                },
+                "south_west": {
+                    "description": "bbox",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.GeoPoint"
+                        }
+                    ]
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "bbox",
+                        "polygon",
+                        "circle"
+                    ]
+                }
+            }
+        },
+        "models.BreakerStats": {
+            "type": "object",
+            "properties": {
+                "destination": {
+                    "type": "string"
+                },
+                "failures": {
+                    "description": "consecutive failed requests",
+                    "type": "integer"
+                },
+                "opened_at": {
+                    "description": "set unless closed",
+                    "type": "string"
+                },
+                "retry_at": {
+                    "description": "when a probe request is let through",
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string",
+                    "enum": [
+                        "closed",
+                        "open",
+                        "half_open"
+                    ]
+                }
+            }
+        },
+        "models.Broadcast": {
+            "type": "object",
+            "properties": {
+                "area": {
+                    "$ref": "#/definitions/models.Area"
+                },
+                "completed_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "incident_id": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "recency_seconds": {
+                    "description": "how recent a user's last location has to be",
+                    "type": "integer"
+                },
+                "sent": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "sending",
+                        "completed",
+                        "failed"
+                    ]
+                },
+                "title": {
+                    "type": "string"
+                },
+                "total": {
+                    "description": "notifications, one per device",
+                    "type": "integer"
+                },
+                "unreachable": {
+                    "description": "of the users, those without a device to push to",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "users": {
+                    "description": "found in the area",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.BroadcastRecipient": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "broadcast_id": {
+                    "type": "integer"
+                },
+                "device_id": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "platform": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "sent",
+                        "failed"
+                    ]
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CheckLocationResult": {
+            "type": "object",
+            "properties": {
+                "accuracy_meters": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "crossed": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.IncidentShort"
+                    }
+                },
+                "dangers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.IncidentShort"
+                    }
+                },
+                "escape": {
+                    "$ref": "#/definitions/models.EscapeRoute"
+                },
+                "has_danger": {
+                    "type": "boolean"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "matches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ZoneMatch"
+                    }
+                },
+                "nearby": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.NearbyIncident"
+                    }
+                },
+                "predicted": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PredictedIncident"
+                    }
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Checkin": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "incident_id": {
+                    "type": "integer"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "safe",
+                        "need_help",
+                        "evacuated"
+                    ]
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CheckinCounts": {
+            "type": "object",
+            "properties": {
+                "evacuated": {
+                    "type": "integer"
+                },
+                "need_help": {
+                    "type": "integer"
+                },
+                "safe": {
+                    "type": "integer"
+                },
+                "unaccounted": {
+                    "description": "seen in the zone without checking in",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Device": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "locale": {
+                    "type": "string"
+                },
+                "platform": {
+                    "type": "string",
+                    "enum": [
+                        "android",
+                        "ios"
+                    ]
+                },
+                "token": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.DigestSettings": {
+            "type": "object",
+            "properties": {
+                "interval": {
+                    "description": "seconds, 0 = alerts are sent one by one",
+                    "type": "integer"
+                },
+                "max_alerts": {
+                    "description": "0 = flushed on interval only",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.EscalationPolicy": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "escalate_after": {
+                    "description": "seconds inside, 0 = no escalation",
+                    "type": "integer"
+                },
+                "realert_after": {
+                    "description": "seconds inside, 0 = no re-alert",
+                    "type": "integer"
+                },
+                "severity": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.EscapeRoute": {
+            "type": "object",
+            "properties": {
+                "bearing": {
+                    "description": "degrees clockwise from north",
+                    "type": "number"
+                },
+                "distance": {
+                    "description": "meters",
+                    "type": "number"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.GeoPoint": {
+            "type": "object",
+            "properties": {
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.GroupMember": {
+            "type": "object",
+            "properties": {
+                "consented_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "group_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "consented",
+                        "revoked"
+                    ]
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Guardian": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "description": "URL, email address or phone number, depending on the channel",
+                    "type": "string"
+                },
+                "channel": {
+                    "type": "string",
+                    "enum": [
+                        "webhook",
+                        "email",
+                        "sms"
+                    ]
+                },
+                "group_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.GuardianGroup": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "guardians": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Guardian"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.GroupMember"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.HealthCheckResult": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Incident": {
+            "type": "object",
+            "properties": {
+                "approach_buffer": {
+                    "type": "integer"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "radius": {
+                    "type": "integer"
+                },
+                "severity": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.IncidentShort": {
+            "type": "object",
+            "properties": {
+                "approach_buffer": {
+                    "type": "integer"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "radius": {
+                    "type": "integer"
+                },
+                "severity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.LimiterStats": {
+            "type": "object",
+            "properties": {
+                "allowed": {
+                    "description": "requests let through since start",
+                    "type": "integer"
+                },
+                "burst": {
+                    "type": "integer"
+                },
+                "rps": {
+                    "description": "0 = unlimited",
+                    "type": "number"
+                },
+                "subscription_id": {
+                    "description": "0 = WEBHOOK_URL receiver",
+                    "type": "integer"
+                },
+                "throttled": {
+                    "description": "tasks put off since start",
+                    "type": "integer"
+                },
+                "tokens": {
+                    "description": "requests that can go out right now, negative while reserved ones wait",
+                    "type": "number"
+                }
+            }
+        },
+        "models.NearbyIncident": {
+            "type": "object",
+            "properties": {
+                "approach_buffer": {
+                    "type": "integer"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "distance_to_edge": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "radius": {
+                    "type": "integer"
+                },
+                "severity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.OutboundStats": {
+            "type": "object",
+            "properties": {
+                "breakers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BreakerStats"
+                    }
+                },
+                "limiters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.LimiterStats"
+                    }
+                }
+            }
+        },
+        "models.PredictedIncident": {
+            "type": "object",
+            "properties": {
+                "approach_buffer": {
+                    "type": "integer"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "distance_to_entry": {
+                    "description": "meters along the projected path",
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "radius": {
+                    "type": "integer"
+                },
+                "severity": {
+                    "type": "integer"
+                },
+                "time_to_entry": {
+                    "description": "seconds from now",
+                    "type": "number"
+                }
+            }
+        },
+        "models.QueueInfo": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "integer"
+                },
+                "archived": {
+                    "type": "integer"
+                },
+                "failed": {
+                    "description": "today",
+                    "type": "integer"
+                },
+                "latency_ms": {
+                    "type": "integer"
+                },
+                "paused": {
+                    "type": "boolean"
+                },
+                "pending": {
+                    "type": "integer"
+                },
+                "processed": {
+                    "description": "today",
+                    "type": "integer"
+                },
+                "queue": {
+                    "type": "string"
+                },
+                "retry": {
+                    "type": "integer"
+                },
+                "scheduled": {
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.QueueTask": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "last_failed_at": {
+                    "type": "string"
+                },
+                "max_retry": {
+                    "type": "integer"
+                },
+                "next_process_at": {
+                    "type": "string"
+                },
+                "payload": {
+                    "description": "decoded webhook task, raw if it can't be decoded",
+                    "type": "object"
+                },
+                "queue": {
+                    "type": "string"
+                },
+                "retried": {
+                    "type": "integer"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.QuietHours": {
+            "type": "object",
+            "properties": {
+                "end": {
+                    "type": "string",
+                    "example": "07:00"
+                },
+                "start": {
+                    "type": "string",
+                    "example": "22:00"
+                }
+            }
+        },
+        "models.Redelivery": {
+            "type": "object",
+            "properties": {
+                "delivery_id": {
+                    "type": "integer"
+                },
+                "task_id": {
+                    "description": "attempts of the resent task are logged under this ID",
+                    "type": "string"
+                }
+            }
+        },
+        "models.ReplayResult": {
+            "type": "object",
+            "properties": {
+                "replayed": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.SavedPlace": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "home"
+                },
+                "radius": {
+                    "description": "meters, an incident zone reaching into it alerts the owner",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Stats": {
+            "type": "object",
+            "properties": {
+                "incident_id": {
+                    "type": "integer"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "user_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.UnaccountedReport": {
+            "type": "object",
+            "properties": {
+                "counts": {
+                    "$ref": "#/definitions/models.CheckinCounts"
+                },
+                "incident_id": {
+                    "type": "integer"
+                },
+                "seen": {
+                    "description": "users seen in the zone",
+                    "type": "integer"
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.UnaccountedUser"
+                    }
+                },
+                "window_seconds": {
+                    "description": "how far back users count as seen in the zone",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.UnaccountedUser": {
+            "type": "object",
+            "properties": {
+                "last_seen_at": {
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.UserPreferences": {
+            "type": "object",
+            "properties": {
+                "min_severity": {
+                    "type": "integer"
+                },
+                "muted_categories": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "opted_out": {
+                    "type": "boolean"
+                },
+                "quiet_hours": {
+                    "$ref": "#/definitions/models.QuietHours"
+                },
+                "timezone": {
+                    "description": "IANA name, quiet hours are in it",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "unset if the user never saved any",
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.WebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "attempt": {
+                    "description": "1 for the first try",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "event_id": {
+                    "description": "CloudEvents id, the same for retries and redeliveries",
+                    "type": "string"
+                },
+                "event_time": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "latency_ms": {
+                    "type": "integer"
+                },
+                "payload": {
+                    "description": "event data, the body in the legacy format",
+                    "type": "object"
+                },
+                "payload_hash": {
+                    "description": "hex SHA-256 of the request body",
+                    "type": "string"
+                },
+                "redelivery_of": {
+                    "description": "delivery this one was manually resent from",
+                    "type": "integer"
+                },
+                "response_body": {
+                    "description": "truncated",
+                    "type": "string"
+                },
+                "status_code": {
+                    "description": "nil if no response was received",
+                    "type": "integer"
+                },
+                "subscription_id": {
+                    "description": "0 = WEBHOOK_URL receiver",
+                    "type": "integer"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "task_id": {
+                    "type": "string"
+                },
+                "task_type": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.WebhookSubscription": {
+            "type": "object",
+            "properties": {
+                "area": {
+                    "description": "nil = anywhere",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Area"
                         }
+                    ]
+                },
+                "categories": {
+                    "description": "empty = all categories",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "digest": {
+                    "$ref": "#/definitions/models.DigestSettings"
+                },
+                "events": {
+                    "description": "empty = all alert events",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
                     }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_enabled": {
+                    "type": "boolean"
+                },
+                "min_severity": {
+                    "description": "0 = any severity",
+                    "type": "integer"
+                },
+                "payload_format": {
+                    "type": "string",
+                    "enum": [
+                        "legacy",
+                        "cloudevents_structured",
+                        "cloudevents_binary"
+                    ]
+                },
+                "previous_secret_expires_at": {
+                    "type": "string"
+                },
+                "rate_limit_rps": {
+                    "description": "outbound requests per second, nil = server default, 0 = unlimited",
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
                 }
             }
-        }
-    },
-    "definitions": {
-        "incident.CreateReq": {
+        },
+        "models.ZoneMatch": {
             "type": "object",
-            "required": [
-                "latitude",
-                "longitude",
-                "radius"
-            ],
             "properties": {
+                "approach_buffer": {
+                    "type": "integer"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
                 "latitude": {
                     "type": "number"
                 },
                 "longitude": {
                     "type": "number"
                 },
+                "match": {
+                    "type": "string",
+                    "enum": [
+                        "inside",
+                        "possibly_inside"
+                    ]
+                },
                 "radius": {
-                    "type": "integer",
-                    "minimum": 1
+                    "type": "integer"
+                },
+                "severity": {
+                    "type": "integer"
                 }
             }
         },
-        "incident.UpdateReq": {
+        "place.SaveReq": {
             "type": "object",
             "required": [
                 "latitude",
                 "longitude",
+                "name",
                 "radius"
             ],
             "properties": {
@@ -424,131 +4086,288 @@ const docTemplate = `{
                 "longitude": {
                     "type": "number"
                 },
+                "name": {
+                    "description": "unique per user",
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "home"
+                },
                 "radius": {
+                    "description": "meters",
                     "type": "integer",
+                    "maximum": 50000,
                     "minimum": 1
                 }
             }
         },
-        "location.CheckReq": {
+        "preferences.QuietHoursReq": {
             "type": "object",
             "required": [
-                "latitude",
-                "longitude",
-                "user_id"
+                "end",
+                "start"
             ],
             "properties": {
-                "latitude": {
-                    "type": "number"
-                },
-                "longitude": {
-                    "type": "number"
+                "end": {
+                    "type": "string",
+                    "example": "07:00"
                 },
-                "user_id": {
+                "start": {
                     "type": "string",
-                    "maxLength": 255,
-                    "minLength": 1
+                    "example": "22:00"
                 }
             }
         },
-        "models.CheckLocationResult": {
+        "preferences.UpdateReq": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
+                "min_severity": {
+                    "description": "alerts about less severe incidents are held back",
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 0
                 },
-                "dangers": {
+                "muted_categories": {
+                    "description": "incident categories to hear nothing about",
                     "type": "array",
+                    "maxItems": 50,
                     "items": {
-                        "$ref": "#/definitions/models.IncidentShort"
+                        "type": "string"
                     }
                 },
-                "has_danger": {
+                "opted_out": {
+                    "description": "no alerts at all",
                     "type": "boolean"
                 },
-                "latitude": {
-                    "type": "number"
-                },
-                "longitude": {
-                    "type": "number"
+                "quiet_hours": {
+                    "$ref": "#/definitions/preferences.QuietHoursReq"
                 },
-                "user_id": {
+                "timezone": {
+                    "description": "IANA name, default UTC",
                     "type": "string"
                 }
             }
         },
-        "models.HealthCheckResult": {
+        "queue.ReplayReq": {
             "type": "object",
             "properties": {
-                "status": {
+                "error": {
+                    "description": "substring of the last error",
+                    "type": "string"
+                },
+                "from": {
+                    "description": "last failure at or after",
+                    "type": "string"
+                },
+                "to": {
+                    "description": "last failure before",
                     "type": "string"
                 }
             }
         },
-        "models.Incident": {
+        "response.ErrorResponse": {
             "type": "object",
             "properties": {
-                "created_at": {
+                "message": {
                     "type": "string"
+                }
+            }
+        },
+        "utils.AreaReq": {
+            "type": "object",
+            "required": [
+                "type"
+            ],
+            "properties": {
+                "center": {
+                    "$ref": "#/definitions/utils.GeoPointReq"
                 },
-                "id": {
-                    "type": "integer"
-                },
-                "is_active": {
-                    "type": "boolean"
-                },
-                "latitude": {
-                    "type": "number"
+                "north_east": {
+                    "$ref": "#/definitions/utils.GeoPointReq"
                 },
-                "longitude": {
-                    "type": "number"
+                "points": {
+                    "type": "array",
+                    "maxItems": 1000,
+                    "minItems": 3,
+                    "items": {
+                        "$ref": "#/definitions/utils.GeoPointReq"
+                    }
                 },
                 "radius": {
-                    "type": "integer"
+                    "type": "integer",
+                    "minimum": 1
                 },
-                "updated_at": {
-                    "type": "string"
+                "south_west": {
+                    "$ref": "#/definitions/utils.GeoPointReq"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "bbox",
+                        "polygon",
+                        "circle"
+                    ]
                 }
             }
         },
-        "models.IncidentShort": {
+        "utils.GeoPointReq": {
             "type": "object",
+            "required": [
+                "latitude",
+                "longitude"
+            ],
             "properties": {
-                "id": {
-                    "type": "integer"
-                },
                 "latitude": {
                     "type": "number"
                 },
                 "longitude": {
                     "type": "number"
-                },
-                "radius": {
-                    "type": "integer"
                 }
             }
         },
-        "models.Stats": {
+        "webhook.CreateReq": {
             "type": "object",
+            "required": [
+                "secret",
+                "url"
+            ],
             "properties": {
-                "incident_id": {
-                    "type": "integer"
+                "area": {
+                    "description": "omit to receive alerts from anywhere",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/utils.AreaReq"
+                        }
+                    ]
                 },
-                "latitude": {
-                    "type": "number"
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "longitude": {
-                    "type": "number"
+                "digest": {
+                    "description": "omit to send alerts one by one",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/webhook.DigestReq"
+                        }
+                    ]
                 },
-                "user_count": {
-                    "type": "integer"
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "is_enabled": {
+                    "description": "default true",
+                    "type": "boolean"
+                },
+                "min_severity": {
+                    "type": "integer",
+                    "maximum": 5
+                },
+                "payload_format": {
+                    "description": "default legacy",
+                    "type": "string",
+                    "enum": [
+                        "legacy",
+                        "cloudevents_structured",
+                        "cloudevents_binary"
+                    ]
+                },
+                "rate_limit_rps": {
+                    "description": "omit for the server default, 0 = unlimited",
+                    "type": "number",
+                    "minimum": 0
+                },
+                "secret": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 16
+                },
+                "url": {
+                    "type": "string"
                 }
             }
         },
-        "response.ErrorResponse": {
+        "webhook.DigestReq": {
             "type": "object",
+            "required": [
+                "interval"
+            ],
             "properties": {
-                "message": {
+                "interval": {
+                    "description": "seconds",
+                    "type": "integer",
+                    "maximum": 86400,
+                    "minimum": 1
+                },
+                "max_alerts": {
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 1
+                }
+            }
+        },
+        "webhook.UpdateReq": {
+            "type": "object",
+            "required": [
+                "is_enabled",
+                "url"
+            ],
+            "properties": {
+                "area": {
+                    "$ref": "#/definitions/utils.AreaReq"
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "digest": {
+                    "description": "omit to send alerts one by one",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/webhook.DigestReq"
+                        }
+                    ]
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "is_enabled": {
+                    "type": "boolean"
+                },
+                "min_severity": {
+                    "type": "integer",
+                    "maximum": 5
+                },
+                "payload_format": {
+                    "description": "default legacy",
+                    "type": "string",
+                    "enum": [
+                        "legacy",
+                        "cloudevents_structured",
+                        "cloudevents_binary"
+                    ]
+                },
+                "rate_limit_rps": {
+                    "description": "omit for the server default, 0 = unlimited",
+                    "type": "number",
+                    "minimum": 0
+                },
+                "secret": {
+                    "description": "omit to keep the current secret",
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 16
+                },
+                "url": {
                     "type": "string"
                 }
             }
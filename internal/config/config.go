@@ -18,7 +18,9 @@ type Config struct {
 
 	Log      LogConfig
 	App      AppConfig
+	Location LocationConfig
 	Webhook  WebhookConfig
+	Notify   NotifyConfig
 	Server   ServerConfig
 	Postgres PostgresConfig
 	Redis    RedisConfig
@@ -27,17 +29,68 @@ type Config struct {
 }
 
 type AppConfig struct {
-	APIKey          string        `env:"API_KEY" env-required:"true"`
-	StatsTimeWindow time.Duration `env:"STATS_TIME_WINDOW_MINUTES" env-default:"15m"`
+	APIKey                   string        `env:"API_KEY" env-required:"true"`
+	StatsTimeWindow          time.Duration `env:"STATS_TIME_WINDOW_MINUTES" env-default:"15m"`
+	StatsCountPossiblyInside bool          `env:"STATS_COUNT_POSSIBLY_INSIDE" env-default:"false"`
+	RetroactiveAlertWindow   time.Duration `env:"RETROACTIVE_ALERT_WINDOW" env-default:"15m" validate:"min=1m"` // how far back checks count for alerts about a new or expanded zone
+	BroadcastRecency         time.Duration `env:"BROADCAST_RECENCY_WINDOW" env-default:"30m" validate:"min=1m"` // how recent a last location has to be to get a broadcast
+}
+
+type LocationConfig struct {
+	ProximityBuffer   int           `env:"PROXIMITY_BUFFER_METERS" env-default:"0" validate:"min=0"` // 0 = no proximity warnings unless set per incident
+	ProximityAlerts   bool          `env:"PROXIMITY_ALERTS_ENABLED" env-default:"false"`
+	PredictionHorizon time.Duration `env:"PREDICTION_HORIZON" env-default:"5m" validate:"min=0"` // 0 = no predictive alerts
+	CrossingAlerts    bool          `env:"CROSSING_ALERTS_ENABLED" env-default:"false"`
+	PossiblyInside    bool          `env:"POSSIBLY_INSIDE_ALERTS" env-default:"false"` // count zones that only overlap the GPS uncertainty as danger
 }
 
 type WebhookConfig struct {
 	Port                      string        `env:"WEBHOOK_PORT" env-default:"9090" validate:"numeric"`
-	URL                       string        `env:"WEBHOOK_URL" env-default:"http://localhost:9090" validate:"url"`
+	URL                       string        `env:"WEBHOOK_URL" env-default:"http://localhost:9090" validate:"omitempty,url"`    // receives all events in addition to subscriptions, empty = disabled
+	Secret                    string        `env:"WEBHOOK_SECRET" validate:"omitempty,min=16"`                                  // signs deliveries to WEBHOOK_URL, empty = unsigned
+	PreviousSecret            string        `env:"WEBHOOK_PREVIOUS_SECRET" validate:"excluded_without=Secret,omitempty,min=16"` // also signs deliveries to WEBHOOK_URL while receivers rotate
+	SecretRotationGrace       time.Duration `env:"WEBHOOK_SECRET_ROTATION_GRACE" env-default:"24h" validate:"min=0"`            // how long a replaced subscription secret keeps signing
 	RequestTimeout            time.Duration `env:"WEBHOOK_REQUEST_TIMEOUT" env-default:"10s" validate:"min=1s"`
 	ClientMaxIdleConns        int           `env:"WEBHOOK_CLIENT_MAX_IDLE_CONNS" env-default:"100" validate:"min=1"`
 	ClientMaxIdleConnsPerHost int           `env:"WEBHOOK_CLIENT_MAX_IDLE_CONNS_PER_HOST" env-default:"20" validate:"min=1"`
 	ClientIdleConnTimeout     time.Duration `env:"WEBHOOK_CLIENT_IDLE_CONN_TIMEOUT" env-default:"90s" validate:"min=1s"`
+	BreakerFailureThreshold   int           `env:"WEBHOOK_BREAKER_FAILURE_THRESHOLD" env-default:"5" validate:"min=1"` // consecutive failures that open a destination's breaker
+	BreakerCooldown           time.Duration `env:"WEBHOOK_BREAKER_COOLDOWN" env-default:"30s" validate:"min=1s"`       // how long an open breaker puts deliveries off before a probe
+	RateLimitRPS              float64       `env:"WEBHOOK_RATE_LIMIT_RPS" env-default:"0" validate:"min=0"`            // per subscriber unless set on the subscription, 0 = unlimited
+	RateLimitBurst            int           `env:"WEBHOOK_RATE_LIMIT_BURST" env-default:"5" validate:"min=1"`
+	PayloadFormat             string        `env:"WEBHOOK_PAYLOAD_FORMAT" env-default:"legacy" validate:"oneof=legacy cloudevents_structured cloudevents_binary"` // for WEBHOOK_URL
+	EventSource               string        `env:"WEBHOOK_EVENT_SOURCE" env-default:"/geo-alerts" validate:"required,uri"`                                        // CloudEvents source
+	EventSchemaBase           string        `env:"WEBHOOK_EVENT_SCHEMA_BASE" env-default:"urn:geo-alerts:schemas" validate:"required,uri"`                        // CloudEvents dataschema prefix
+	DigestSampleUsers         int           `env:"WEBHOOK_DIGEST_SAMPLE_USERS" env-default:"10" validate:"min=0"`                                                 // users listed per incident in a digest
+}
+
+// NotifyConfig sets up notifications over channels other than webhook subscriptions: to duty officers,
+// where a channel is off while it has no recipients, and pushes to the registered devices of the user alerted.
+type NotifyConfig struct {
+	Events          []string      `env:"NOTIFY_EVENTS" env-default:"danger" validate:"dive,oneof=danger proximity predicted_danger path_crossing place_danger"` // alert types sent to duty officers
+	RequestTimeout  time.Duration `env:"NOTIFY_REQUEST_TIMEOUT" env-default:"10s" validate:"min=1s"`
+	WebhookURLs     []string      `env:"NOTIFY_WEBHOOK_URLS" validate:"dive,url"`
+	WebhookSecret   string        `env:"NOTIFY_WEBHOOK_SECRET" validate:"omitempty,min=16"` // signs notifications like subscription webhooks, empty = unsigned
+	EmailTo         []string      `env:"NOTIFY_EMAIL_TO" validate:"dive,email"`
+	SMTPHost        string        `env:"SMTP_HOST" validate:"omitempty,hostname|ip"`
+	SMTPPort        string        `env:"SMTP_PORT" env-default:"587" validate:"numeric"`
+	SMTPUsername    string        `env:"SMTP_USERNAME"` // empty = no authentication
+	SMTPPassword    string        `env:"SMTP_PASSWORD"`
+	SMTPFrom        string        `env:"SMTP_FROM" validate:"omitempty,email"`
+	SMTPStartTLS    bool          `env:"SMTP_STARTTLS" env-default:"true"` // refuse to send over a server that doesn't offer STARTTLS
+	SMSTo           []string      `env:"NOTIFY_SMS_TO" validate:"dive,e164"`
+	SMSGatewayURL   string        `env:"SMS_GATEWAY_URL" validate:"omitempty,url"`
+	SMSGatewayToken string        `env:"SMS_GATEWAY_TOKEN"` // sent as a bearer token, empty = none
+	SMSSender       string        `env:"SMS_SENDER"`
+	PushEvents      []string      `env:"PUSH_EVENTS" env-default:"danger,place_danger" validate:"dive,oneof=danger proximity predicted_danger path_crossing place_danger"` // alert types pushed to the user's own devices
+	FCMURL          string        `env:"PUSH_FCM_URL" validate:"omitempty,url"`                                                                                            // empty = no pushes to android devices
+	FCMProject      string        `env:"PUSH_FCM_PROJECT"`
+	FCMToken        string        `env:"PUSH_FCM_TOKEN"`                                                         // OAuth 2.0 access token
+	APNsURL         string        `env:"PUSH_APNS_URL" validate:"omitempty,url"`                                 // empty = no pushes to ios devices
+	APNsTopic       string        `env:"PUSH_APNS_TOPIC"`                                                        // app bundle ID
+	APNsToken       string        `env:"PUSH_APNS_TOKEN"`                                                        // provider authentication token
+	AckTimeout      time.Duration `env:"ALERT_ACK_TIMEOUT" env-default:"0" validate:"min=0"`                     // unacknowledged alerts are followed up after it, 0 = never
+	AckAction       string        `env:"ALERT_ACK_ACTION" env-default:"resend" validate:"oneof=resend escalate"` // resend to the same receivers or escalate to duty officers
 }
 
 type LogConfig struct {
@@ -79,13 +132,17 @@ type RedisConfig struct {
 }
 
 type CacheConfig struct {
-	IncidentsTTL time.Duration `env:"CACHE_INCIDENTS_TTL" env-default:"1h" validate:"min=1s"`
+	IncidentsTTL    time.Duration `env:"CACHE_INCIDENTS_TTL" env-default:"1h" validate:"min=1s"`
+	LastLocationTTL time.Duration `env:"CACHE_LAST_LOCATION_TTL" env-default:"30m" validate:"min=1s"` // older points are not used for path crossing
 }
 
 type QueueConfig struct {
-	MaxRetries  int           `env:"QUEUE_MAX_RETRIES" env-default:"5" validate:"min=0"`
-	Timeout     time.Duration `env:"QUEUE_TIMEOUT" env-default:"1m" validate:"min=1s"`
-	Concurrency int           `env:"QUEUE_CONCURRENCY" env-default:"10" validate:"min=1"`
+	MaxRetries     int           `env:"QUEUE_MAX_RETRIES" env-default:"5" validate:"min=0"`
+	Timeout        time.Duration `env:"QUEUE_TIMEOUT" env-default:"1m" validate:"min=1s"`
+	Concurrency    int           `env:"QUEUE_CONCURRENCY" env-default:"10" validate:"min=1"`
+	RetryBaseDelay time.Duration `env:"QUEUE_RETRY_BASE_DELAY" env-default:"10s" validate:"min=1s"`
+	RetryMaxDelay  time.Duration `env:"QUEUE_RETRY_MAX_DELAY" env-default:"1h" validate:"min=1s,gtefield=RetryBaseDelay"`
+	RetryJitter    float64       `env:"QUEUE_RETRY_JITTER" env-default:"0.5" validate:"min=0,max=1"` // fraction of the delay that is randomized
 }
 
 func MustLoad() *Config {
@@ -123,5 +180,17 @@ func (cfg *Config) validateLogic() error {
 		return fmt.Errorf("postgres: max_idle_conns (%d) cannot be greater than max_open_conns (%d)",
 			cfg.Postgres.MaxIdleConns, cfg.Postgres.MaxOpenConns)
 	}
+	if len(cfg.Notify.EmailTo) > 0 && (cfg.Notify.SMTPHost == "" || cfg.Notify.SMTPFrom == "") {
+		return fmt.Errorf("notify: email recipients are set but smtp host or sender is not")
+	}
+	if len(cfg.Notify.SMSTo) > 0 && cfg.Notify.SMSGatewayURL == "" {
+		return fmt.Errorf("notify: sms recipients are set but sms gateway url is not")
+	}
+	if cfg.Notify.FCMURL != "" && cfg.Notify.FCMProject == "" {
+		return fmt.Errorf("notify: fcm url is set but fcm project is not")
+	}
+	if cfg.Notify.APNsURL != "" && cfg.Notify.APNsTopic == "" {
+		return fmt.Errorf("notify: apns url is set but apns topic is not")
+	}
 	return nil
 }
@@ -0,0 +1,5 @@
+package errs
+
+import "errors"
+
+var ErrAlertNotFound = errors.New("alert not found")
@@ -0,0 +1,7 @@
+package errs
+
+import "errors"
+
+var (
+	ErrBroadcastNotFound = errors.New("broadcast not found")
+)
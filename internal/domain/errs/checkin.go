@@ -0,0 +1,5 @@
+package errs
+
+import "errors"
+
+var ErrReminderPending = errors.New("check-in reminder is already queued")
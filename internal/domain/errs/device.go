@@ -0,0 +1,7 @@
+package errs
+
+import "errors"
+
+var (
+	ErrDeviceNotFound = errors.New("device not found")
+)
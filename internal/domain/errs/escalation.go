@@ -0,0 +1,8 @@
+package errs
+
+import "errors"
+
+var (
+	ErrEscalationPolicyNotFound = errors.New("escalation policy not found")
+	ErrDwellNotFound            = errors.New("user is not inside the zone")
+)
@@ -0,0 +1,8 @@
+package errs
+
+import "errors"
+
+var (
+	ErrGuardianGroupNotFound = errors.New("guardian group not found")
+	ErrGroupMemberNotFound   = errors.New("group member not found")
+)
@@ -5,4 +5,5 @@ import "errors"
 var (
 	ErrIncidentExists   = errors.New("incident already exists")
 	ErrIncidentNotFound = errors.New("incident not found")
+	ErrIncidentInactive = errors.New("incident is not active")
 )
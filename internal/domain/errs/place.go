@@ -0,0 +1,8 @@
+package errs
+
+import "errors"
+
+var (
+	ErrPlaceNotFound = errors.New("saved place not found")
+	ErrPlaceExists   = errors.New("saved place with this name already exists")
+)
@@ -0,0 +1,7 @@
+package errs
+
+import "errors"
+
+var (
+	ErrPreferencesNotFound = errors.New("user preferences not found")
+)
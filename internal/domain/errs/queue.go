@@ -0,0 +1,9 @@
+package errs
+
+import "errors"
+
+var (
+	ErrQueueNotFound = errors.New("queue not found")
+	ErrTaskNotFound  = errors.New("task not found")
+	ErrTaskState     = errors.New("operation is not allowed in the current task state")
+)
@@ -0,0 +1,8 @@
+package errs
+
+import "errors"
+
+var (
+	ErrSubscriptionNotFound = errors.New("webhook subscription not found")
+	ErrDeliveryNotFound     = errors.New("webhook delivery not found")
+)
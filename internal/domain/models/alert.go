@@ -0,0 +1,39 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// What is done with alerts nobody acknowledged in time.
+const (
+	AckResend   = "resend"   // sent again over the same channels
+	AckEscalate = "escalate" // duty officers are told
+)
+
+// EventUnacknowledged is the event of the notifications duty officers get about an alert nobody acknowledged.
+const EventUnacknowledged = "unacknowledged"
+
+// Alert is an alert sent about a user, tracked until a receiver or the user's app acknowledges it.
+// @name Alert
+type Alert struct {
+	ID             string          `json:"id"`
+	UserID         string          `json:"user_id"`
+	AlertType      string          `json:"alert_type"`
+	IncidentIDs    []int64         `json:"incident_ids"`
+	CreatedAt      time.Time       `json:"created_at"`
+	AcknowledgedAt *time.Time      `json:"acknowledged_at,omitempty"` // first acknowledgement
+	TimeToAck      *float64        `json:"time_to_ack,omitempty"`     // seconds from sending to the first acknowledgement
+	FollowedUpAt   *time.Time      `json:"followed_up_at,omitempty"`  // when it was resent or escalated for being unacknowledged
+	Payload        json.RawMessage `json:"-"`                         // as sent
+}
+
+// AckReport sums up how the alerts about an incident were acknowledged.
+// @name AlertAckReport
+type AckReport struct {
+	IncidentID     int64    `json:"incident_id"`
+	Sent           int      `json:"sent"`
+	Acknowledged   int      `json:"acknowledged"`
+	AvgTimeToAck   *float64 `json:"avg_time_to_ack,omitempty"` // seconds
+	Unacknowledged []Alert  `json:"unacknowledged"`
+}
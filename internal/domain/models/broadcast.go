@@ -0,0 +1,67 @@
+package models
+
+import "time"
+
+// States of a broadcast.
+const (
+	BroadcastPending   = "pending"   // recipients are being looked up
+	BroadcastSending   = "sending"   // notifications are queued
+	BroadcastCompleted = "completed" // every notification was sent or given up on
+	BroadcastFailed    = "failed"    // recipients couldn't be looked up
+)
+
+// States of a broadcast notification to one device.
+const (
+	RecipientPending = "pending"
+	RecipientSent    = "sent"
+	RecipientFailed  = "failed"
+)
+
+// @name Broadcast
+type Broadcast struct {
+	ID          int64      `json:"id"`
+	IncidentID  *int64     `json:"incident_id,omitempty"`
+	Area        *Area      `json:"area"`
+	Title       string     `json:"title"`
+	Message     string     `json:"message"`
+	Recency     int        `json:"recency_seconds"` // how recent a user's last location has to be
+	Status      string     `json:"status" enums:"pending,sending,completed,failed"`
+	Users       int        `json:"users"`       // found in the area
+	Unreachable int        `json:"unreachable"` // of the users, those without a device to push to
+	Total       int        `json:"total"`       // notifications, one per device
+	Sent        int        `json:"sent"`
+	Failed      int        `json:"failed"`
+	Error       string     `json:"error,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+type CreateBroadcastParams struct {
+	IncidentID *int64
+	Area       *Area // ignored when IncidentID is set
+	Title      string
+	Message    string
+	Recency    time.Duration
+}
+
+// @name BroadcastRecipient
+type BroadcastRecipient struct {
+	ID          int64     `json:"id"`
+	BroadcastID int64     `json:"broadcast_id"`
+	UserID      string    `json:"user_id"`
+	DeviceID    int64     `json:"device_id"`
+	Token       string    `json:"-"`
+	Platform    string    `json:"platform"`
+	Status      string    `json:"status" enums:"pending,sent,failed"`
+	Attempts    int       `json:"attempts"`
+	Error       string    `json:"error,omitempty"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type ListBroadcastRecipientsParams struct {
+	BroadcastID int64
+	Status      string // empty = any
+	Limit       int
+	Offset      int
+}
@@ -0,0 +1,65 @@
+package models
+
+import "time"
+
+// Statuses a user reports about themselves during an incident.
+const (
+	CheckinSafe      = "safe"
+	CheckinNeedHelp  = "need_help"
+	CheckinEvacuated = "evacuated"
+)
+
+// EventCheckinReminder is the event of pushes asking users in a zone to check in.
+const EventCheckinReminder = "checkin_reminder"
+
+// @name Checkin
+type Checkin struct {
+	IncidentID int64     `json:"incident_id"`
+	UserID     string    `json:"user_id"`
+	Status     string    `json:"status" enums:"safe,need_help,evacuated"`
+	Note       string    `json:"note,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type CheckinParams struct {
+	IncidentID int64
+	UserID     string
+	Status     string
+	Note       string
+}
+
+// @name CheckinCounts
+type CheckinCounts struct {
+	Safe        int `json:"safe"`
+	NeedHelp    int `json:"need_help"`
+	Evacuated   int `json:"evacuated"`
+	Unaccounted int `json:"unaccounted"` // seen in the zone without checking in
+}
+
+// UnaccountedUser is a user seen in an incident zone who hasn't checked in, with the last location seen there.
+//
+// @name UnaccountedUser
+type UnaccountedUser struct {
+	UserID     string    `json:"user_id"`
+	Latitude   float64   `json:"latitude"`
+	Longitude  float64   `json:"longitude"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+}
+
+// @name UnaccountedReport
+type UnaccountedReport struct {
+	IncidentID int64             `json:"incident_id"`
+	Window     int               `json:"window_seconds"` // how far back users count as seen in the zone
+	Seen       int               `json:"seen"`           // users seen in the zone
+	Counts     CheckinCounts     `json:"counts"`
+	Users      []UnaccountedUser `json:"users"`
+}
+
+type ListUnaccountedParams struct {
+	IncidentID          int64
+	Window              time.Duration
+	CountPossiblyInside bool
+	Limit               int // 0 = all
+	Offset              int
+}
@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// Platforms a device can be registered for, each pushed to through its own service.
+const (
+	PlatformAndroid = "android" // FCM
+	PlatformIOS     = "ios"     // APNs
+)
+
+// @name Device
+type Device struct {
+	ID        int64     `json:"id"`
+	UserID    string    `json:"user_id"`
+	Token     string    `json:"token"`
+	Platform  string    `json:"platform" enums:"android,ios"`
+	Locale    string    `json:"locale"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type RegisterDeviceParams struct {
+	UserID   string
+	Token    string
+	Platform string
+	Locale   string
+}
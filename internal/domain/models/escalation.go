@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+// Follow-ups to an alert while the user stays inside the zone.
+const (
+	EscalationRealert = "realert"  // the user is alerted again
+	EscalationDuty    = "escalate" // duty officers are told the user hasn't left
+)
+
+// EventEscalation is the event of the notifications duty officers get about a user who stays in a zone.
+const EventEscalation = "escalation"
+
+// EscalationPolicy says when to follow up on users staying in the zones of incidents of a severity.
+// @name EscalationPolicy
+type EscalationPolicy struct {
+	Severity      int       `json:"severity"`
+	RealertAfter  int       `json:"realert_after"`  // seconds inside, 0 = no re-alert
+	EscalateAfter int       `json:"escalate_after"` // seconds inside, 0 = no escalation
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// EscalationSteps are all the follow-ups, in the order a policy usually has them.
+var EscalationSteps = []string{EscalationRealert, EscalationDuty}
+
+// After returns how long after entering the zone the step follows, 0 if the policy doesn't have it.
+func (p *EscalationPolicy) After(step string) time.Duration {
+	switch step {
+	case EscalationRealert:
+		return time.Duration(p.RealertAfter) * time.Second
+	case EscalationDuty:
+		return time.Duration(p.EscalateAfter) * time.Second
+	}
+	return 0
+}
+
+type UpdateEscalationPolicyParams struct {
+	Severity      int
+	RealertAfter  int
+	EscalateAfter int
+}
+
+// ZoneDwell is a user staying inside an incident zone, as told by consecutive checks.
+type ZoneDwell struct {
+	UserID     string    `json:"user_id"`
+	IncidentID int64     `json:"incident_id"`
+	EnteredAt  time.Time `json:"entered_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	Latitude   float64   `json:"latitude"` // of the last check
+	Longitude  float64   `json:"longitude"`
+}
@@ -0,0 +1,59 @@
+package models
+
+import "time"
+
+// Channels a guardian is alerted over, each sent through the notification channel of the same name.
+const (
+	GuardianWebhook = "webhook"
+	GuardianEmail   = "email"
+	GuardianSMS     = "sms"
+)
+
+// Consent of a group member to their guardians being alerted.
+const (
+	MemberPending   = "pending"
+	MemberConsented = "consented"
+	MemberRevoked   = "revoked"
+)
+
+// GuardianGroup links guardians, such as parents or a school, to the users they look after.
+// @name GuardianGroup
+type GuardianGroup struct {
+	ID        int64         `json:"id"`
+	Name      string        `json:"name"`
+	Guardians []Guardian    `json:"guardians"`
+	Members   []GroupMember `json:"members"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+}
+
+// @name Guardian
+type Guardian struct {
+	ID      int64  `json:"id"`
+	GroupID int64  `json:"group_id"`
+	Name    string `json:"name"`
+	Channel string `json:"channel" enums:"webhook,email,sms"`
+	Address string `json:"address"` // URL, email address or phone number, depending on the channel
+}
+
+// @name GroupMember
+type GroupMember struct {
+	GroupID     int64      `json:"group_id"`
+	UserID      string     `json:"user_id"`
+	Status      string     `json:"status" enums:"pending,consented,revoked"`
+	ConsentedAt *time.Time `json:"consented_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+type CreateGuardianGroupParams struct {
+	Name      string
+	Guardians []GuardianParams
+	UserIDs   []string
+}
+
+type GuardianParams struct {
+	Name    string
+	Channel string
+	Address string
+}
@@ -4,36 +4,86 @@ import (
 	"time"
 )
 
+// Incident severity ranges from SeverityMin (lowest) to SeverityMax (highest).
+const (
+	SeverityMin = 1
+	SeverityMax = 5
+)
+
 type CreateIncidentParams struct {
-	Latitude  float64
-	Longitude float64
-	Radius    int
+	Latitude       float64
+	Longitude      float64
+	Radius         int
+	ApproachBuffer *int
+	Severity       int
+	Category       string
+
+	AlertRecentUsers bool // alert users whose last check within the look-back window is in the zone
 }
 
 type UpdateIncidentParams struct {
-	ID        int64
-	Latitude  float64
-	Longitude float64
-	Radius    int
+	ID             int64
+	Latitude       float64
+	Longitude      float64
+	Radius         int
+	ApproachBuffer *int    // nil keeps the current buffer
+	Severity       *int    // nil keeps the current severity
+	Category       *string // nil keeps the current category
+
+	ClearApproachBuffer bool // fall back to the default approach distance
+
+	AlertRecentUsers bool // alert users whose last check within the look-back window is in the zone and who haven't been yet
 }
 
 // @name Incident
 type Incident struct {
-	ID        int64     `json:"id"`
-	Latitude  float64   `json:"latitude"`
-	Longitude float64   `json:"longitude"`
-	Radius    int       `json:"radius"`
-	IsActive  bool      `json:"is_active"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID             int64     `json:"id"`
+	Latitude       float64   `json:"latitude"`
+	Longitude      float64   `json:"longitude"`
+	Radius         int       `json:"radius"`
+	ApproachBuffer *int      `json:"approach_buffer,omitempty"`
+	Severity       int       `json:"severity"`
+	Category       string    `json:"category"`
+	IsActive       bool      `json:"is_active"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// Short returns the fields of the incident alerts carry.
+func (i *Incident) Short() IncidentShort {
+	return IncidentShort{
+		ID:             i.ID,
+		Latitude:       i.Latitude,
+		Longitude:      i.Longitude,
+		Radius:         i.Radius,
+		ApproachBuffer: i.ApproachBuffer,
+		Severity:       i.Severity,
+		Category:       i.Category,
+	}
 }
 
 // @name IncidentShort
 type IncidentShort struct {
-	ID        int64   `json:"id"`
-	Latitude  float64 `json:"latitude"`
-	Longitude float64 `json:"longitude"`
-	Radius    int     `json:"radius"`
+	ID             int64   `json:"id"`
+	Latitude       float64 `json:"latitude"`
+	Longitude      float64 `json:"longitude"`
+	Radius         int     `json:"radius"`
+	ApproachBuffer *int    `json:"approach_buffer,omitempty"`
+	Severity       int     `json:"severity"`
+	Category       string  `json:"category"`
+}
+
+// @name NearbyIncident
+type NearbyIncident struct {
+	IncidentShort
+	DistanceToEdge float64 `json:"distance_to_edge"`
+}
+
+// @name PredictedIncident
+type PredictedIncident struct {
+	IncidentShort
+	DistanceToEntry float64 `json:"distance_to_entry"` // meters along the projected path
+	TimeToEntry     float64 `json:"time_to_entry"`     // seconds from now
 }
 
 // @name Stats
@@ -8,14 +8,62 @@ type CheckLocationParams struct {
 	UserID    string
 	Latitude  float64
 	Longitude float64
+	Accuracy  *float64   // horizontal accuracy in meters
+	Speed     *float64   // meters per second
+	Heading   *float64   // degrees clockwise from north
+	Timestamp *time.Time // when the position was recorded
 }
 
 // @name CheckLocationResult
 type CheckLocationResult struct {
-	UserID    string          `json:"user_id"`
-	Latitude  float64         `json:"latitude"`
-	Longitude float64         `json:"longitude"`
-	HasDanger bool            `json:"has_danger"`
-	Dangers   []IncidentShort `json:"dangers"`
-	CreatedAt time.Time       `json:"created_at"`
+	UserID    string              `json:"user_id"`
+	Latitude  float64             `json:"latitude"`
+	Longitude float64             `json:"longitude"`
+	Accuracy  *float64            `json:"accuracy_meters,omitempty"`
+	HasDanger bool                `json:"has_danger"`
+	Dangers   []IncidentShort     `json:"dangers"`
+	Matches   []ZoneMatch         `json:"matches"`
+	Nearby    []NearbyIncident    `json:"nearby"`
+	Predicted []PredictedIncident `json:"predicted"`
+	Crossed   []IncidentShort     `json:"crossed"`
+	Escape    *EscapeRoute        `json:"escape,omitempty"`
+	CreatedAt time.Time           `json:"created_at"`
+}
+
+const (
+	MatchInside         = "inside"
+	MatchPossiblyInside = "possibly_inside"
+	MatchOutside        = "outside"
+)
+
+// ZoneMatch is a zone the user is inside or possibly inside given the GPS accuracy.
+// Active zones that are not listed are outside.
+//
+// @name ZoneMatch
+type ZoneMatch struct {
+	IncidentShort
+	Match string `json:"match" enums:"inside,possibly_inside"`
+}
+
+// UserLocation is the last known point of a user.
+type UserLocation struct {
+	Latitude   float64   `json:"latitude"`
+	Longitude  float64   `json:"longitude"`
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+// LastCheck is the most recent location check of a user.
+type LastCheck struct {
+	UserID    string
+	Latitude  float64
+	Longitude float64
+	CheckedAt time.Time
+}
+
+// @name EscapeRoute
+type EscapeRoute struct {
+	Bearing   float64 `json:"bearing"`  // degrees clockwise from north
+	Distance  float64 `json:"distance"` // meters
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
 }
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// SavedPlace is a place a user wants to hear about danger near, wherever they are themselves.
+// @name SavedPlace
+type SavedPlace struct {
+	ID        int64     `json:"id"`
+	UserID    string    `json:"user_id"`
+	Name      string    `json:"name" example:"home"`
+	Latitude  float64   `json:"latitude"`
+	Longitude float64   `json:"longitude"`
+	Radius    int       `json:"radius"` // meters, an incident zone reaching into it alerts the owner
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type SavePlaceParams struct {
+	ID        int64 // of the place to update, 0 to create one
+	UserID    string
+	Name      string
+	Latitude  float64
+	Longitude float64
+	Radius    int
+}
+
+// PlaceMatch is an active incident whose zone reaches into a saved place.
+type PlaceMatch struct {
+	Place    SavedPlace
+	Incident IncidentShort
+}
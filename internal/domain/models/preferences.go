@@ -0,0 +1,99 @@
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+// Reasons an alert was held back by the preferences of its user.
+const (
+	SuppressedOptedOut    = "opted_out"
+	SuppressedQuietHours  = "quiet_hours"
+	SuppressedMinSeverity = "below_min_severity"
+	SuppressedCategory    = "muted_category"
+)
+
+// UserPreferences say which alerts a user wants. Users who never set them get every alert.
+// @name UserPreferences
+type UserPreferences struct {
+	UserID          string      `json:"user_id"`
+	OptedOut        bool        `json:"opted_out"`
+	MinSeverity     int         `json:"min_severity"`
+	MutedCategories []string    `json:"muted_categories"`
+	QuietHours      *QuietHours `json:"quiet_hours,omitempty"`
+	Timezone        string      `json:"timezone"`             // IANA name, quiet hours are in it
+	UpdatedAt       *time.Time  `json:"updated_at,omitempty"` // unset if the user never saved any
+}
+
+// QuietHours is a daily period without alerts in local time. It wraps around midnight if End is before Start.
+// @name QuietHours
+type QuietHours struct {
+	Start string `json:"start" example:"22:00"`
+	End   string `json:"end" example:"07:00"`
+}
+
+const clockLayout = "15:04"
+
+// DefaultPreferences are those of a user who never set any.
+func DefaultPreferences(userID string) *UserPreferences {
+	return &UserPreferences{UserID: userID, MutedCategories: []string{}, Timezone: "UTC"}
+}
+
+// Quiet tells whether the time falls within the quiet hours of the user. Malformed quiet hours are never quiet
+// and an unknown timezone counts as UTC.
+func (p *UserPreferences) Quiet(t time.Time) bool {
+	if p.QuietHours == nil {
+		return false
+	}
+	start, errStart := time.Parse(clockLayout, p.QuietHours.Start)
+	end, errEnd := time.Parse(clockLayout, p.QuietHours.End)
+	if errStart != nil || errEnd != nil {
+		return false
+	}
+
+	if loc, err := time.LoadLocation(p.Timezone); err == nil {
+		t = t.In(loc)
+	} else {
+		t = t.UTC()
+	}
+	now := t.Hour()*60 + t.Minute()
+	from, to := start.Hour()*60+start.Minute(), end.Hour()*60+end.Minute()
+
+	if from <= to {
+		return now >= from && now < to
+	}
+	return now >= from || now < to
+}
+
+// Suppresses returns why the user doesn't want alerts about the incident, or "" if they do.
+// Opt-out and quiet hours hold back every alert.
+func (p *UserPreferences) Suppresses(inc IncidentShort, now time.Time) string {
+	switch {
+	case p.OptedOut:
+		return SuppressedOptedOut
+	case p.Quiet(now):
+		return SuppressedQuietHours
+	case inc.Severity < p.MinSeverity:
+		return SuppressedMinSeverity
+	case slices.Contains(p.MutedCategories, inc.Category):
+		return SuppressedCategory
+	}
+	return ""
+}
+
+type UpdatePreferencesParams struct {
+	UserID          string
+	OptedOut        bool
+	MinSeverity     int
+	MutedCategories []string
+	QuietHours      *QuietHours
+	Timezone        string
+}
+
+// AlertSuppression records alerts held back by the preferences of the user, for one reason.
+type AlertSuppression struct {
+	UserID      string
+	AlertType   string
+	IncidentIDs []int64
+	Reason      string
+}
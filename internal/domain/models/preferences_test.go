@@ -0,0 +1,37 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUserPreferences_Suppresses_QuietHours(t *testing.T) {
+	incident := IncidentShort{ID: 1, Severity: 5}
+	// 21:30 UTC is 00:30 in Moscow.
+	now := time.Date(2026, 3, 1, 21, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		quiet    *QuietHours
+		timezone string
+		want     string
+	}{
+		{"No quiet hours", nil, "UTC", ""},
+		{"Within, same day", &QuietHours{Start: "21:00", End: "22:00"}, "UTC", SuppressedQuietHours},
+		{"Before, same day", &QuietHours{Start: "22:00", End: "23:00"}, "UTC", ""},
+		{"Within, over midnight", &QuietHours{Start: "23:00", End: "07:00"}, "Europe/Moscow", SuppressedQuietHours},
+		{"After, over midnight", &QuietHours{Start: "23:00", End: "07:00"}, "UTC", ""},
+		{"End is exclusive", &QuietHours{Start: "20:00", End: "21:30"}, "UTC", ""},
+		{"Unknown timezone is UTC", &QuietHours{Start: "21:00", End: "22:00"}, "Mars/Olympus", SuppressedQuietHours},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prefs := &UserPreferences{UserID: "u1", QuietHours: tt.quiet, Timezone: tt.timezone}
+
+			if got := prefs.Suppresses(incident, now); got != tt.want {
+				t.Errorf("Suppresses() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+)
+
+// Task states that can be inspected.
+const (
+	TaskStatePending  = "pending"
+	TaskStateRetry    = "retry"
+	TaskStateArchived = "archived"
+)
+
+// @name QueueInfo
+type QueueInfo struct {
+	Queue     string `json:"queue"`
+	Size      int    `json:"size"`
+	Pending   int    `json:"pending"`
+	Active    int    `json:"active"`
+	Scheduled int    `json:"scheduled"`
+	Retry     int    `json:"retry"`
+	Archived  int    `json:"archived"`
+	Processed int    `json:"processed"` // today
+	Failed    int    `json:"failed"`    // today
+	LatencyMs int64  `json:"latency_ms"`
+	Paused    bool   `json:"paused"`
+}
+
+// @name QueueTask
+type QueueTask struct {
+	ID            string     `json:"id"`
+	Queue         string     `json:"queue"`
+	Type          string     `json:"type"`
+	State         string     `json:"state"`
+	Payload       any        `json:"payload" swaggertype:"object"` // decoded for webhook tasks, raw otherwise
+	MaxRetry      int        `json:"max_retry"`
+	Retried       int        `json:"retried"`
+	LastError     string     `json:"last_error,omitempty"`
+	LastFailedAt  *time.Time `json:"last_failed_at,omitempty"`
+	NextProcessAt *time.Time `json:"next_process_at,omitempty"`
+}
+
+// QueueTaskFilter narrows tasks down by their last failure. Empty fields match everything.
+type QueueTaskFilter struct {
+	Error string     // substring of the last error
+	From  *time.Time // last failure at or after
+	To    *time.Time // last failure before
+}
+
+// @name ReplayResult
+type ReplayResult struct {
+	Replayed int `json:"replayed"`
+}
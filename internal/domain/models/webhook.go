@@ -0,0 +1,195 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Events a webhook subscription can filter on.
+const (
+	EventDanger          = "danger"
+	EventProximity       = "proximity"
+	EventPredictedDanger = "predicted_danger"
+	EventPathCrossing    = "path_crossing"
+	EventPlaceDanger     = "place_danger" // an incident zone reaches into a place the user saved
+
+	// Incident lifecycle events are only delivered to subscriptions that list them.
+	EventIncidentCreated     = "incident.created"
+	EventIncidentUpdated     = "incident.updated"
+	EventIncidentDeactivated = "incident.deactivated"
+)
+
+// Body formats a subscriber can receive events in.
+const (
+	PayloadFormatLegacy                = "legacy"                 // the bare event data
+	PayloadFormatCloudEventsStructured = "cloudevents_structured" // CloudEvents 1.0 envelope as the body
+	PayloadFormatCloudEventsBinary     = "cloudevents_binary"     // CloudEvents 1.0 attributes in ce-* headers, event data as the body
+)
+
+// States of a destination's circuit breaker.
+const (
+	BreakerClosed   = "closed"
+	BreakerOpen     = "open"
+	BreakerHalfOpen = "half_open"
+)
+
+// Shapes of a subscription area of interest.
+const (
+	AreaBBox    = "bbox"
+	AreaPolygon = "polygon"
+	AreaCircle  = "circle"
+)
+
+// @name GeoPoint
+type GeoPoint struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// Area is a region a subscription is interested in. Only the fields of its type are set.
+//
+// @name Area
+type Area struct {
+	Type      string     `json:"type" enums:"bbox,polygon,circle"`
+	SouthWest *GeoPoint  `json:"south_west,omitempty"` // bbox
+	NorthEast *GeoPoint  `json:"north_east,omitempty"` // bbox, may have a lower longitude than south_west across the antimeridian
+	Points    []GeoPoint `json:"points,omitempty"`     // polygon ring, closing point is implied
+	Center    *GeoPoint  `json:"center,omitempty"`     // circle
+	Radius    int        `json:"radius,omitempty"`     // circle, meters
+}
+
+type CreateWebhookSubscriptionParams struct {
+	URL           string
+	Secret        string
+	IsEnabled     bool
+	Events        []string
+	Area          *Area
+	MinSeverity   int
+	Categories    []string
+	RateLimit     *float64
+	PayloadFormat string
+	Digest        DigestSettings
+}
+
+type UpdateWebhookSubscriptionParams struct {
+	ID            int64
+	URL           string
+	Secret        *string // nil keeps the current secret
+	IsEnabled     bool
+	Events        []string
+	Area          *Area
+	MinSeverity   int
+	Categories    []string
+	RateLimit     *float64
+	PayloadFormat string
+	Digest        DigestSettings
+
+	// SecretRotationGrace is how long deliveries stay signed with the replaced secret as well.
+	SecretRotationGrace time.Duration
+}
+
+// DigestSettings batch a subscriber's alerts into one delivery every Interval seconds
+// or every MaxAlerts alerts, whichever comes first.
+//
+// @name DigestSettings
+type DigestSettings struct {
+	Interval  int `json:"interval"`   // seconds, 0 = alerts are sent one by one
+	MaxAlerts int `json:"max_alerts"` // 0 = flushed on interval only
+}
+
+// Enabled reports whether alerts are batched.
+func (d DigestSettings) Enabled() bool {
+	return d.Interval > 0
+}
+
+// @name WebhookSubscription
+type WebhookSubscription struct {
+	ID                      int64          `json:"id"`
+	URL                     string         `json:"url"`
+	Secret                  string         `json:"-"`
+	PreviousSecret          string         `json:"-"`
+	PreviousSecretExpiresAt *time.Time     `json:"previous_secret_expires_at,omitempty"`
+	IsEnabled               bool           `json:"is_enabled"`
+	Events                  []string       `json:"events"`         // empty = all alert events
+	Area                    *Area          `json:"area,omitempty"` // nil = anywhere
+	MinSeverity             int            `json:"min_severity"`   // 0 = any severity
+	Categories              []string       `json:"categories"`     // empty = all categories
+	RateLimit               *float64       `json:"rate_limit_rps"` // outbound requests per second, nil = server default, 0 = unlimited
+	PayloadFormat           string         `json:"payload_format" enums:"legacy,cloudevents_structured,cloudevents_binary"`
+	Digest                  DigestSettings `json:"digest"`
+	CreatedAt               time.Time      `json:"created_at"`
+	UpdatedAt               time.Time      `json:"updated_at"`
+}
+
+// WebhookDelivery is one attempt to deliver a payload to a subscriber.
+//
+// @name WebhookDelivery
+type WebhookDelivery struct {
+	ID             int64           `json:"id"`
+	TaskID         string          `json:"task_id"`
+	TaskType       string          `json:"task_type"`
+	SubscriptionID int64           `json:"subscription_id"`         // 0 = WEBHOOK_URL receiver
+	RedeliveryOf   *int64          `json:"redelivery_of,omitempty"` // delivery this one was manually resent from
+	EventID        string          `json:"event_id"`                // CloudEvents id, the same for retries and redeliveries
+	EventTime      *time.Time      `json:"event_time,omitempty"`
+	URL            string          `json:"url"`
+	Payload        json.RawMessage `json:"payload" swaggertype:"object"` // event data, the body in the legacy format
+	PayloadHash    string          `json:"payload_hash"`                 // hex SHA-256 of the request body
+	Attempt        int             `json:"attempt"`                      // 1 for the first try
+	Success        bool            `json:"success"`
+	StatusCode     *int            `json:"status_code,omitempty"` // nil if no response was received
+	LatencyMs      int64           `json:"latency_ms"`
+	ResponseBody   string          `json:"response_body"` // truncated
+	Error          string          `json:"error,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+type ListWebhookDeliveriesParams struct {
+	SubscriptionID *int64
+	UserID         string
+	TaskID         string
+	EventID        string
+	PayloadHash    string
+	Success        *bool
+	From           *time.Time
+	To             *time.Time
+	Limit          int
+	Offset         int
+}
+
+// @name Redelivery
+type Redelivery struct {
+	DeliveryID int64  `json:"delivery_id"`
+	TaskID     string `json:"task_id"` // attempts of the resent task are logged under this ID
+}
+
+// OutboundStats shows how the webhook worker of this instance throttles outbound requests.
+//
+// @name OutboundStats
+type OutboundStats struct {
+	Breakers []BreakerStats `json:"breakers"`
+	Limiters []LimiterStats `json:"limiters"`
+}
+
+// BreakerStats is the circuit breaker of one destination host.
+//
+// @name BreakerStats
+type BreakerStats struct {
+	Destination string     `json:"destination"`
+	State       string     `json:"state" enums:"closed,open,half_open"`
+	Failures    int        `json:"failures"`            // consecutive failed requests
+	OpenedAt    *time.Time `json:"opened_at,omitempty"` // set unless closed
+	RetryAt     *time.Time `json:"retry_at,omitempty"`  // when a probe request is let through
+}
+
+// LimiterStats is the outbound rate limiter of one subscriber.
+//
+// @name LimiterStats
+type LimiterStats struct {
+	SubscriptionID int64   `json:"subscription_id"` // 0 = WEBHOOK_URL receiver
+	RPS            float64 `json:"rps"`             // 0 = unlimited
+	Burst          int     `json:"burst"`
+	Tokens         float64 `json:"tokens"`    // requests that can go out right now, negative while reserved ones wait
+	Allowed        int64   `json:"allowed"`   // requests let through since start
+	Throttled      int64   `json:"throttled"` // tasks put off since start
+}
@@ -0,0 +1,115 @@
+package alert
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ocenb/geo-alerts/internal/domain/errs"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/http/response"
+	"github.com/ocenb/geo-alerts/internal/http/utils"
+)
+
+type Service interface {
+	Acknowledge(ctx context.Context, id string) (*models.Alert, error)
+	Unacknowledged(ctx context.Context, incidentID int64, limit, offset int) (*models.AckReport, error)
+}
+
+type Handler struct {
+	service Service
+}
+
+func New(service Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// Acknowledge godoc
+// @Summary      Acknowledge an alert
+// @Description  Called by webhook receivers or the user's app with the alert_id the alert came with. Stores the time
+// @Description  to acknowledge and stops the alert from being resent or escalated. Only the first acknowledgement counts.
+// @Tags         alerts
+// @Produce      json
+// @Param        id   path      string  true  "Alert ID"
+// @Success      200  {object}  models.Alert
+// @Failure      400  {object}  response.ErrorResponse "Invalid ID format"
+// @Failure      404  {object}  response.ErrorResponse "Alert not found"
+// @Failure      500  {object}  response.ErrorResponse "Internal server error"
+// @Router       /alerts/{id}/ack [post]
+func (h *Handler) acknowledge(c *gin.Context) {
+	var uri IDURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+
+	alert, err := h.service.Acknowledge(c.Request.Context(), uri.ID)
+	if err != nil {
+		if errors.Is(err, errs.ErrAlertNotFound) {
+			response.NotFoundError(c, "Alert not found")
+			return
+		}
+		response.InternalError(c)
+		return
+	}
+
+	response.OK(c, alert)
+}
+
+// Unacknowledged godoc
+// @Summary      Unacknowledged alerts of an incident
+// @Description  Counts the alerts sent about the incident and the acknowledged ones, with the average time to acknowledge,
+// @Description  and lists the alerts nobody acknowledged, oldest first.
+// @Tags         alerts
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        id      path      int  true   "Incident ID"
+// @Param        limit   query     int  false  "Limit (default 100)"
+// @Param        offset  query     int  false  "Offset (default 0)"
+// @Success      200     {object}  models.AckReport
+// @Failure      400     {object}  response.ErrorResponse "Invalid query parameters or ID"
+// @Failure      404     {object}  response.ErrorResponse "Incident not found"
+// @Failure      500     {object}  response.ErrorResponse "Internal server error"
+// @Router       /incidents/{id}/unacknowledged-alerts [get]
+func (h *Handler) unacknowledged(c *gin.Context) {
+	id, err := utils.ParseID(c, "id")
+	if err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+
+	var req ListUnacknowledgedReq
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+	if req.Limit == 0 {
+		req.Limit = defaultListLimit
+	}
+
+	report, err := h.service.Unacknowledged(c.Request.Context(), id, req.Limit, req.Offset)
+	if err != nil {
+		if errors.Is(err, errs.ErrIncidentNotFound) {
+			response.NotFoundError(c, "Incident not found")
+			return
+		}
+		response.InternalError(c)
+		return
+	}
+
+	response.OK(c, report)
+}
+
+// RegisterRoutes registers the operator routes.
+func (h *Handler) RegisterRoutes(router *gin.RouterGroup) {
+	incRouter := router.Group("/incidents")
+	incRouter.GET(":id/unacknowledged-alerts", h.unacknowledged)
+}
+
+// RegisterPublicRoutes registers the routes receivers and apps call.
+func (h *Handler) RegisterPublicRoutes(router *gin.RouterGroup) {
+	alertRouter := router.Group("/alerts")
+	alertRouter.POST(":id/ack", h.acknowledge)
+}
@@ -0,0 +1,14 @@
+package alert
+
+const defaultListLimit = 100
+
+// @name AlertIDURI
+type IDURI struct {
+	ID string `uri:"id" binding:"required,uuid"`
+}
+
+// @name ListUnacknowledgedRequest
+type ListUnacknowledgedReq struct {
+	Limit  int `form:"limit" binding:"omitempty,min=1,max=1000"`
+	Offset int `form:"offset" binding:"omitempty,min=0"`
+}
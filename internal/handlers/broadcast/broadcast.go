@@ -0,0 +1,203 @@
+package broadcast
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ocenb/geo-alerts/internal/domain/errs"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/http/response"
+	"github.com/ocenb/geo-alerts/internal/http/utils"
+)
+
+type Service interface {
+	Create(ctx context.Context, params *models.CreateBroadcastParams) (*models.Broadcast, error)
+	GetByID(ctx context.Context, id int64) (*models.Broadcast, error)
+	List(ctx context.Context, limit, offset int) ([]models.Broadcast, error)
+	ListRecipients(ctx context.Context, params *models.ListBroadcastRecipientsParams) ([]models.BroadcastRecipient, error)
+}
+
+type Handler struct {
+	service Service
+}
+
+func New(service Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// Create godoc
+// @Summary      Broadcast a message to an area
+// @Description  Pushes the message to the registered devices of every user whose last location, no older than recency_minutes,
+// @Description  is inside the incident zone or the given area (bbox, polygon or circle). Recipients are looked up in the background,
+// @Description  follow the progress with GET /broadcasts/{id}.
+// @Tags         broadcasts
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        input body CreateReq true "Broadcast"
+// @Success      202  {object}  models.Broadcast
+// @Failure      400  {object}  response.ErrorResponse "Invalid input"
+// @Failure      404  {object}  response.ErrorResponse "Incident not found"
+// @Failure      500  {object}  response.ErrorResponse "Internal server error"
+// @Router       /broadcasts [post]
+func (h *Handler) create(c *gin.Context) {
+	var req CreateReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+	if req.IncidentID != nil && req.Area != nil {
+		response.BadRequestError(c, "incident_id and area are mutually exclusive")
+		return
+	}
+
+	area, err := utils.ToArea(req.Area)
+	if err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+
+	params := &models.CreateBroadcastParams{
+		IncidentID: req.IncidentID,
+		Area:       area,
+		Title:      req.Title,
+		Message:    req.Message,
+		Recency:    time.Duration(req.RecencyMinutes) * time.Minute,
+	}
+
+	b, err := h.service.Create(c.Request.Context(), params)
+	if err != nil {
+		if errors.Is(err, errs.ErrIncidentNotFound) {
+			response.NotFoundError(c, "Incident not found")
+			return
+		}
+		response.InternalError(c)
+		return
+	}
+
+	response.Accepted(c, b)
+}
+
+// GetByID godoc
+// @Summary      Get broadcast progress
+// @Description  Returns the broadcast with the number of users found, of those without a device, and of notifications sent and failed.
+// @Tags         broadcasts
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        id   path      int  true  "Broadcast ID"
+// @Success      200  {object}  models.Broadcast
+// @Failure      400  {object}  response.ErrorResponse "Invalid ID format"
+// @Failure      404  {object}  response.ErrorResponse "Broadcast not found"
+// @Failure      500  {object}  response.ErrorResponse "Internal server error"
+// @Router       /broadcasts/{id} [get]
+func (h *Handler) getByID(c *gin.Context) {
+	id, err := utils.ParseID(c, "id")
+	if err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+
+	b, err := h.service.GetByID(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, errs.ErrBroadcastNotFound) {
+			response.NotFoundError(c, "Broadcast not found")
+			return
+		}
+		response.InternalError(c)
+		return
+	}
+
+	response.OK(c, b)
+}
+
+// List godoc
+// @Summary      List broadcasts
+// @Description  Newest first.
+// @Tags         broadcasts
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        limit   query     int  false  "Limit (default 10)"
+// @Param        offset  query     int  false  "Offset (default 0)"
+// @Success      200     {array}   models.Broadcast
+// @Failure      400     {object}  response.ErrorResponse "Invalid query parameters"
+// @Failure      500     {object}  response.ErrorResponse "Internal server error"
+// @Router       /broadcasts [get]
+func (h *Handler) list(c *gin.Context) {
+	var req ListReq
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+	if req.Limit == 0 {
+		req.Limit = defaultListLimit
+	}
+
+	broadcasts, err := h.service.List(c.Request.Context(), req.Limit, req.Offset)
+	if err != nil {
+		response.InternalError(c)
+		return
+	}
+
+	response.OK(c, broadcasts)
+}
+
+// ListRecipients godoc
+// @Summary      Broadcast delivery report
+// @Description  Lists the devices the broadcast was pushed to with the outcome of each notification.
+// @Tags         broadcasts
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        id      path      int     true   "Broadcast ID"
+// @Param        status  query     string  false  "Filter by status" Enums(pending, sent, failed)
+// @Param        limit   query     int     false  "Limit (default 10)"
+// @Param        offset  query     int     false  "Offset (default 0)"
+// @Success      200     {array}   models.BroadcastRecipient
+// @Failure      400     {object}  response.ErrorResponse "Invalid query parameters or ID"
+// @Failure      404     {object}  response.ErrorResponse "Broadcast not found"
+// @Failure      500     {object}  response.ErrorResponse "Internal server error"
+// @Router       /broadcasts/{id}/recipients [get]
+func (h *Handler) listRecipients(c *gin.Context) {
+	id, err := utils.ParseID(c, "id")
+	if err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+
+	var req ListRecipientsReq
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+	if req.Limit == 0 {
+		req.Limit = defaultListLimit
+	}
+
+	recipients, err := h.service.ListRecipients(c.Request.Context(), &models.ListBroadcastRecipientsParams{
+		BroadcastID: id,
+		Status:      req.Status,
+		Limit:       req.Limit,
+		Offset:      req.Offset,
+	})
+	if err != nil {
+		if errors.Is(err, errs.ErrBroadcastNotFound) {
+			response.NotFoundError(c, "Broadcast not found")
+			return
+		}
+		response.InternalError(c)
+		return
+	}
+
+	response.OK(c, recipients)
+}
+
+func (h *Handler) RegisterRoutes(router *gin.RouterGroup) {
+	broadcastRouter := router.Group("/broadcasts")
+	broadcastRouter.POST("", h.create)
+	broadcastRouter.GET("", h.list)
+	broadcastRouter.GET(":id", h.getByID)
+	broadcastRouter.GET(":id/recipients", h.listRecipients)
+}
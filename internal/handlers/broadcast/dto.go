@@ -0,0 +1,27 @@
+package broadcast
+
+import "github.com/ocenb/geo-alerts/internal/http/utils"
+
+const defaultListLimit = 10
+
+// @name CreateBroadcastRequest
+type CreateReq struct {
+	IncidentID     *int64         `json:"incident_id" binding:"required_without=Area,omitempty,min=1"` // target the incident zone
+	Area           *utils.AreaReq `json:"area" binding:"required_without=IncidentID"`                  // or an area of your own
+	Title          string         `json:"title" binding:"required,max=255"`
+	Message        string         `json:"message" binding:"required,max=2000"`
+	RecencyMinutes int            `json:"recency_minutes" binding:"omitempty,min=1,max=10080"` // omit for the server default
+}
+
+// @name ListBroadcastsRequest
+type ListReq struct {
+	Limit  int `form:"limit" binding:"omitempty,min=1"`
+	Offset int `form:"offset" binding:"omitempty,min=0"`
+}
+
+// @name ListBroadcastRecipientsRequest
+type ListRecipientsReq struct {
+	Status string `form:"status" binding:"omitempty,oneof=pending sent failed"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1"`
+	Offset int    `form:"offset" binding:"omitempty,min=0"`
+}
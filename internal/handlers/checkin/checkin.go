@@ -0,0 +1,165 @@
+package checkin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ocenb/geo-alerts/internal/domain/errs"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/http/response"
+	"github.com/ocenb/geo-alerts/internal/http/utils"
+)
+
+type Service interface {
+	Checkin(ctx context.Context, params *models.CheckinParams) (*models.Checkin, error)
+	Unaccounted(ctx context.Context, incidentID int64, limit, offset int) (*models.UnaccountedReport, error)
+	Remind(ctx context.Context, incidentID int64) error
+}
+
+type Handler struct {
+	service Service
+}
+
+func New(service Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// Checkin godoc
+// @Summary      Check in during an incident
+// @Description  Lets a user report that they are safe, need help or have evacuated. A later check-in replaces an earlier one.
+// @Tags         checkins
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int         true  "Incident ID"
+// @Param        input  body      CheckinReq  true  "Check-in"
+// @Success      200    {object}  models.Checkin
+// @Failure      400    {object}  response.ErrorResponse "Invalid input or ID"
+// @Failure      404    {object}  response.ErrorResponse "Incident not found"
+// @Failure      409    {object}  response.ErrorResponse "Incident is not active"
+// @Failure      500    {object}  response.ErrorResponse "Internal server error"
+// @Router       /incidents/{id}/checkin [post]
+func (h *Handler) checkin(c *gin.Context) {
+	id, err := utils.ParseID(c, "id")
+	if err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+
+	var req CheckinReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+
+	checkin, err := h.service.Checkin(c.Request.Context(), &models.CheckinParams{
+		IncidentID: id,
+		UserID:     req.UserID,
+		Status:     req.Status,
+		Note:       req.Note,
+	})
+	if err != nil {
+		h.incidentError(c, err)
+		return
+	}
+
+	response.OK(c, checkin)
+}
+
+// Unaccounted godoc
+// @Summary      Unaccounted users of an incident
+// @Description  Counts check-ins by status and lists the users seen inside the zone within the stats window
+// @Description  who haven't checked in, most recently seen first.
+// @Tags         checkins
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        id      path      int  true   "Incident ID"
+// @Param        limit   query     int  false  "Limit (default 100)"
+// @Param        offset  query     int  false  "Offset (default 0)"
+// @Success      200     {object}  models.UnaccountedReport
+// @Failure      400     {object}  response.ErrorResponse "Invalid query parameters or ID"
+// @Failure      404     {object}  response.ErrorResponse "Incident not found"
+// @Failure      500     {object}  response.ErrorResponse "Internal server error"
+// @Router       /incidents/{id}/unaccounted [get]
+func (h *Handler) unaccounted(c *gin.Context) {
+	id, err := utils.ParseID(c, "id")
+	if err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+
+	var req ListUnaccountedReq
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+	if req.Limit == 0 {
+		req.Limit = defaultListLimit
+	}
+
+	report, err := h.service.Unaccounted(c.Request.Context(), id, req.Limit, req.Offset)
+	if err != nil {
+		h.incidentError(c, err)
+		return
+	}
+
+	response.OK(c, report)
+}
+
+// Remind godoc
+// @Summary      Remind unaccounted users to check in
+// @Description  Queues a push to the devices of the users seen inside the zone who haven't checked in.
+// @Tags         checkins
+// @Security     ApiKeyAuth
+// @Param        id   path  int  true  "Incident ID"
+// @Success      202  "Accepted"
+// @Failure      400  {object}  response.ErrorResponse "Invalid ID format"
+// @Failure      404  {object}  response.ErrorResponse "Incident not found"
+// @Failure      409  {object}  response.ErrorResponse "Incident is not active or a reminder is already queued"
+// @Failure      500  {object}  response.ErrorResponse "Internal server error"
+// @Router       /incidents/{id}/checkin-reminders [post]
+func (h *Handler) remind(c *gin.Context) {
+	id, err := utils.ParseID(c, "id")
+	if err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+
+	if err := h.service.Remind(c.Request.Context(), id); err != nil {
+		if errors.Is(err, errs.ErrReminderPending) {
+			response.ConflictError(c, "Reminder is already queued")
+			return
+		}
+		h.incidentError(c, err)
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+func (h *Handler) incidentError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, errs.ErrIncidentNotFound):
+		response.NotFoundError(c, "Incident not found")
+	case errors.Is(err, errs.ErrIncidentInactive):
+		response.ConflictError(c, "Incident is not active")
+	default:
+		response.InternalError(c)
+	}
+}
+
+// RegisterRoutes registers the operator routes.
+func (h *Handler) RegisterRoutes(router *gin.RouterGroup) {
+	incRouter := router.Group("/incidents")
+	incRouter.GET(":id/unaccounted", h.unaccounted)
+	incRouter.POST(":id/checkin-reminders", h.remind)
+}
+
+// RegisterPublicRoutes registers the routes users call.
+func (h *Handler) RegisterPublicRoutes(router *gin.RouterGroup) {
+	incRouter := router.Group("/incidents")
+	incRouter.POST(":id/checkin", h.checkin)
+}
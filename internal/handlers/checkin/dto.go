@@ -0,0 +1,16 @@
+package checkin
+
+const defaultListLimit = 100
+
+// @name CheckinRequest
+type CheckinReq struct {
+	UserID string `json:"user_id" binding:"required,min=1,max=255"`
+	Status string `json:"status" binding:"required,oneof=safe need_help evacuated"`
+	Note   string `json:"note" binding:"max=500"`
+}
+
+// @name ListUnaccountedRequest
+type ListUnaccountedReq struct {
+	Limit  int `form:"limit" binding:"omitempty,min=1,max=1000"`
+	Offset int `form:"offset" binding:"omitempty,min=0"`
+}
@@ -0,0 +1,104 @@
+package device
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ocenb/geo-alerts/internal/domain/errs"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/http/response"
+)
+
+const defaultLocale = "en"
+
+type Service interface {
+	Register(ctx context.Context, params *models.RegisterDeviceParams) (*models.Device, error)
+	Unregister(ctx context.Context, userID, token string) error
+}
+
+type Handler struct {
+	service Service
+}
+
+func New(service Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// Register godoc
+// @Summary      Register a device
+// @Description  Registers a device the user's danger alerts are pushed to. Registering a known token again
+// @Description  updates its platform and locale and moves it to the given user.
+// @Tags         devices
+// @Accept       json
+// @Produce      json
+// @Param        input body RegisterReq true "Device"
+// @Success      200  {object}  models.Device
+// @Failure      400  {object}  response.ErrorResponse "Invalid input"
+// @Failure      500  {object}  response.ErrorResponse "Internal server error"
+// @Router       /devices [post]
+func (h *Handler) register(c *gin.Context) {
+	var req RegisterReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+
+	params := &models.RegisterDeviceParams{
+		UserID:   req.UserID,
+		Token:    req.Token,
+		Platform: req.Platform,
+		Locale:   req.Locale,
+	}
+	if params.Locale == "" {
+		params.Locale = defaultLocale
+	}
+
+	device, err := h.service.Register(c.Request.Context(), params)
+	if err != nil {
+		response.InternalError(c)
+		return
+	}
+
+	response.OK(c, device)
+}
+
+// Unregister godoc
+// @Summary      Unregister a device
+// @Description  Stops pushing alerts to the device, e.g. when the user signs out.
+// @Tags         devices
+// @Produce      json
+// @Param        token    path      string  true  "Push token"
+// @Param        user_id  query     string  true  "User the device is registered for"
+// @Success      204
+// @Failure      400  {object}  response.ErrorResponse "Invalid input"
+// @Failure      404  {object}  response.ErrorResponse "Device not found"
+// @Failure      500  {object}  response.ErrorResponse "Internal server error"
+// @Router       /devices/{token} [delete]
+func (h *Handler) unregister(c *gin.Context) {
+	var req UnregisterReq
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+
+	if err := h.service.Unregister(c.Request.Context(), req.UserID, c.Param("token")); err != nil {
+		if errors.Is(err, errs.ErrDeviceNotFound) {
+			response.NotFoundError(c, "Device not found")
+			return
+		}
+		response.InternalError(c)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Handler) RegisterRoutes(router *gin.RouterGroup) {
+	deviceRouter := router.Group("/devices")
+	deviceRouter.POST("", h.register)
+	deviceRouter.DELETE(":token", h.unregister)
+}
@@ -0,0 +1,14 @@
+package device
+
+// @name RegisterDeviceRequest
+type RegisterReq struct {
+	UserID   string `json:"user_id" binding:"required,min=1,max=255"`
+	Token    string `json:"token" binding:"required,min=1,max=4096"`
+	Platform string `json:"platform" binding:"required,oneof=android ios"`
+	Locale   string `json:"locale" binding:"omitempty,bcp47_language_tag"` // default en
+}
+
+// @name UnregisterDeviceRequest
+type UnregisterReq struct {
+	UserID string `form:"user_id" binding:"required,min=1,max=255"`
+}
@@ -0,0 +1,12 @@
+package escalation
+
+// @name EscalationSeverityURI
+type SeverityURI struct {
+	Severity int `uri:"severity" binding:"required,min=1,max=5"`
+}
+
+// @name UpdateEscalationPolicyRequest
+type UpdateReq struct {
+	RealertAfter  int `json:"realert_after" binding:"min=0,max=86400,required_without=EscalateAfter" example:"600"` // seconds inside before the user is alerted again, 0 = never
+	EscalateAfter int `json:"escalate_after" binding:"min=0,max=86400" example:"1800"`                              // seconds inside before duty officers are told, 0 = never
+}
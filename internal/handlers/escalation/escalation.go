@@ -0,0 +1,125 @@
+package escalation
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ocenb/geo-alerts/internal/domain/errs"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/http/response"
+)
+
+type Service interface {
+	List(ctx context.Context) ([]models.EscalationPolicy, error)
+	Update(ctx context.Context, params *models.UpdateEscalationPolicyParams) (*models.EscalationPolicy, error)
+	Delete(ctx context.Context, severity int) error
+}
+
+type Handler struct {
+	service Service
+}
+
+func New(service Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// List godoc
+// @Summary      List escalation policies
+// @Description  Returns the policy of each severity that has one. Users in zones of other severities are alerted once.
+// @Tags         escalation
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Success      200  {array}   models.EscalationPolicy
+// @Failure      500  {object}  response.ErrorResponse "Internal server error"
+// @Router       /escalation-policies [get]
+func (h *Handler) list(c *gin.Context) {
+	policies, err := h.service.List(c.Request.Context())
+	if err != nil {
+		response.InternalError(c)
+		return
+	}
+
+	response.OK(c, policies)
+}
+
+// Update godoc
+// @Summary      Set the escalation policy of a severity
+// @Description  Sets how long a user may stay inside the zone of an incident of the severity, as told by consecutive checks,
+// @Description  before they are alerted again and before duty officers are told over their channels. The follow-ups are dropped
+// @Description  when the user leaves the zone or the incident is deactivated. The policy applies to users entering a zone from now on.
+// @Tags         escalation
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        severity  path      int        true  "Incident severity (1-5)"
+// @Param        input     body      UpdateReq  true  "Policy"
+// @Success      200       {object}  models.EscalationPolicy
+// @Failure      400       {object}  response.ErrorResponse "Invalid input or severity"
+// @Failure      500       {object}  response.ErrorResponse "Internal server error"
+// @Router       /escalation-policies/{severity} [put]
+func (h *Handler) update(c *gin.Context) {
+	var uri SeverityURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+
+	var req UpdateReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+
+	policy, err := h.service.Update(c.Request.Context(), &models.UpdateEscalationPolicyParams{
+		Severity:      uri.Severity,
+		RealertAfter:  req.RealertAfter,
+		EscalateAfter: req.EscalateAfter,
+	})
+	if err != nil {
+		response.InternalError(c)
+		return
+	}
+
+	response.OK(c, policy)
+}
+
+// Delete godoc
+// @Summary      Delete the escalation policy of a severity
+// @Description  Users entering zones of the severity are alerted once again. Follow-ups already scheduled still run.
+// @Tags         escalation
+// @Security     ApiKeyAuth
+// @Param        severity  path  int  true  "Incident severity (1-5)"
+// @Success      204  "No Content"
+// @Failure      400  {object}  response.ErrorResponse "Invalid severity"
+// @Failure      404  {object}  response.ErrorResponse "Policy not found"
+// @Failure      500  {object}  response.ErrorResponse "Internal server error"
+// @Router       /escalation-policies/{severity} [delete]
+func (h *Handler) delete(c *gin.Context) {
+	var uri SeverityURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), uri.Severity); err != nil {
+		if errors.Is(err, errs.ErrEscalationPolicyNotFound) {
+			response.NotFoundError(c, "Policy not found")
+			return
+		}
+		response.InternalError(c)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Handler) RegisterRoutes(router *gin.RouterGroup) {
+	policyRouter := router.Group("/escalation-policies")
+	policyRouter.GET("", h.list)
+	policyRouter.PUT(":severity", h.update)
+	policyRouter.DELETE(":severity", h.delete)
+}
@@ -0,0 +1,59 @@
+package guardian
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+)
+
+const defaultListLimit = 10
+
+// @name CreateGuardianGroupRequest
+type CreateReq struct {
+	Name      string        `json:"name" binding:"required,max=255"`
+	Guardians []GuardianReq `json:"guardians" binding:"required,min=1,max=20,dive"`
+	UserIDs   []string      `json:"user_ids" binding:"omitempty,max=1000,dive,min=1,max=255"` // added pending their consent
+}
+
+// @name GuardianRequest
+type GuardianReq struct {
+	Name    string `json:"name" binding:"max=255"`
+	Channel string `json:"channel" binding:"required,oneof=webhook email sms"`
+	Address string `json:"address" binding:"required,max=2048"` // URL, email address or E.164 phone number, depending on the channel
+}
+
+// addressTags are the validation rules of guardian addresses by channel, the same as for duty officers.
+var addressTags = map[string]string{
+	models.GuardianWebhook: "url",
+	models.GuardianEmail:   "email",
+	models.GuardianSMS:     "e164",
+}
+
+func (r *GuardianReq) validateAddress() error {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return nil
+	}
+	if err := v.Var(r.Address, addressTags[r.Channel]); err != nil {
+		return fmt.Errorf("invalid %s address %q", r.Channel, r.Address)
+	}
+	return nil
+}
+
+// @name AddGroupMembersRequest
+type AddMembersReq struct {
+	UserIDs []string `json:"user_ids" binding:"required,min=1,max=1000,dive,min=1,max=255"`
+}
+
+// @name GroupConsentRequest
+type ConsentReq struct {
+	UserID string `json:"user_id" form:"user_id" binding:"required,min=1,max=255"`
+}
+
+// @name ListGuardianGroupsRequest
+type ListReq struct {
+	Limit  int `form:"limit" binding:"omitempty,min=1"`
+	Offset int `form:"offset" binding:"omitempty,min=0"`
+}
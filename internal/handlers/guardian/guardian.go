@@ -0,0 +1,339 @@
+package guardian
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ocenb/geo-alerts/internal/domain/errs"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/http/response"
+	"github.com/ocenb/geo-alerts/internal/http/utils"
+)
+
+type Service interface {
+	Create(ctx context.Context, params *models.CreateGuardianGroupParams) (*models.GuardianGroup, error)
+	GetByID(ctx context.Context, id int64) (*models.GuardianGroup, error)
+	List(ctx context.Context, limit, offset int) ([]models.GuardianGroup, error)
+	Delete(ctx context.Context, id int64) error
+	AddMembers(ctx context.Context, groupID int64, userIDs []string) (*models.GuardianGroup, error)
+	RemoveMember(ctx context.Context, groupID int64, userID string) error
+	SetConsent(ctx context.Context, groupID int64, userID string, consent bool) (*models.GroupMember, error)
+	ListMemberships(ctx context.Context, userID string) ([]models.GroupMember, error)
+}
+
+type Handler struct {
+	service Service
+}
+
+func New(service Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// Create godoc
+// @Summary      Create a guardian group
+// @Description  Links guardians, such as parents or a school, to the users they look after. When a member is detected
+// @Description  in a danger zone, every guardian of the group is alerted over their channel, naming the member and the incidents.
+// @Description  Members are added pending and are alerted on only once they consent.
+// @Tags         guardians
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        input body CreateReq true "Group"
+// @Success      201  {object}  models.GuardianGroup
+// @Failure      400  {object}  response.ErrorResponse "Invalid input"
+// @Failure      500  {object}  response.ErrorResponse "Internal server error"
+// @Router       /guardian-groups [post]
+func (h *Handler) create(c *gin.Context) {
+	var req CreateReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+
+	guardians := make([]models.GuardianParams, 0, len(req.Guardians))
+	for _, g := range req.Guardians {
+		if err := g.validateAddress(); err != nil {
+			response.BadRequestError(c, err.Error())
+			return
+		}
+		guardians = append(guardians, models.GuardianParams{Name: g.Name, Channel: g.Channel, Address: g.Address})
+	}
+
+	group, err := h.service.Create(c.Request.Context(), &models.CreateGuardianGroupParams{
+		Name:      req.Name,
+		Guardians: guardians,
+		UserIDs:   req.UserIDs,
+	})
+	if err != nil {
+		response.InternalError(c)
+		return
+	}
+
+	response.Created(c, group)
+}
+
+// GetByID godoc
+// @Summary      Get a guardian group
+// @Description  Returns the group with its guardians and members and the consent of each.
+// @Tags         guardians
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        id   path      int  true  "Group ID"
+// @Success      200  {object}  models.GuardianGroup
+// @Failure      400  {object}  response.ErrorResponse "Invalid ID format"
+// @Failure      404  {object}  response.ErrorResponse "Group not found"
+// @Failure      500  {object}  response.ErrorResponse "Internal server error"
+// @Router       /guardian-groups/{id} [get]
+func (h *Handler) getByID(c *gin.Context) {
+	id, err := utils.ParseID(c, "id")
+	if err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+
+	group, err := h.service.GetByID(c.Request.Context(), id)
+	if err != nil {
+		h.groupError(c, err)
+		return
+	}
+
+	response.OK(c, group)
+}
+
+// List godoc
+// @Summary      List guardian groups
+// @Description  Newest first, with guardians but without members.
+// @Tags         guardians
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        limit   query     int  false  "Limit (default 10)"
+// @Param        offset  query     int  false  "Offset (default 0)"
+// @Success      200     {array}   models.GuardianGroup
+// @Failure      400     {object}  response.ErrorResponse "Invalid query parameters"
+// @Failure      500     {object}  response.ErrorResponse "Internal server error"
+// @Router       /guardian-groups [get]
+func (h *Handler) list(c *gin.Context) {
+	var req ListReq
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+	if req.Limit == 0 {
+		req.Limit = defaultListLimit
+	}
+
+	groups, err := h.service.List(c.Request.Context(), req.Limit, req.Offset)
+	if err != nil {
+		response.InternalError(c)
+		return
+	}
+
+	response.OK(c, groups)
+}
+
+// Delete godoc
+// @Summary      Delete a guardian group
+// @Description  Stops alerting the guardians of the group.
+// @Tags         guardians
+// @Security     ApiKeyAuth
+// @Param        id   path      int  true  "Group ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  response.ErrorResponse "Invalid ID format"
+// @Failure      404  {object}  response.ErrorResponse "Group not found"
+// @Failure      500  {object}  response.ErrorResponse "Internal server error"
+// @Router       /guardian-groups/{id} [delete]
+func (h *Handler) delete(c *gin.Context) {
+	id, err := utils.ParseID(c, "id")
+	if err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), id); err != nil {
+		h.groupError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// AddMembers godoc
+// @Summary      Add members to a guardian group
+// @Description  Adds the users pending their consent. Users already in the group keep theirs.
+// @Tags         guardians
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        id     path      int            true  "Group ID"
+// @Param        input  body      AddMembersReq  true  "Members"
+// @Success      200    {object}  models.GuardianGroup
+// @Failure      400    {object}  response.ErrorResponse "Invalid input or ID"
+// @Failure      404    {object}  response.ErrorResponse "Group not found"
+// @Failure      500    {object}  response.ErrorResponse "Internal server error"
+// @Router       /guardian-groups/{id}/members [post]
+func (h *Handler) addMembers(c *gin.Context) {
+	id, err := utils.ParseID(c, "id")
+	if err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+
+	var req AddMembersReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+
+	group, err := h.service.AddMembers(c.Request.Context(), id, req.UserIDs)
+	if err != nil {
+		h.groupError(c, err)
+		return
+	}
+
+	response.OK(c, group)
+}
+
+// RemoveMember godoc
+// @Summary      Remove a member from a guardian group
+// @Tags         guardians
+// @Security     ApiKeyAuth
+// @Param        id       path  int     true  "Group ID"
+// @Param        user_id  path  string  true  "User ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  response.ErrorResponse "Invalid ID format"
+// @Failure      404  {object}  response.ErrorResponse "Member not found"
+// @Failure      500  {object}  response.ErrorResponse "Internal server error"
+// @Router       /guardian-groups/{id}/members/{user_id} [delete]
+func (h *Handler) removeMember(c *gin.Context) {
+	id, err := utils.ParseID(c, "id")
+	if err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+
+	if err := h.service.RemoveMember(c.Request.Context(), id, c.Param("user_id")); err != nil {
+		h.groupError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Consent godoc
+// @Summary      Consent to guardian alerts
+// @Description  Opts the member in to the guardians of the group being alerted when they are in a danger zone.
+// @Tags         guardians
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int         true  "Group ID"
+// @Param        input  body      ConsentReq  true  "Member"
+// @Success      200    {object}  models.GroupMember
+// @Failure      400    {object}  response.ErrorResponse "Invalid input or ID"
+// @Failure      404    {object}  response.ErrorResponse "User isn't a member of the group"
+// @Failure      500    {object}  response.ErrorResponse "Internal server error"
+// @Router       /guardian-groups/{id}/consent [post]
+func (h *Handler) consent(c *gin.Context) {
+	var req ConsentReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+	h.setConsent(c, req.UserID, true)
+}
+
+// RevokeConsent godoc
+// @Summary      Revoke consent to guardian alerts
+// @Description  Opts the member out. The member stays in the group and can consent again.
+// @Tags         guardians
+// @Produce      json
+// @Param        id       path      int     true  "Group ID"
+// @Param        user_id  query     string  true  "Member"
+// @Success      200      {object}  models.GroupMember
+// @Failure      400      {object}  response.ErrorResponse "Invalid input or ID"
+// @Failure      404      {object}  response.ErrorResponse "User isn't a member of the group"
+// @Failure      500      {object}  response.ErrorResponse "Internal server error"
+// @Router       /guardian-groups/{id}/consent [delete]
+func (h *Handler) revokeConsent(c *gin.Context) {
+	var req ConsentReq
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+	h.setConsent(c, req.UserID, false)
+}
+
+func (h *Handler) setConsent(c *gin.Context, userID string, consent bool) {
+	id, err := utils.ParseID(c, "id")
+	if err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+
+	member, err := h.service.SetConsent(c.Request.Context(), id, userID, consent)
+	if err != nil {
+		h.groupError(c, err)
+		return
+	}
+
+	response.OK(c, member)
+}
+
+// ListMemberships godoc
+// @Summary      List a user's guardian groups
+// @Description  Returns the groups the user was added to with their consent, so that they can review pending invitations.
+// @Tags         guardians
+// @Produce      json
+// @Param        user_id  query     string  true  "User ID"
+// @Success      200      {array}   models.GroupMember
+// @Failure      400      {object}  response.ErrorResponse "Invalid input"
+// @Failure      500      {object}  response.ErrorResponse "Internal server error"
+// @Router       /guardian-groups/memberships [get]
+func (h *Handler) listMemberships(c *gin.Context) {
+	var req ConsentReq
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+
+	members, err := h.service.ListMemberships(c.Request.Context(), req.UserID)
+	if err != nil {
+		response.InternalError(c)
+		return
+	}
+
+	response.OK(c, members)
+}
+
+func (h *Handler) groupError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, errs.ErrGuardianGroupNotFound):
+		response.NotFoundError(c, "Group not found")
+	case errors.Is(err, errs.ErrGroupMemberNotFound):
+		response.NotFoundError(c, "User isn't a member of the group")
+	default:
+		response.InternalError(c)
+	}
+}
+
+// RegisterRoutes registers the operator routes.
+func (h *Handler) RegisterRoutes(router *gin.RouterGroup) {
+	groupRouter := router.Group("/guardian-groups")
+	groupRouter.POST("", h.create)
+	groupRouter.GET("", h.list)
+	groupRouter.GET(":id", h.getByID)
+	groupRouter.DELETE(":id", h.delete)
+	groupRouter.POST(":id/members", h.addMembers)
+	groupRouter.DELETE(":id/members/:user_id", h.removeMember)
+}
+
+// RegisterPublicRoutes registers the routes members call.
+func (h *Handler) RegisterPublicRoutes(router *gin.RouterGroup) {
+	groupRouter := router.Group("/guardian-groups")
+	groupRouter.GET("/memberships", h.listMemberships)
+	groupRouter.POST(":id/consent", h.consent)
+	groupRouter.DELETE(":id/consent", h.revokeConsent)
+}
@@ -8,14 +8,24 @@ type ListReq struct {
 
 // @name CreateIncidentRequest
 type CreateReq struct {
-	Latitude  *float64 `json:"latitude" binding:"required,latitude"`
-	Longitude *float64 `json:"longitude" binding:"required,longitude"`
-	Radius    int      `json:"radius" binding:"required,min=1"`
+	Latitude       *float64 `json:"latitude" binding:"required,latitude"`
+	Longitude      *float64 `json:"longitude" binding:"required,longitude"`
+	Radius         int      `json:"radius" binding:"required,min=1"`
+	ApproachBuffer *int     `json:"approach_buffer" binding:"omitempty,min=0"`
+	Severity       int      `json:"severity" binding:"omitempty,min=1,max=5"` // default 1
+	Category       string   `json:"category" binding:"omitempty,max=64,lowercase"`
+	AlertRecent    bool     `json:"alert_recent_users"` // alert users last seen in the zone within the look-back window
 }
 
 // @name UpdateIncidentRequest
 type UpdateReq struct {
-	Latitude  *float64 `json:"latitude" binding:"required,latitude"`
-	Longitude *float64 `json:"longitude" binding:"required,longitude"`
-	Radius    int      `json:"radius" binding:"required,min=1"`
+	Latitude       *float64 `json:"latitude" binding:"required,latitude"`
+	Longitude      *float64 `json:"longitude" binding:"required,longitude"`
+	Radius         int      `json:"radius" binding:"required,min=1"`
+	ApproachBuffer *int     `json:"approach_buffer" binding:"omitempty,min=0"`     // omit to keep the current buffer
+	Severity       *int     `json:"severity" binding:"omitempty,min=1,max=5"`      // omit to keep the current severity
+	Category       *string  `json:"category" binding:"omitempty,max=64,lowercase"` // omit to keep the current category
+	AlertRecent    bool     `json:"alert_recent_users"`                            // alert users last seen in the new zone within the look-back window, except those already alerted
+
+	ClearApproachBuffer bool `json:"clear_approach_buffer" binding:"excluded_with=ApproachBuffer"` // drop the buffer to use the default approach distance
 }
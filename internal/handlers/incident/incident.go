@@ -34,6 +34,7 @@ func New(service Service) *Handler {
 // CreateIncident godoc
 // @Summary      Create a new incident
 // @Description  Creates a dangerous zone incident. Returns the created incident.
+// @Description  With alert_recent_users, users whose last check within the look-back window is inside the zone are alerted right away.
 // @Tags         incidents
 // @Accept       json
 // @Produce      json
@@ -52,9 +53,14 @@ func (h *Handler) create(c *gin.Context) {
 	}
 
 	params := &models.CreateIncidentParams{
-		Latitude:  *req.Latitude,
-		Longitude: *req.Longitude,
-		Radius:    req.Radius,
+		Latitude:       *req.Latitude,
+		Longitude:      *req.Longitude,
+		Radius:         req.Radius,
+		ApproachBuffer: req.ApproachBuffer,
+		Severity:       max(req.Severity, models.SeverityMin),
+		Category:       req.Category,
+
+		AlertRecentUsers: req.AlertRecent,
 	}
 
 	inc, err := h.service.Create(c.Request.Context(), params)
@@ -132,7 +138,10 @@ func (h *Handler) list(c *gin.Context) {
 
 // UpdateIncident godoc
 // @Summary      Update incident
-// @Description  Updates location or radius of an existing incident.
+// @Description  Updates location, radius or approach buffer of an existing incident. Omitted approach buffer, severity
+// @Description  and category are kept, clear_approach_buffer drops the buffer.
+// @Description  With alert_recent_users, users whose last check within the look-back window is inside the new zone
+// @Description  and who haven't been alerted about the incident yet are alerted right away.
 // @Tags         incidents
 // @Accept       json
 // @Produce      json
@@ -159,10 +168,16 @@ func (h *Handler) update(c *gin.Context) {
 	}
 
 	params := &models.UpdateIncidentParams{
-		ID:        id,
-		Latitude:  *req.Latitude,
-		Longitude: *req.Longitude,
-		Radius:    req.Radius,
+		ID:             id,
+		Latitude:       *req.Latitude,
+		Longitude:      *req.Longitude,
+		Radius:         req.Radius,
+		ApproachBuffer: req.ApproachBuffer,
+		Severity:       req.Severity,
+		Category:       req.Category,
+
+		ClearApproachBuffer: req.ClearApproachBuffer,
+		AlertRecentUsers:    req.AlertRecent,
 	}
 
 	inc, err := h.service.Update(c.Request.Context(), params)
@@ -1,8 +1,14 @@
 package location
 
+import "time"
+
 // @name CheckLocationRequest
 type CheckReq struct {
-	UserID    string   `json:"user_id" binding:"required,min=1,max=255"`
-	Latitude  *float64 `json:"latitude" binding:"required,latitude"`
-	Longitude *float64 `json:"longitude" binding:"required,longitude"`
+	UserID    string     `json:"user_id" binding:"required,min=1,max=255"`
+	Latitude  *float64   `json:"latitude" binding:"required,latitude"`
+	Longitude *float64   `json:"longitude" binding:"required,longitude"`
+	Accuracy  *float64   `json:"accuracy_meters" binding:"omitempty,min=0"`
+	Speed     *float64   `json:"speed" binding:"required_with=Heading,omitempty,min=0"` // meters per second
+	Heading   *float64   `json:"heading" binding:"required_with=Speed,omitempty,min=0,lt=360"`
+	Timestamp *time.Time `json:"timestamp"`
 }
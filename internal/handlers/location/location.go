@@ -25,6 +25,8 @@ func New(service Service) *Handler {
 // CheckLocation godoc
 // @Summary      Check user location
 // @Description  Checks if the user's coordinates are within any active dangerous zone.
+// @Description  With accuracy, zones overlapping the GPS uncertainty circle are reported as possibly inside.
+// @Description  With speed and heading, also reports zones the user is expected to enter soon.
 // @Tags         location
 // @Accept       json
 // @Produce      json
@@ -44,6 +46,10 @@ func (h *Handler) check(c *gin.Context) {
 		UserID:    req.UserID,
 		Latitude:  *req.Latitude,
 		Longitude: *req.Longitude,
+		Accuracy:  req.Accuracy,
+		Speed:     req.Speed,
+		Heading:   req.Heading,
+		Timestamp: req.Timestamp,
 	}
 
 	res, err := h.service.Check(c.Request.Context(), params)
@@ -0,0 +1,14 @@
+package place
+
+// @name PlaceUserURI
+type UserURI struct {
+	UserID string `uri:"user_id" binding:"required,min=1,max=255"`
+}
+
+// @name SavePlaceRequest
+type SaveReq struct {
+	Name      string   `json:"name" binding:"required,max=64" example:"home"` // unique per user
+	Latitude  *float64 `json:"latitude" binding:"required,latitude"`
+	Longitude *float64 `json:"longitude" binding:"required,longitude"`
+	Radius    int      `json:"radius" binding:"required,min=1,max=50000"` // meters
+}
@@ -0,0 +1,195 @@
+package place
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ocenb/geo-alerts/internal/domain/errs"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/http/response"
+	"github.com/ocenb/geo-alerts/internal/http/utils"
+)
+
+type Service interface {
+	Create(ctx context.Context, params *models.SavePlaceParams) (*models.SavedPlace, error)
+	Update(ctx context.Context, params *models.SavePlaceParams) (*models.SavedPlace, error)
+	Delete(ctx context.Context, userID string, id int64) error
+	List(ctx context.Context, userID string) ([]models.SavedPlace, error)
+}
+
+type Handler struct {
+	service Service
+}
+
+func New(service Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// Create godoc
+// @Summary      Save a place
+// @Description  Saves a named place, e.g. home or school, whose owner is alerted when an active incident zone
+// @Description  reaches within its radius, wherever the user is themselves. A zone already reaching it alerts right away.
+// @Tags         places
+// @Accept       json
+// @Produce      json
+// @Param        user_id  path      string   true  "User ID"
+// @Param        input    body      SaveReq  true  "Place"
+// @Success      201      {object}  models.SavedPlace
+// @Failure      400      {object}  response.ErrorResponse "Invalid input"
+// @Failure      409      {object}  response.ErrorResponse "User already has a place with this name"
+// @Failure      500      {object}  response.ErrorResponse "Internal server error"
+// @Router       /users/{user_id}/places [post]
+func (h *Handler) create(c *gin.Context) {
+	var uri UserURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+
+	var req SaveReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+
+	place, err := h.service.Create(c.Request.Context(), saveParams(0, uri.UserID, &req))
+	if err != nil {
+		h.placeError(c, err)
+		return
+	}
+
+	response.Created(c, place)
+}
+
+// List godoc
+// @Summary      List saved places
+// @Tags         places
+// @Produce      json
+// @Param        user_id  path      string  true  "User ID"
+// @Success      200      {array}   models.SavedPlace
+// @Failure      400      {object}  response.ErrorResponse "Invalid user ID"
+// @Failure      500      {object}  response.ErrorResponse "Internal server error"
+// @Router       /users/{user_id}/places [get]
+func (h *Handler) list(c *gin.Context) {
+	var uri UserURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+
+	places, err := h.service.List(c.Request.Context(), uri.UserID)
+	if err != nil {
+		response.InternalError(c)
+		return
+	}
+
+	response.OK(c, places)
+}
+
+// Update godoc
+// @Summary      Update a saved place
+// @Description  Renames, moves or resizes the place. Zones reaching it at its new extent are alerted about again.
+// @Tags         places
+// @Accept       json
+// @Produce      json
+// @Param        user_id  path      string   true  "User ID"
+// @Param        id       path      int      true  "Place ID"
+// @Param        input    body      SaveReq  true  "Place"
+// @Success      200      {object}  models.SavedPlace
+// @Failure      400      {object}  response.ErrorResponse "Invalid input or ID"
+// @Failure      404      {object}  response.ErrorResponse "Place not found"
+// @Failure      409      {object}  response.ErrorResponse "User already has a place with this name"
+// @Failure      500      {object}  response.ErrorResponse "Internal server error"
+// @Router       /users/{user_id}/places/{id} [put]
+func (h *Handler) update(c *gin.Context) {
+	var uri UserURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+
+	id, err := utils.ParseID(c, "id")
+	if err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+
+	var req SaveReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+
+	place, err := h.service.Update(c.Request.Context(), saveParams(id, uri.UserID, &req))
+	if err != nil {
+		h.placeError(c, err)
+		return
+	}
+
+	response.OK(c, place)
+}
+
+// Delete godoc
+// @Summary      Delete a saved place
+// @Tags         places
+// @Param        user_id  path  string  true  "User ID"
+// @Param        id       path  int     true  "Place ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  response.ErrorResponse "Invalid ID format"
+// @Failure      404  {object}  response.ErrorResponse "Place not found"
+// @Failure      500  {object}  response.ErrorResponse "Internal server error"
+// @Router       /users/{user_id}/places/{id} [delete]
+func (h *Handler) delete(c *gin.Context) {
+	var uri UserURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+
+	id, err := utils.ParseID(c, "id")
+	if err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), uri.UserID, id); err != nil {
+		h.placeError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func saveParams(id int64, userID string, req *SaveReq) *models.SavePlaceParams {
+	return &models.SavePlaceParams{
+		ID:        id,
+		UserID:    userID,
+		Name:      req.Name,
+		Latitude:  *req.Latitude,
+		Longitude: *req.Longitude,
+		Radius:    req.Radius,
+	}
+}
+
+func (h *Handler) placeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, errs.ErrPlaceNotFound):
+		response.NotFoundError(c, "Place not found")
+	case errors.Is(err, errs.ErrPlaceExists):
+		response.ConflictError(c, "User already has a place with this name")
+	default:
+		response.InternalError(c)
+	}
+}
+
+func (h *Handler) RegisterRoutes(router *gin.RouterGroup) {
+	userRouter := router.Group("/users")
+	userRouter.POST(":user_id/places", h.create)
+	userRouter.GET(":user_id/places", h.list)
+	userRouter.PUT(":user_id/places/:id", h.update)
+	userRouter.DELETE(":user_id/places/:id", h.delete)
+}
@@ -0,0 +1,21 @@
+package preferences
+
+// @name UserURI
+type UserURI struct {
+	UserID string `uri:"user_id" binding:"required,min=1,max=255"`
+}
+
+// @name UpdatePreferencesRequest
+type UpdateReq struct {
+	OptedOut        bool           `json:"opted_out"`                                                               // no alerts at all
+	MinSeverity     int            `json:"min_severity" binding:"min=0,max=5"`                                      // alerts about less severe incidents are held back
+	MutedCategories []string       `json:"muted_categories" binding:"omitempty,max=50,dive,min=1,max=64,lowercase"` // incident categories to hear nothing about
+	QuietHours      *QuietHoursReq `json:"quiet_hours"`
+	Timezone        string         `json:"timezone" binding:"omitempty,timezone"` // IANA name, default UTC
+}
+
+// @name QuietHoursRequest
+type QuietHoursReq struct {
+	Start string `json:"start" binding:"required,datetime=15:04" example:"22:00"`
+	End   string `json:"end" binding:"required,datetime=15:04" example:"07:00"`
+}
@@ -0,0 +1,102 @@
+package preferences
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/http/response"
+)
+
+type Service interface {
+	Get(ctx context.Context, userID string) (*models.UserPreferences, error)
+	Update(ctx context.Context, params *models.UpdatePreferencesParams) (*models.UserPreferences, error)
+}
+
+type Handler struct {
+	service Service
+}
+
+func New(service Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// Get godoc
+// @Summary      Get notification preferences
+// @Description  Returns the preferences of the user, or the defaults (every alert, any time) if they never set any.
+// @Tags         preferences
+// @Produce      json
+// @Param        user_id  path      string  true  "User ID"
+// @Success      200      {object}  models.UserPreferences
+// @Failure      400      {object}  response.ErrorResponse "Invalid user ID"
+// @Failure      500      {object}  response.ErrorResponse "Internal server error"
+// @Router       /users/{user_id}/preferences [get]
+func (h *Handler) get(c *gin.Context) {
+	var uri UserURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+
+	prefs, err := h.service.Get(c.Request.Context(), uri.UserID)
+	if err != nil {
+		response.InternalError(c)
+		return
+	}
+
+	response.OK(c, prefs)
+}
+
+// Update godoc
+// @Summary      Update notification preferences
+// @Description  Replaces the preferences of the user. Alerts the user opted out of, about incidents below min_severity
+// @Description  or in a muted category, or falling within quiet hours in the user's timezone aren't sent, and the reason is recorded.
+// @Tags         preferences
+// @Accept       json
+// @Produce      json
+// @Param        user_id  path      string     true  "User ID"
+// @Param        input    body      UpdateReq  true  "Preferences"
+// @Success      200      {object}  models.UserPreferences
+// @Failure      400      {object}  response.ErrorResponse "Invalid input"
+// @Failure      500      {object}  response.ErrorResponse "Internal server error"
+// @Router       /users/{user_id}/preferences [put]
+func (h *Handler) update(c *gin.Context) {
+	var uri UserURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+
+	var req UpdateReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+
+	params := &models.UpdatePreferencesParams{
+		UserID:          uri.UserID,
+		OptedOut:        req.OptedOut,
+		MinSeverity:     req.MinSeverity,
+		MutedCategories: req.MutedCategories,
+		Timezone:        req.Timezone,
+	}
+	if req.QuietHours != nil {
+		params.QuietHours = &models.QuietHours{Start: req.QuietHours.Start, End: req.QuietHours.End}
+	}
+
+	prefs, err := h.service.Update(c.Request.Context(), params)
+	if err != nil {
+		response.InternalError(c)
+		return
+	}
+
+	response.OK(c, prefs)
+}
+
+func (h *Handler) RegisterRoutes(router *gin.RouterGroup) {
+	userRouter := router.Group("/users")
+	userRouter.GET(":user_id/preferences", h.get)
+	userRouter.PUT(":user_id/preferences", h.update)
+}
@@ -0,0 +1,22 @@
+package queue
+
+import "time"
+
+const defaultListLimit = 10
+
+// @name ListQueueTasksRequest
+type ListTasksReq struct {
+	State  string     `form:"state" binding:"omitempty,oneof=pending retry archived"` // default archived
+	Error  string     `form:"error"`
+	From   *time.Time `form:"from"` // RFC 3339
+	To     *time.Time `form:"to"`   // RFC 3339
+	Limit  int        `form:"limit" binding:"omitempty,min=1,max=1000"`
+	Offset int        `form:"offset" binding:"omitempty,min=0"`
+}
+
+// @name ReplayArchivedRequest
+type ReplayReq struct {
+	Error string     `json:"error"` // substring of the last error
+	From  *time.Time `json:"from"`  // last failure at or after
+	To    *time.Time `json:"to"`    // last failure before
+}
@@ -0,0 +1,195 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ocenb/geo-alerts/internal/domain/errs"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/http/response"
+)
+
+type Service interface {
+	Queues(ctx context.Context) ([]models.QueueInfo, error)
+	ListTasks(ctx context.Context, queue, state string, filter models.QueueTaskFilter, limit, offset int) ([]models.QueueTask, error)
+	RunTask(ctx context.Context, queue, id string) error
+	DeleteTask(ctx context.Context, queue, id string) error
+	ReplayArchived(ctx context.Context, queue string, filter models.QueueTaskFilter) (*models.ReplayResult, error)
+}
+
+type Handler struct {
+	service Service
+}
+
+func New(service Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// ListQueues godoc
+// @Summary      List task queues
+// @Description  Returns every queue with task counts per state.
+// @Tags         queues
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Success      200  {array}   models.QueueInfo
+// @Failure      500  {object}  response.ErrorResponse "Internal server error"
+// @Router       /queues [get]
+func (h *Handler) listQueues(c *gin.Context) {
+	queues, err := h.service.Queues(c.Request.Context())
+	if err != nil {
+		response.InternalError(c)
+		return
+	}
+
+	response.OK(c, queues)
+}
+
+// ListTasks godoc
+// @Summary      List tasks in a queue
+// @Description  Returns pending, retry or archived tasks with decoded webhook payloads.
+// @Description  Error and time filters apply to the last failure of a task.
+// @Tags         queues
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        queue   path      string  true   "Queue name"
+// @Param        state   query     string  false  "Task state (default archived)" Enums(pending, retry, archived)
+// @Param        error   query     string  false  "Substring of the last error"
+// @Param        from    query     string  false  "Last failure at or after this time (RFC 3339)"
+// @Param        to      query     string  false  "Last failure before this time (RFC 3339)"
+// @Param        limit   query     int     false  "Limit (default 10)"
+// @Param        offset  query     int     false  "Offset (default 0)"
+// @Success      200     {array}   models.QueueTask
+// @Failure      400     {object}  response.ErrorResponse "Invalid query parameters"
+// @Failure      404     {object}  response.ErrorResponse "Queue not found"
+// @Failure      500     {object}  response.ErrorResponse "Internal server error"
+// @Router       /queues/{queue}/tasks [get]
+func (h *Handler) listTasks(c *gin.Context) {
+	var req ListTasksReq
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+	if req.State == "" {
+		req.State = models.TaskStateArchived
+	}
+	if req.Limit == 0 {
+		req.Limit = defaultListLimit
+	}
+
+	filter := models.QueueTaskFilter{Error: req.Error, From: req.From, To: req.To}
+
+	tasks, err := h.service.ListTasks(c.Request.Context(), c.Param("queue"), req.State, filter, req.Limit, req.Offset)
+	if err != nil {
+		if errors.Is(err, errs.ErrQueueNotFound) {
+			response.NotFoundError(c, "Queue not found")
+			return
+		}
+		response.InternalError(c)
+		return
+	}
+
+	response.OK(c, tasks)
+}
+
+// RunTask godoc
+// @Summary      Run a task now
+// @Description  Moves a retry or archived task to pending so a worker picks it up immediately.
+// @Tags         queues
+// @Security     ApiKeyAuth
+// @Param        queue  path  string  true  "Queue name"
+// @Param        id     path  string  true  "Task ID"
+// @Success      204  "No Content"
+// @Failure      404  {object}  response.ErrorResponse "Queue or task not found"
+// @Failure      409  {object}  response.ErrorResponse "Task is already pending or being processed"
+// @Failure      500  {object}  response.ErrorResponse "Internal server error"
+// @Router       /queues/{queue}/tasks/{id}/run [post]
+func (h *Handler) runTask(c *gin.Context) {
+	if err := h.service.RunTask(c.Request.Context(), c.Param("queue"), c.Param("id")); err != nil {
+		handleTaskErr(c, err, "Task is already pending or being processed")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// DeleteTask godoc
+// @Summary      Delete a task
+// @Tags         queues
+// @Security     ApiKeyAuth
+// @Param        queue  path  string  true  "Queue name"
+// @Param        id     path  string  true  "Task ID"
+// @Success      204  "No Content"
+// @Failure      404  {object}  response.ErrorResponse "Queue or task not found"
+// @Failure      409  {object}  response.ErrorResponse "Task is being processed"
+// @Failure      500  {object}  response.ErrorResponse "Internal server error"
+// @Router       /queues/{queue}/tasks/{id} [delete]
+func (h *Handler) deleteTask(c *gin.Context) {
+	if err := h.service.DeleteTask(c.Request.Context(), c.Param("queue"), c.Param("id")); err != nil {
+		handleTaskErr(c, err, "Task is being processed")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ReplayArchived godoc
+// @Summary      Replay archived tasks
+// @Description  Moves archived tasks that match the filter back to pending. An empty filter replays the whole archive.
+// @Tags         queues
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        queue  path      string     true  "Queue name"
+// @Param        input  body      ReplayReq  true  "Filter by the last failure"
+// @Success      200    {object}  models.ReplayResult
+// @Failure      400    {object}  response.ErrorResponse "Invalid input"
+// @Failure      404    {object}  response.ErrorResponse "Queue not found"
+// @Failure      500    {object}  response.ErrorResponse "Internal server error"
+// @Router       /queues/{queue}/archived/replay [post]
+func (h *Handler) replayArchived(c *gin.Context) {
+	var req ReplayReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+
+	filter := models.QueueTaskFilter{Error: req.Error, From: req.From, To: req.To}
+
+	res, err := h.service.ReplayArchived(c.Request.Context(), c.Param("queue"), filter)
+	if err != nil {
+		if errors.Is(err, errs.ErrQueueNotFound) {
+			response.NotFoundError(c, "Queue not found")
+			return
+		}
+		response.InternalError(c)
+		return
+	}
+
+	response.OK(c, res)
+}
+
+func handleTaskErr(c *gin.Context, err error, stateMsg string) {
+	switch {
+	case errors.Is(err, errs.ErrQueueNotFound):
+		response.NotFoundError(c, "Queue not found")
+	case errors.Is(err, errs.ErrTaskNotFound):
+		response.NotFoundError(c, "Task not found")
+	case errors.Is(err, errs.ErrTaskState):
+		response.ConflictError(c, stateMsg)
+	default:
+		response.InternalError(c)
+	}
+}
+
+func (h *Handler) RegisterRoutes(router *gin.RouterGroup) {
+	queueRouter := router.Group("/queues")
+	queueRouter.GET("", h.listQueues)
+	queueRouter.GET(":queue/tasks", h.listTasks)
+	queueRouter.POST(":queue/tasks/:id/run", h.runTask)
+	queueRouter.DELETE(":queue/tasks/:id", h.deleteTask)
+	queueRouter.POST(":queue/archived/replay", h.replayArchived)
+}
@@ -0,0 +1,63 @@
+package webhook
+
+import (
+	"time"
+
+	"github.com/ocenb/geo-alerts/internal/http/utils"
+)
+
+const defaultListLimit = 10
+
+// @name ListWebhookSubscriptionsRequest
+type ListReq struct {
+	Limit  int `form:"limit" binding:"omitempty,min=1"`
+	Offset int `form:"offset" binding:"omitempty,min=0"`
+}
+
+// @name DigestRequest
+type DigestReq struct {
+	Interval  int `json:"interval" binding:"required,min=1,max=86400"` // seconds
+	MaxAlerts int `json:"max_alerts" binding:"omitempty,min=1,max=1000"`
+}
+
+// @name CreateWebhookSubscriptionRequest
+type CreateReq struct {
+	URL           string         `json:"url" binding:"required,url"`
+	Secret        string         `json:"secret" binding:"required,min=16,max=255"`
+	IsEnabled     *bool          `json:"is_enabled"` // default true
+	Events        []string       `json:"events" binding:"omitempty,dive,oneof=danger proximity predicted_danger path_crossing place_danger incident.created incident.updated incident.deactivated"`
+	Area          *utils.AreaReq `json:"area"` // omit to receive alerts from anywhere
+	MinSeverity   int            `json:"min_severity" binding:"omitempty,max=5"`
+	Categories    []string       `json:"categories" binding:"omitempty,dive,min=1,max=64,lowercase"`
+	RateLimit     *float64       `json:"rate_limit_rps" binding:"omitempty,min=0"`                                                  // omit for the server default, 0 = unlimited
+	PayloadFormat string         `json:"payload_format" binding:"omitempty,oneof=legacy cloudevents_structured cloudevents_binary"` // default legacy
+	Digest        *DigestReq     `json:"digest"`                                                                                    // omit to send alerts one by one
+}
+
+// @name UpdateWebhookSubscriptionRequest
+type UpdateReq struct {
+	URL           string         `json:"url" binding:"required,url"`
+	Secret        *string        `json:"secret" binding:"omitempty,min=16,max=255"` // omit to keep the current secret
+	IsEnabled     *bool          `json:"is_enabled" binding:"required"`
+	Events        []string       `json:"events" binding:"omitempty,dive,oneof=danger proximity predicted_danger path_crossing place_danger incident.created incident.updated incident.deactivated"`
+	Area          *utils.AreaReq `json:"area"`
+	MinSeverity   int            `json:"min_severity" binding:"omitempty,max=5"`
+	Categories    []string       `json:"categories" binding:"omitempty,dive,min=1,max=64,lowercase"`
+	RateLimit     *float64       `json:"rate_limit_rps" binding:"omitempty,min=0"`                                                  // omit for the server default, 0 = unlimited
+	PayloadFormat string         `json:"payload_format" binding:"omitempty,oneof=legacy cloudevents_structured cloudevents_binary"` // default legacy
+	Digest        *DigestReq     `json:"digest"`                                                                                    // omit to send alerts one by one
+}
+
+// @name ListWebhookDeliveriesRequest
+type ListDeliveriesReq struct {
+	SubscriptionID *int64     `form:"subscription_id" binding:"omitempty,min=0"`
+	UserID         string     `form:"user_id"`
+	TaskID         string     `form:"task_id"`
+	EventID        string     `form:"event_id"`
+	PayloadHash    string     `form:"payload_hash" binding:"omitempty,hexadecimal,len=64"`
+	Status         string     `form:"status" binding:"omitempty,oneof=success failed"`
+	From           *time.Time `form:"from"` // RFC 3339
+	To             *time.Time `form:"to"`   // RFC 3339
+	Limit          int        `form:"limit" binding:"omitempty,min=1"`
+	Offset         int        `form:"offset" binding:"omitempty,min=0"`
+}
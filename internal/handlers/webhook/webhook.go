@@ -0,0 +1,379 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ocenb/geo-alerts/internal/domain/errs"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/http/response"
+	"github.com/ocenb/geo-alerts/internal/http/utils"
+)
+
+type Service interface {
+	Create(ctx context.Context, params *models.CreateWebhookSubscriptionParams) (*models.WebhookSubscription, error)
+	GetByID(ctx context.Context, id int64) (*models.WebhookSubscription, error)
+	List(ctx context.Context, limit, offset int) ([]models.WebhookSubscription, error)
+	Update(ctx context.Context, params *models.UpdateWebhookSubscriptionParams) (*models.WebhookSubscription, error)
+	Delete(ctx context.Context, id int64) error
+	ListDeliveries(ctx context.Context, params *models.ListWebhookDeliveriesParams) ([]models.WebhookDelivery, error)
+	Redeliver(ctx context.Context, id int64) (*models.Redelivery, error)
+	OutboundStats(ctx context.Context) *models.OutboundStats
+}
+
+type Handler struct {
+	service Service
+}
+
+func New(service Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// CreateSubscription godoc
+// @Summary      Create a webhook subscription
+// @Description  Registers a receiver URL for alerts. Empty events list subscribes to all alert events,
+// @Description  incident lifecycle events (incident.created, incident.updated, incident.deactivated) have to be listed.
+// @Description  Area (bbox, polygon or circle), min_severity and categories narrow down the alerts delivered.
+// @Description  rate_limit_rps caps requests per second to the receiver, omit it for the server default.
+// @Description  payload_format picks the bare event data (legacy) or a CloudEvents 1.0 structured or binary mode message.
+// @Description  digest batches alerts into one delivery every interval seconds or max_alerts alerts, whichever comes first.
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        input body CreateReq true "Subscription parameters"
+// @Success      201  {object}  models.WebhookSubscription
+// @Failure      400  {object}  response.ErrorResponse "Invalid input"
+// @Failure      500  {object}  response.ErrorResponse "Internal server error"
+// @Router       /webhooks/subscriptions [post]
+func (h *Handler) create(c *gin.Context) {
+	var req CreateReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+
+	area, err := utils.ToArea(req.Area)
+	if err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+
+	params := &models.CreateWebhookSubscriptionParams{
+		URL:           req.URL,
+		Secret:        req.Secret,
+		IsEnabled:     req.IsEnabled == nil || *req.IsEnabled,
+		Events:        orEmpty(req.Events),
+		Area:          area,
+		MinSeverity:   req.MinSeverity,
+		Categories:    orEmpty(req.Categories),
+		RateLimit:     req.RateLimit,
+		PayloadFormat: payloadFormat(req.PayloadFormat),
+		Digest:        toDigest(req.Digest),
+	}
+
+	sub, err := h.service.Create(c.Request.Context(), params)
+	if err != nil {
+		response.InternalError(c)
+		return
+	}
+
+	response.Created(c, sub)
+}
+
+// GetSubscription godoc
+// @Summary      Get webhook subscription by ID
+// @Tags         webhooks
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        id   path      int  true  "Subscription ID"
+// @Success      200  {object}  models.WebhookSubscription
+// @Failure      400  {object}  response.ErrorResponse "Invalid ID format"
+// @Failure      404  {object}  response.ErrorResponse "Subscription not found"
+// @Failure      500  {object}  response.ErrorResponse "Internal server error"
+// @Router       /webhooks/subscriptions/{id} [get]
+func (h *Handler) getByID(c *gin.Context) {
+	id, err := utils.ParseID(c, "id")
+	if err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+
+	sub, err := h.service.GetByID(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, errs.ErrSubscriptionNotFound) {
+			response.NotFoundError(c, "Subscription not found")
+			return
+		}
+		response.InternalError(c)
+		return
+	}
+
+	response.OK(c, sub)
+}
+
+// ListSubscriptions godoc
+// @Summary      List webhook subscriptions
+// @Tags         webhooks
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        limit   query     int  false  "Limit (default 10)"
+// @Param        offset  query     int  false  "Offset (default 0)"
+// @Success      200     {array}   models.WebhookSubscription
+// @Failure      400     {object}  response.ErrorResponse "Invalid query parameters"
+// @Failure      500     {object}  response.ErrorResponse "Internal server error"
+// @Router       /webhooks/subscriptions [get]
+func (h *Handler) list(c *gin.Context) {
+	var req ListReq
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+	if req.Limit == 0 {
+		req.Limit = defaultListLimit
+	}
+
+	subs, err := h.service.List(c.Request.Context(), req.Limit, req.Offset)
+	if err != nil {
+		response.InternalError(c)
+		return
+	}
+
+	response.OK(c, subs)
+}
+
+// UpdateSubscription godoc
+// @Summary      Update webhook subscription
+// @Description  Updates URL, secret, enabled flag or filters of a subscription.
+// @Description  A new secret replaces the current one, which keeps signing deliveries alongside it for the rotation grace period.
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        id     path      int        true  "Subscription ID"
+// @Param        input  body      UpdateReq  true  "Update parameters"
+// @Success      200    {object}  models.WebhookSubscription
+// @Failure      400    {object}  response.ErrorResponse "Invalid input or ID"
+// @Failure      404    {object}  response.ErrorResponse "Subscription not found"
+// @Failure      500    {object}  response.ErrorResponse "Internal server error"
+// @Router       /webhooks/subscriptions/{id} [put]
+func (h *Handler) update(c *gin.Context) {
+	id, err := utils.ParseID(c, "id")
+	if err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+
+	var req UpdateReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+
+	area, err := utils.ToArea(req.Area)
+	if err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+
+	params := &models.UpdateWebhookSubscriptionParams{
+		ID:            id,
+		URL:           req.URL,
+		Secret:        req.Secret,
+		IsEnabled:     *req.IsEnabled,
+		Events:        orEmpty(req.Events),
+		Area:          area,
+		MinSeverity:   req.MinSeverity,
+		Categories:    orEmpty(req.Categories),
+		RateLimit:     req.RateLimit,
+		PayloadFormat: payloadFormat(req.PayloadFormat),
+		Digest:        toDigest(req.Digest),
+	}
+
+	sub, err := h.service.Update(c.Request.Context(), params)
+	if err != nil {
+		if errors.Is(err, errs.ErrSubscriptionNotFound) {
+			response.NotFoundError(c, "Subscription not found")
+			return
+		}
+		response.InternalError(c)
+		return
+	}
+
+	response.OK(c, sub)
+}
+
+// DeleteSubscription godoc
+// @Summary      Delete webhook subscription
+// @Tags         webhooks
+// @Security     ApiKeyAuth
+// @Param        id   path      int  true  "Subscription ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  response.ErrorResponse "Invalid ID format"
+// @Failure      404  {object}  response.ErrorResponse "Subscription not found"
+// @Failure      500  {object}  response.ErrorResponse "Internal server error"
+// @Router       /webhooks/subscriptions/{id} [delete]
+func (h *Handler) delete(c *gin.Context) {
+	id, err := utils.ParseID(c, "id")
+	if err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), id); err != nil {
+		if errors.Is(err, errs.ErrSubscriptionNotFound) {
+			response.NotFoundError(c, "Subscription not found")
+			return
+		}
+		response.InternalError(c)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListDeliveries godoc
+// @Summary      List webhook delivery attempts
+// @Description  Returns logged delivery attempts, newest first. All filters are optional.
+// @Tags         webhooks
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        subscription_id  query     int     false  "Subscription ID (0 = WEBHOOK_URL receiver)"
+// @Param        user_id          query     string  false  "User ID from the payload"
+// @Param        task_id          query     string  false  "Task ID, shared by all attempts of a delivery"
+// @Param        event_id         query     string  false  "Event ID, shared by all deliveries of an event"
+// @Param        payload_hash     query     string  false  "Hex SHA-256 of the request body"
+// @Param        status           query     string  false  "Attempt outcome" Enums(success, failed)
+// @Param        from             query     string  false  "Attempts at or after this time (RFC 3339)"
+// @Param        to               query     string  false  "Attempts before this time (RFC 3339)"
+// @Param        limit            query     int     false  "Limit (default 10)"
+// @Param        offset           query     int     false  "Offset (default 0)"
+// @Success      200              {array}   models.WebhookDelivery
+// @Failure      400              {object}  response.ErrorResponse "Invalid query parameters"
+// @Failure      500              {object}  response.ErrorResponse "Internal server error"
+// @Router       /webhooks/deliveries [get]
+func (h *Handler) listDeliveries(c *gin.Context) {
+	var req ListDeliveriesReq
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+	if req.Limit == 0 {
+		req.Limit = defaultListLimit
+	}
+
+	params := &models.ListWebhookDeliveriesParams{
+		SubscriptionID: req.SubscriptionID,
+		UserID:         req.UserID,
+		TaskID:         req.TaskID,
+		EventID:        req.EventID,
+		PayloadHash:    strings.ToLower(req.PayloadHash),
+		From:           req.From,
+		To:             req.To,
+		Limit:          req.Limit,
+		Offset:         req.Offset,
+	}
+	if req.Status != "" {
+		success := req.Status == "success"
+		params.Success = &success
+	}
+
+	deliveries, err := h.service.ListDeliveries(c.Request.Context(), params)
+	if err != nil {
+		response.InternalError(c)
+		return
+	}
+
+	response.OK(c, deliveries)
+}
+
+// Redeliver godoc
+// @Summary      Resend a webhook delivery
+// @Description  Enqueues the payload of a logged delivery to the same subscriber again, regardless of its filters.
+// @Description  Attempts of the new task are logged with redelivery_of set to this delivery.
+// @Tags         webhooks
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        id   path      int  true  "Delivery ID"
+// @Success      202  {object}  models.Redelivery
+// @Failure      400  {object}  response.ErrorResponse "Invalid ID format"
+// @Failure      404  {object}  response.ErrorResponse "Delivery not found"
+// @Failure      500  {object}  response.ErrorResponse "Internal server error"
+// @Router       /webhooks/deliveries/{id}/redeliver [post]
+func (h *Handler) redeliver(c *gin.Context) {
+	id, err := utils.ParseID(c, "id")
+	if err != nil {
+		response.BadRequestError(c, err.Error())
+		return
+	}
+
+	redelivery, err := h.service.Redeliver(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, errs.ErrDeliveryNotFound) {
+			response.NotFoundError(c, "Delivery not found")
+			return
+		}
+		response.InternalError(c)
+		return
+	}
+
+	response.Accepted(c, redelivery)
+}
+
+// OutboundStats godoc
+// @Summary      Get outbound throttling state
+// @Description  Returns the circuit breaker of every destination host and the rate limiter of every subscriber
+// @Description  the webhook worker of this instance has sent to. Deliveries are put off without using up retries
+// @Description  while a breaker is open or a subscriber's rate limit is reached.
+// @Tags         webhooks
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Success      200  {object}  models.OutboundStats
+// @Router       /webhooks/outbound [get]
+func (h *Handler) outboundStats(c *gin.Context) {
+	response.OK(c, h.service.OutboundStats(c.Request.Context()))
+}
+
+// orEmpty keeps array columns non-null when a filter is omitted.
+func orEmpty(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+func payloadFormat(format string) string {
+	if format == "" {
+		return models.PayloadFormatLegacy
+	}
+	return format
+}
+
+func toDigest(req *DigestReq) models.DigestSettings {
+	if req == nil {
+		return models.DigestSettings{}
+	}
+	return models.DigestSettings{Interval: req.Interval, MaxAlerts: req.MaxAlerts}
+}
+
+func (h *Handler) RegisterRoutes(router *gin.RouterGroup) {
+	webhookRouter := router.Group("/webhooks")
+
+	subRouter := webhookRouter.Group("/subscriptions")
+	subRouter.POST("", h.create)
+	subRouter.GET(":id", h.getByID)
+	subRouter.GET("", h.list)
+	subRouter.PUT(":id", h.update)
+	subRouter.DELETE(":id", h.delete)
+
+	deliveryRouter := webhookRouter.Group("/deliveries")
+	deliveryRouter.GET("", h.listDeliveries)
+	deliveryRouter.POST(":id/redeliver", h.redeliver)
+
+	webhookRouter.GET("/outbound", h.outboundStats)
+}
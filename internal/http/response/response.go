@@ -18,6 +18,10 @@ func Created(c *gin.Context, obj any) {
 	c.JSON(http.StatusCreated, obj)
 }
 
+func Accepted(c *gin.Context, obj any) {
+	c.JSON(http.StatusAccepted, obj)
+}
+
 func InternalError(c *gin.Context) {
 	c.AbortWithStatusJSON(http.StatusInternalServerError, ErrorResponse{Message: "Internal server error"})
 }
@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
 )
 
 var ErrInvalidID = errors.New("invalid id format")
@@ -22,3 +23,50 @@ func ParseID(c *gin.Context, paramName string) (int64, error) {
 
 	return id, nil
 }
+
+// @name GeoPointRequest
+type GeoPointReq struct {
+	Latitude  *float64 `json:"latitude" binding:"required,latitude"`
+	Longitude *float64 `json:"longitude" binding:"required,longitude"`
+}
+
+// @name AreaRequest
+type AreaReq struct {
+	Type      string        `json:"type" binding:"required,oneof=bbox polygon circle"`
+	SouthWest *GeoPointReq  `json:"south_west" binding:"required_if=Type bbox"`
+	NorthEast *GeoPointReq  `json:"north_east" binding:"required_if=Type bbox"`
+	Points    []GeoPointReq `json:"points" binding:"required_if=Type polygon,omitempty,min=3,max=1000,dive"`
+	Center    *GeoPointReq  `json:"center" binding:"required_if=Type circle"`
+	Radius    int           `json:"radius" binding:"required_if=Type circle,omitempty,min=1"`
+}
+
+// ToArea converts the requested area, checking what the binding tags can't express.
+func ToArea(req *AreaReq) (*models.Area, error) {
+	if req == nil {
+		return nil, nil
+	}
+
+	area := &models.Area{Type: req.Type}
+	switch req.Type {
+	case models.AreaBBox:
+		if *req.SouthWest.Latitude > *req.NorthEast.Latitude {
+			return nil, errors.New("area south_west latitude must not exceed north_east latitude")
+		}
+		area.SouthWest = toGeoPoint(req.SouthWest)
+		area.NorthEast = toGeoPoint(req.NorthEast)
+	case models.AreaPolygon:
+		area.Points = make([]models.GeoPoint, 0, len(req.Points))
+		for i := range req.Points {
+			area.Points = append(area.Points, *toGeoPoint(&req.Points[i]))
+		}
+	case models.AreaCircle:
+		area.Center = toGeoPoint(req.Center)
+		area.Radius = req.Radius
+	}
+
+	return area, nil
+}
+
+func toGeoPoint(req *GeoPointReq) *models.GeoPoint {
+	return &models.GeoPoint{Latitude: *req.Latitude, Longitude: *req.Longitude}
+}
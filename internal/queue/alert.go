@@ -0,0 +1,128 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+)
+
+// AlertAckTask asks to follow up on the alert if nobody has acknowledged it by then.
+type AlertAckTask struct {
+	AlertID string `json:"alert_id"`
+}
+
+// alertTaskTypes maps alert types to the task types they are delivered with.
+var alertTaskTypes = map[string]string{
+	models.EventDanger:          TypeDangerWebhook,
+	models.EventProximity:       TypeProximityWebhook,
+	models.EventPredictedDanger: TypePredictedWebhook,
+	models.EventPathCrossing:    TypeCrossingWebhook,
+	models.EventPlaceDanger:     TypePlaceWebhook,
+}
+
+// recordAlert stores the alert as sent, to be acknowledged.
+func (q *Client) recordAlert(ctx context.Context, p WebhookPayload) error {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("failed to marshal alert: %w", err)
+	}
+
+	incidents := p.AllIncidents()
+	ids := make([]int64, 0, len(incidents))
+	for _, inc := range incidents {
+		if !slices.Contains(ids, inc.ID) {
+			ids = append(ids, inc.ID)
+		}
+	}
+
+	err = q.alerts.Record(ctx, &models.Alert{
+		ID:          p.AlertID,
+		UserID:      p.UserID,
+		AlertType:   p.AlertType,
+		IncidentIDs: ids,
+		Payload:     data,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record alert: %w", err)
+	}
+	return nil
+}
+
+// ResendAlert delivers the unacknowledged alert again, as it was sent and with the same ID,
+// so that acknowledging either copy acknowledges it.
+func (q *Client) ResendAlert(ctx context.Context, alert *models.Alert) error {
+	var p WebhookPayload
+	if err := json.Unmarshal(alert.Payload, &p); err != nil {
+		return fmt.Errorf("failed to unmarshal alert: %w", err)
+	}
+	taskType, ok := alertTaskTypes[p.AlertType]
+	if !ok {
+		return fmt.Errorf("alert type %q can't be resent", p.AlertType)
+	}
+
+	p.Resent = true
+	_, err := q.deliverAlert(ctx, q.alertLogger(p), taskType, p)
+	return err
+}
+
+// EnqueueUnacknowledged tells the duty officers that nobody acknowledged the alert. Like escalations,
+// it goes to all of their channels.
+func (q *Client) EnqueueUnacknowledged(ctx context.Context, alert *models.Alert) error {
+	var p WebhookPayload
+	if err := json.Unmarshal(alert.Payload, &p); err != nil {
+		return fmt.Errorf("failed to unmarshal alert: %w", err)
+	}
+
+	_, err := q.notifyDuty(ctx, WebhookPayload{
+		AlertID:   p.AlertID,
+		UserID:    p.UserID,
+		Latitude:  p.Latitude,
+		Longitude: p.Longitude,
+		AlertType: models.EventUnacknowledged,
+		Incidents: p.AllIncidents(),
+		Place:     p.Place,
+	})
+	return err
+}
+
+// newAlertID names an alert. An alert sent while processing a task gets the same ID on every attempt
+// at the task, so that a retry after a partial failure delivers it only to the receivers still missing it.
+func newAlertID(ctx context.Context, p WebhookPayload) string {
+	taskID, ok := asynq.GetTaskID(ctx)
+	if !ok {
+		return uuid.NewString()
+	}
+	name := taskID + "/" + p.AlertType + "/" + p.UserID
+	if p.Place != nil {
+		name += "/" + strconv.FormatInt(p.Place.ID, 10)
+	}
+	return uuid.NewSHA1(uuid.NameSpaceOID, []byte(name)).String()
+}
+
+// deliveryKey names one delivery of the alert, the tasks it queues are named after it. Resending the alert
+// and telling the duty officers nobody acknowledged it are deliveries of their own. Alerts without an ID,
+// like escalations, have no key.
+func deliveryKey(p WebhookPayload) string {
+	if p.AlertID == "" {
+		return ""
+	}
+	key := "alert:" + p.AlertID + ":" + p.AlertType
+	if p.Resent {
+		key += ":resent"
+	}
+	return key
+}
+
+// subKey names the part of the delivery that goes to one kind of receiver.
+func subKey(key, receivers string) string {
+	if key == "" {
+		return ""
+	}
+	return key + ":" + receivers
+}
@@ -0,0 +1,32 @@
+package queue
+
+import (
+	"testing"
+
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+)
+
+func TestDeliveryKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload WebhookPayload
+		want    string
+	}{
+		{"Alert", WebhookPayload{AlertID: "a1", AlertType: models.EventDanger}, "alert:a1:danger"},
+		{"Resent alert", WebhookPayload{AlertID: "a1", AlertType: models.EventDanger, Resent: true}, "alert:a1:danger:resent"},
+		{"Unacknowledged alert", WebhookPayload{AlertID: "a1", AlertType: models.EventUnacknowledged}, "alert:a1:" + models.EventUnacknowledged},
+		{"Escalation without an ID", WebhookPayload{AlertType: models.EventEscalation}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := deliveryKey(tt.payload); got != tt.want {
+				t.Errorf("deliveryKey() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got := subKey("", "duty"); got != "" {
+		t.Errorf("subKey() without a delivery key = %q, want none", got)
+	}
+}
@@ -0,0 +1,57 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/ocenb/geo-alerts/internal/config"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+)
+
+// EventBroadcast is the event of notifications an operator broadcast.
+const EventBroadcast = "broadcast"
+
+// BroadcastTask asks to look up the recipients of a broadcast and queue its notifications.
+type BroadcastTask struct {
+	BroadcastID int64 `json:"broadcast_id"`
+}
+
+// PushPlatforms returns the device platforms that have a push endpoint configured.
+func PushPlatforms(cfg config.NotifyConfig) []string {
+	var platforms []string
+	if cfg.FCMURL != "" {
+		platforms = append(platforms, models.PlatformAndroid)
+	}
+	if cfg.APNsURL != "" {
+		platforms = append(platforms, models.PlatformIOS)
+	}
+	return platforms
+}
+
+func (q *Client) EnqueueBroadcast(ctx context.Context, broadcastID int64) error {
+	_, err := q.enqueue(ctx, TypeBroadcastResolve, BroadcastTask{BroadcastID: broadcastID})
+	return err
+}
+
+// EnqueueBroadcastPush queues the broadcast notification to one device. The task ID is derived from the recipient,
+// so queueing the recipients of a broadcast again after a failure doesn't send anyone the message twice.
+func (q *Client) EnqueueBroadcastPush(ctx context.Context, b *models.Broadcast, r *models.BroadcastRecipient) error {
+	n := Notification{
+		ID:                   "broadcast-" + strconv.FormatInt(b.ID, 10),
+		Event:                EventBroadcast,
+		Time:                 time.Now().UTC(),
+		Recipient:            r.Token,
+		Platform:             r.Platform,
+		Subject:              b.Title,
+		Text:                 b.Message,
+		BroadcastRecipientID: r.ID,
+	}
+	_, err := q.enqueue(ctx, TypePushNotification, n, asynq.TaskID("broadcast:"+strconv.FormatInt(r.ID, 10)))
+	if errors.Is(err, asynq.ErrTaskIDConflict) {
+		return nil
+	}
+	return err
+}
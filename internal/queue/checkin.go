@@ -0,0 +1,74 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/ocenb/geo-alerts/internal/domain/errs"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+)
+
+// CheckinReminderTask asks to remind the users seen in an incident zone who haven't checked in.
+type CheckinReminderTask struct {
+	IncidentID int64 `json:"incident_id"`
+}
+
+// checkinReminderData tells the app which incident to check in for.
+type checkinReminderData struct {
+	IncidentID int64 `json:"incident_id"`
+}
+
+// EnqueueCheckinReminder queues a reminder for the incident. Only one can be queued at a time,
+// a second one returns errs.ErrReminderPending.
+func (q *Client) EnqueueCheckinReminder(ctx context.Context, incidentID int64) error {
+	taskID := "checkin-reminder:" + strconv.FormatInt(incidentID, 10)
+	_, err := q.enqueue(ctx, TypeCheckinReminder, CheckinReminderTask{IncidentID: incidentID}, asynq.TaskID(taskID))
+	if errors.Is(err, asynq.ErrTaskIDConflict) {
+		return errs.ErrReminderPending
+	}
+	return err
+}
+
+// EnqueueCheckinReminderPushes pushes a reminder to check in to each device, in its own language.
+func (q *Client) EnqueueCheckinReminderPushes(ctx context.Context, incident *models.Incident, devices []models.Device) error {
+	data, err := json.Marshal(checkinReminderData{IncidentID: incident.ID})
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification data: %w", err)
+	}
+	id, now := uuid.NewString(), time.Now().UTC()
+
+	var enqueueErrs []error
+	for _, d := range devices {
+		if !slices.Contains(q.pushPlatforms, d.Platform) {
+			continue
+		}
+
+		title, body := reminderMessage(incident, d.Locale)
+		n := Notification{
+			ID:        id,
+			Event:     models.EventCheckinReminder,
+			Time:      now,
+			Recipient: d.Token,
+			Platform:  d.Platform,
+			Subject:   title,
+			Text:      body,
+			Data:      data,
+		}
+		if _, err := q.enqueue(ctx, TypePushNotification, n); err != nil {
+			enqueueErrs = append(enqueueErrs, fmt.Errorf("device %d: %w", d.ID, err))
+		}
+	}
+	return errors.Join(enqueueErrs...)
+}
+
+func reminderMessage(incident *models.Incident, locale string) (title, body string) {
+	texts := localeTexts(locale)
+	return texts.reminderTitle, fmt.Sprintf(texts.reminderBody, fmt.Sprintf(texts.zone, incident.Category, incident.Severity))
+}
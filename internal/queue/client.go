@@ -3,64 +3,523 @@ package queue
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/hibiken/asynq"
 	"github.com/ocenb/geo-alerts/internal/config"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/logger/logattr"
 )
 
+// DefaultSubscriptionID addresses the receiver configured with WEBHOOK_URL.
+const DefaultSubscriptionID int64 = 0
+
 type WebhookPayload struct {
-	UserID    string  `json:"user_id"`
-	Latitude  float64 `json:"latitude"`
-	Longitude float64 `json:"longitude"`
+	AlertID     string                     `json:"alert_id,omitempty"` // to acknowledge the alert with
+	Resent      bool                       `json:"resent,omitempty"`   // nobody acknowledged the alert in time, this is another try
+	UserID      string                     `json:"user_id"`
+	Latitude    float64                    `json:"latitude"`
+	Longitude   float64                    `json:"longitude"`
+	AlertType   string                     `json:"alert_type,omitempty"`
+	Incidents   []models.IncidentShort     `json:"incidents,omitempty"`
+	Nearby      []models.NearbyIncident    `json:"nearby,omitempty"`
+	Predicted   []models.PredictedIncident `json:"predicted,omitempty"`
+	Crossed     []models.IncidentShort     `json:"crossed,omitempty"`
+	Place       *models.SavedPlace         `json:"place,omitempty"`        // the incidents reach into, the location is of the place then
+	InsideSince *time.Time                 `json:"inside_since,omitempty"` // when an escalated user entered the zone
+	Muted       []int64                    `json:"muted,omitempty"`        // incidents the user's preferences hold back from their devices
+}
+
+// IncidentEventPayload tells receivers that an incident was created, updated or deactivated.
+type IncidentEventPayload struct {
+	Event    string           `json:"event"`
+	Incident models.Incident  `json:"incident"`           // state after the change
+	Previous *models.Incident `json:"previous,omitempty"` // state before an update
+}
+
+// DigestEntry is an alert waiting in a subscriber's digest buffer.
+type DigestEntry struct {
+	Time    time.Time      `json:"time"`
+	Payload WebhookPayload `json:"payload"`
+}
+
+// DigestPayload is a batch of alerts sent to a digest subscriber at once.
+type DigestPayload struct {
+	AlertType string           `json:"alert_type"` // always "digest"
+	From      time.Time        `json:"from"`       // first alert in the batch
+	To        time.Time        `json:"to"`         // last alert in the batch
+	Total     int              `json:"total"`
+	Incidents []DigestIncident `json:"incidents"`
+}
+
+// DigestIncident sums up the alerts of a digest about one incident.
+type DigestIncident struct {
+	models.IncidentShort
+	Count       int            `json:"count"`
+	AlertTypes  map[string]int `json:"alert_types"`  // count per alert type
+	SampleUsers []string       `json:"sample_users"` // some of the users alerted, without repeats
+}
+
+// RetroactiveAlertTask asks to alert the users last seen in an incident zone since the given time.
+type RetroactiveAlertTask struct {
+	IncidentID int64     `json:"incident_id"`
+	Since      time.Time `json:"since"`
+}
+
+// DigestFlushTask asks to flush a subscriber's digest buffer.
+type DigestFlushTask struct {
+	SubscriptionID int64 `json:"subscription_id"`
+}
+
+// WebhookTask is a single delivery of a payload to one subscriber.
+type WebhookTask struct {
+	SubscriptionID int64                 `json:"subscription_id"`
+	RedeliveryOf   *int64                `json:"redelivery_of,omitempty"` // delivery log entry an operator resent
+	EventID        string                `json:"event_id,omitempty"`      // the same in the tasks of all subscribers of the event
+	EventTime      time.Time             `json:"event_time,omitzero"`
+	Payload        WebhookPayload        `json:"payload,omitzero"`
+	Incident       *IncidentEventPayload `json:"incident,omitempty"` // set instead of Payload for incident events
+	Digest         *DigestPayload        `json:"digest,omitempty"`   // set instead of Payload for digests
+}
+
+// Data returns the event data sent to the receiver.
+func (t *WebhookTask) Data() ([]byte, error) {
+	switch {
+	case t.Incident != nil:
+		return json.Marshal(t.Incident)
+	case t.Digest != nil:
+		return json.Marshal(t.Digest)
+	}
+	return json.Marshal(t.Payload)
+}
+
+// incidentTaskTypes maps incident events to their task types.
+var incidentTaskTypes = map[string]string{
+	models.EventIncidentCreated:     TypeIncidentCreatedWebhook,
+	models.EventIncidentUpdated:     TypeIncidentUpdatedWebhook,
+	models.EventIncidentDeactivated: TypeIncidentDeactivatedWebhook,
+}
+
+type SubscriptionSource interface {
+	ListEnabledForEvent(ctx context.Context, event string) ([]models.WebhookSubscription, error)
+}
+
+type DigestBuffer interface {
+	Append(ctx context.Context, subID int64, alert []byte) (int64, error)
+}
+
+type DeviceSource interface {
+	ListByUser(ctx context.Context, userID string) ([]models.Device, error)
+}
+
+type GuardianSource interface {
+	ListGuardiansOfMember(ctx context.Context, userID string) ([]models.Guardian, error)
+}
+
+type AlertLog interface {
+	Record(ctx context.Context, alert *models.Alert) error
+	Forget(ctx context.Context, id string) error
 }
 
 type Client struct {
-	client     *asynq.Client
-	maxRetries int
-	timeout    time.Duration
+	log             *slog.Logger
+	client          *asynq.Client
+	inspector       *asynq.Inspector // removes scheduled tasks that are no longer due
+	subscriptions   SubscriptionSource
+	digests         DigestBuffer
+	devices         DeviceSource
+	guardians       GuardianSource
+	alerts          AlertLog
+	prefs           PreferenceSource
+	ackTimeout      time.Duration // after which unacknowledged alerts are followed up, 0 = never
+	guardianTypes   []string      // notification task types guardians can be reached with
+	defaultReceiver bool
+	dutyEvents      []string
+	dutyRecipients  map[string][]string // by notification task type
+	pushEvents      []string
+	pushPlatforms   []string // that have a push endpoint
+	maxRetries      int
+	timeout         time.Duration
+	retention       time.Duration // of done alert tasks, see enqueueOnce
 }
 
-func NewClient(redisCfg config.RedisConfig, queueCfg config.QueueConfig) (*Client, error) {
-	client := asynq.NewClient(asynq.RedisClientOpt{
+func NewClient(log *slog.Logger, redisCfg config.RedisConfig, queueCfg config.QueueConfig, webhookCfg config.WebhookConfig, notifyCfg config.NotifyConfig, subscriptions SubscriptionSource, digests DigestBuffer, devices DeviceSource, guardians GuardianSource, alerts AlertLog, prefs PreferenceSource) (*Client, error) {
+	redisOpt := asynq.RedisClientOpt{
 		Addr:         redisCfg.Addr,
 		Password:     redisCfg.Password,
 		DB:           redisCfg.DBQueue,
 		DialTimeout:  redisCfg.DialTimeout,
 		ReadTimeout:  redisCfg.ReadTimeout,
 		WriteTimeout: redisCfg.WriteTimeout,
-	})
+	}
+	client := asynq.NewClient(redisOpt)
 
 	if err := client.Ping(); err != nil {
 		return nil, fmt.Errorf("failed to ping redis (queue client): %w", err)
 	}
 
 	return &Client{
-		client:     client,
-		maxRetries: queueCfg.MaxRetries,
-		timeout:    queueCfg.Timeout,
+		log:             log,
+		client:          client,
+		inspector:       asynq.NewInspector(redisOpt),
+		subscriptions:   subscriptions,
+		digests:         digests,
+		devices:         devices,
+		guardians:       guardians,
+		alerts:          alerts,
+		prefs:           prefs,
+		ackTimeout:      notifyCfg.AckTimeout,
+		guardianTypes:   guardianNotificationTypes(notifyCfg),
+		defaultReceiver: webhookCfg.URL != "",
+		dutyEvents:      notifyCfg.Events,
+		pushEvents:      notifyCfg.PushEvents,
+		pushPlatforms:   PushPlatforms(notifyCfg),
+		maxRetries:      queueCfg.MaxRetries,
+		timeout:         queueCfg.Timeout,
+		retention:       queueCfg.RetryMaxDelay,
+		dutyRecipients: map[string][]string{
+			TypeWebhookNotification: notifyCfg.WebhookURLs,
+			TypeEmailNotification:   notifyCfg.EmailTo,
+			TypeSMSNotification:     notifyCfg.SMSTo,
+		},
 	}, nil
 }
 
-func (q *Client) EnqueueDangerAlert(ctx context.Context, userID string, latitude, longitude float64) error {
-	payload, err := json.Marshal(WebhookPayload{UserID: userID, Latitude: latitude, Longitude: longitude})
+func (q *Client) EnqueueDangerAlert(ctx context.Context, userID string, latitude, longitude float64, dangers []models.IncidentShort) error {
+	return q.enqueueAlert(ctx, TypeDangerWebhook, WebhookPayload{
+		UserID:    userID,
+		Latitude:  latitude,
+		Longitude: longitude,
+		AlertType: models.EventDanger,
+		Incidents: dangers,
+	})
+}
+
+// EnqueueProximityAlert enqueues a softer "approaching danger" notice as a separate task type,
+// so receivers can tell it apart from a danger alert.
+func (q *Client) EnqueueProximityAlert(ctx context.Context, userID string, latitude, longitude float64, nearby []models.NearbyIncident) error {
+	return q.enqueueAlert(ctx, TypeProximityWebhook, WebhookPayload{
+		UserID:    userID,
+		Latitude:  latitude,
+		Longitude: longitude,
+		AlertType: models.EventProximity,
+		Nearby:    nearby,
+	})
+}
+
+// EnqueuePredictedAlert enqueues a "predicted danger" alert for zones the user is expected to enter.
+func (q *Client) EnqueuePredictedAlert(ctx context.Context, userID string, latitude, longitude float64, predicted []models.PredictedIncident) error {
+	return q.enqueueAlert(ctx, TypePredictedWebhook, WebhookPayload{
+		UserID:    userID,
+		Latitude:  latitude,
+		Longitude: longitude,
+		AlertType: models.EventPredictedDanger,
+		Predicted: predicted,
+	})
+}
+
+// EnqueueCrossingAlert enqueues an alert for zones the user passed through between two checks.
+func (q *Client) EnqueueCrossingAlert(ctx context.Context, userID string, latitude, longitude float64, crossed []models.IncidentShort) error {
+	return q.enqueueAlert(ctx, TypeCrossingWebhook, WebhookPayload{
+		UserID:    userID,
+		Latitude:  latitude,
+		Longitude: longitude,
+		AlertType: models.EventPathCrossing,
+		Crossed:   crossed,
+	})
+}
+
+// EnqueueIncidentEvent tells the subscriptions that list the event about a change of an incident.
+// Previous is the state before an update and nil otherwise.
+func (q *Client) EnqueueIncidentEvent(ctx context.Context, event string, incident, previous *models.Incident) error {
+	taskType, ok := incidentTaskTypes[event]
+	if !ok {
+		return fmt.Errorf("unknown incident event %q", event)
+	}
+
+	p := &IncidentEventPayload{Event: event, Incident: *incident, Previous: previous}
+	match := func(sub models.WebhookSubscription) bool {
+		// Subscriptions without an event filter were made for alerts and may not expect these payloads.
+		return slices.Contains(sub.Events, event) && matchesIncidentEvent(sub, p)
+	}
+	_, err := q.enqueueWebhook(ctx, taskType, event, "", false, match, WebhookTask{Incident: p})
+	return err
+}
+
+// EnqueueRetroactiveAlert queues alerts for the users whose last check since the given time is in the incident zone.
+func (q *Client) EnqueueRetroactiveAlert(ctx context.Context, incidentID int64, since time.Time) error {
+	_, err := q.enqueue(ctx, TypeRetroactiveAlert, RetroactiveAlertTask{IncidentID: incidentID, Since: since})
+	return err
+}
+
+// enqueueAlert gives the alert an ID, marks the incidents the user muted, records it for acknowledgement
+// and delivers it. If unacknowledged alerts are followed up, the follow-up is scheduled unless the alert
+// reached nobody. The alert is delivered even if it couldn't be recorded, it then can't be acknowledged.
+//
+// Only a failure to queue the webhooks is returned, the rest is logged. Callers take an error as the alert
+// not sent and send it again. An alert that reached nobody is forgotten, one sent while processing a task
+// is sent again with the same ID, and then only to the receivers that didn't get it.
+func (q *Client) enqueueAlert(ctx context.Context, taskType string, p WebhookPayload) error {
+	p.AlertID = newAlertID(ctx, p)
+	log := q.alertLogger(p)
+	p.Muted = q.mutedIncidents(ctx, log, p)
+
+	recorded := true
+	if err := q.recordAlert(ctx, p); err != nil {
+		log.Warn("failed to record alert, it can't be acknowledged", logattr.Err(err))
+		recorded = false
+	}
+
+	reached, err := q.deliverAlert(ctx, log, taskType, p)
+	if err != nil && !reached {
+		if recorded {
+			if err := q.alerts.Forget(ctx, p.AlertID); err != nil {
+				log.Warn("failed to forget undelivered alert", logattr.Err(err))
+			}
+		}
+		return err
+	}
+
+	if recorded && q.ackTimeout > 0 {
+		_, ackErr := q.enqueue(ctx, TypeAlertAckCheck, AlertAckTask{AlertID: p.AlertID},
+			asynq.ProcessIn(q.ackTimeout),
+			asynq.TaskID("alert-ack:"+p.AlertID),
+		)
+		if ackErr != nil && !errors.Is(ackErr, asynq.ErrTaskIDConflict) {
+			log.Warn("failed to schedule acknowledgement check", logattr.Err(ackErr))
+		}
+	}
+	return err
+}
+
+func (q *Client) alertLogger(p WebhookPayload) *slog.Logger {
+	return q.log.With(
+		slog.String("alert_id", p.AlertID),
+		slog.String("alert_type", p.AlertType),
+		slog.String("user_id", p.UserID),
+	)
+}
+
+// deliverAlert sends the alert to the default receiver and to the subscribers whose filters match it.
+// Digest subscribers get it later as part of a batch. Duty officers and the user's own devices
+// are notified of the alert types configured for them, and guardians of the user of danger.
+// The user's preferences only keep muted incidents off their devices.
+//
+// Returns whether the alert reached anyone. Those come on top of the webhooks, so only a failure to queue
+// the webhooks is returned and the rest are logged. Receivers that already have this delivery of the alert
+// queued don't get it again.
+func (q *Client) deliverAlert(ctx context.Context, log *slog.Logger, taskType string, p WebhookPayload) (bool, error) {
+	queued := 0
+	if p.AlertType == models.EventDanger {
+		n, err := q.notifyGuardians(ctx, p)
+		if err != nil {
+			log.Error("failed to enqueue guardian notifications", logattr.Err(err))
+		}
+		queued += n
+	}
+	if slices.Contains(q.dutyEvents, p.AlertType) {
+		n, err := q.notifyDuty(ctx, p)
+		if err != nil {
+			log.Error("failed to enqueue duty notifications", logattr.Err(err))
+		}
+		queued += n
+	}
+	if len(q.pushPlatforms) > 0 && slices.Contains(q.pushEvents, p.AlertType) {
+		n, err := q.notifyDevices(ctx, p)
+		if err != nil {
+			log.Error("failed to enqueue push notifications", logattr.Err(err))
+		}
+		queued += n
+	}
+
+	var digestSubs []models.WebhookSubscription
+	match := func(sub models.WebhookSubscription) bool {
+		if !matchesSubscription(sub, p) {
+			return false
+		}
+		if sub.Digest.Enabled() {
+			digestSubs = append(digestSubs, sub)
+			return false
+		}
+		return true
+	}
+	n, err := q.enqueueWebhook(ctx, taskType, p.AlertType, deliveryKey(p), q.defaultReceiver, match, WebhookTask{Payload: p})
+	queued += n
+
+	// A retry appends the alert again, the digest counts it once.
+	for _, sub := range digestSubs {
+		if err := q.appendDigest(ctx, sub, p); err != nil {
+			log.Error("failed to add alert to digest", slog.Int64("subscription_id", sub.ID), logattr.Err(err))
+			continue
+		}
+		queued++
+	}
+
+	return queued > 0, err
+}
+
+// appendDigest buffers the alert. The first alert of a batch schedules its flush after the interval,
+// the one that fills the batch up flushes it right away.
+func (q *Client) appendDigest(ctx context.Context, sub models.WebhookSubscription, p WebhookPayload) error {
+	entry, err := json.Marshal(DigestEntry{Time: time.Now().UTC(), Payload: p})
+	if err != nil {
+		return fmt.Errorf("failed to marshal digest entry: %w", err)
+	}
+
+	n, err := q.digests.Append(ctx, sub.ID, entry)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case sub.Digest.MaxAlerts > 0 && n == int64(sub.Digest.MaxAlerts):
+		return q.EnqueueDigestFlush(ctx, sub.ID, 0)
+	case n == 1:
+		return q.EnqueueDigestFlush(ctx, sub.ID, time.Duration(sub.Digest.Interval)*time.Second)
+	}
+	return nil
+}
+
+// EnqueueDigestFlush schedules a flush of the subscriber's digest buffer.
+func (q *Client) EnqueueDigestFlush(ctx context.Context, subID int64, delay time.Duration) error {
+	payload, err := json.Marshal(DigestFlushTask{SubscriptionID: subID})
 	if err != nil {
 		return fmt.Errorf("failed to marshal payload: %w", err)
 	}
-	task := asynq.NewTask(TypeDangerWebhook, payload)
 
-	_, err = q.client.EnqueueContext(ctx, task,
+	_, err = q.client.EnqueueContext(ctx, asynq.NewTask(TypeDigestFlush, payload),
 		asynq.MaxRetry(q.maxRetries),
 		asynq.Timeout(q.timeout),
+		asynq.ProcessIn(delay),
 	)
-
 	if err != nil {
 		return fmt.Errorf("failed to enqueue task: %w", err)
 	}
 	return nil
 }
 
+// EnqueueDigest hands a digest over for delivery like any other webhook. The task ID is derived from the batch,
+// so a batch taken again after a failed flush isn't delivered twice while the first task is around.
+func (q *Client) EnqueueDigest(ctx context.Context, subID int64, batchID string, d *DigestPayload) error {
+	t := WebhookTask{SubscriptionID: subID, EventID: batchID, EventTime: d.To, Digest: d}
+	_, err := q.enqueue(ctx, TypeDigestWebhook, t, asynq.TaskID("digest:"+batchID))
+	if errors.Is(err, asynq.ErrTaskIDConflict) {
+		return nil
+	}
+	return err
+}
+
+// enqueueWebhook fans the task out into one delivery task per matching subscriber of the event.
+// All of them share the event ID and time. With a delivery key, the event ID is derived from it and
+// a subscriber that already has the delivery queued isn't sent it again, so a retry only reaches the
+// subscribers whose task failed to queue. Returns how many subscribers have it queued, the error names the others.
+func (q *Client) enqueueWebhook(ctx context.Context, taskType, event, key string, defaultReceiver bool, match func(models.WebhookSubscription) bool, task WebhookTask) (int, error) {
+	subs, err := q.subscriptions.ListEnabledForEvent(ctx, event)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+
+	ids := make([]int64, 0, len(subs)+1)
+	if defaultReceiver {
+		ids = append(ids, DefaultSubscriptionID)
+	}
+	for _, sub := range subs {
+		if match(sub) {
+			ids = append(ids, sub.ID)
+		}
+	}
+
+	task.EventID, task.EventTime = uuid.NewString(), time.Now().UTC()
+	if key != "" {
+		task.EventID = uuid.NewSHA1(uuid.NameSpaceOID, []byte(key)).String()
+	}
+
+	var enqueueErrs []error
+	for _, id := range ids {
+		t := task
+		t.SubscriptionID = id
+		if err := q.enqueueOnce(ctx, taskType, t, subKey(key, "webhook"), strconv.FormatInt(id, 10)); err != nil {
+			enqueueErrs = append(enqueueErrs, fmt.Errorf("subscription %d: %w", id, err))
+		}
+	}
+
+	return len(ids) - len(enqueueErrs), errors.Join(enqueueErrs...)
+}
+
+// EnqueueRedelivery sends a logged delivery's payload to the same subscriber again as a new task,
+// bypassing the subscription filters. The event keeps its ID so receivers can tell it's a duplicate.
+// Returns the new task ID.
+func (q *Client) EnqueueRedelivery(ctx context.Context, d *models.WebhookDelivery) (string, error) {
+	t := WebhookTask{SubscriptionID: d.SubscriptionID, RedeliveryOf: &d.ID, EventID: d.EventID}
+
+	var err error
+	switch {
+	case isIncidentTaskType(d.TaskType):
+		t.Incident = &IncidentEventPayload{}
+		err = json.Unmarshal(d.Payload, t.Incident)
+	case d.TaskType == TypeDigestWebhook:
+		t.Digest = &DigestPayload{}
+		err = json.Unmarshal(d.Payload, t.Digest)
+	default:
+		err = json.Unmarshal(d.Payload, &t.Payload)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to unmarshal delivery payload: %w", err)
+	}
+
+	if d.EventTime != nil {
+		t.EventTime = *d.EventTime
+	}
+	return q.enqueue(ctx, d.TaskType, t)
+}
+
+func isIncidentTaskType(taskType string) bool {
+	for _, t := range incidentTaskTypes {
+		if t == taskType {
+			return true
+		}
+	}
+	return false
+}
+
+func (q *Client) enqueue(ctx context.Context, taskType string, t any, opts ...asynq.Option) (string, error) {
+	payload, err := json.Marshal(t)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal payload: %w", err)
+	}
+	task := asynq.NewTask(taskType, payload)
+
+	opts = append([]asynq.Option{asynq.MaxRetry(q.maxRetries), asynq.Timeout(q.timeout)}, opts...)
+	info, err := q.client.EnqueueContext(ctx, task, opts...)
+
+	if err != nil {
+		return "", fmt.Errorf("failed to enqueue task: %w", err)
+	}
+	return info.ID, nil
+}
+
+// enqueueOnce queues the task of one receiver of a delivery. With a delivery key the task is named after it
+// and the receiver, and isn't queued again while a task of that name is around. Done tasks are kept for
+// the max retry delay, long enough for a failed attempt at the delivery to come round again.
+func (q *Client) enqueueOnce(ctx context.Context, taskType string, t any, key, receiver string) error {
+	if key == "" {
+		_, err := q.enqueue(ctx, taskType, t)
+		return err
+	}
+
+	_, err := q.enqueue(ctx, taskType, t, asynq.TaskID(key+":"+receiver), asynq.Retention(q.retention))
+	if errors.Is(err, asynq.ErrTaskIDConflict) {
+		return nil
+	}
+	return err
+}
+
 func (q *Client) Close() error {
-	return q.client.Close()
+	return errors.Join(q.client.Close(), q.inspector.Close())
 }
@@ -0,0 +1,92 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+)
+
+// defaultQueue is the queue all tasks are enqueued to.
+const defaultQueue = "default"
+
+// EscalationTask is a follow-up due if the user is still inside the zone they entered at EnteredAt.
+type EscalationTask struct {
+	UserID     string    `json:"user_id"`
+	IncidentID int64     `json:"incident_id"`
+	Step       string    `json:"step"`
+	EnteredAt  time.Time `json:"entered_at"`
+}
+
+// EscalationCancelTask asks to drop the follow-ups about everyone inside the zone of an incident.
+type EscalationCancelTask struct {
+	IncidentID int64 `json:"incident_id"`
+}
+
+// escalationTaskID identifies the follow-up, so that it can be found and cancelled.
+// A new stay in the same zone gets new IDs.
+func escalationTaskID(d *models.ZoneDwell, step string) string {
+	return fmt.Sprintf("escalation:%s:%d:%s:%d", d.UserID, d.IncidentID, step, d.EnteredAt.Unix())
+}
+
+// ScheduleEscalations schedules the follow-ups of the policy, counted from when the user entered the zone.
+func (q *Client) ScheduleEscalations(ctx context.Context, dwell *models.ZoneDwell, policy *models.EscalationPolicy) error {
+	var enqueueErrs []error
+	for _, step := range models.EscalationSteps {
+		after := policy.After(step)
+		if after <= 0 {
+			continue
+		}
+
+		task := EscalationTask{UserID: dwell.UserID, IncidentID: dwell.IncidentID, Step: step, EnteredAt: dwell.EnteredAt}
+		_, err := q.enqueue(ctx, TypeEscalation, task,
+			asynq.ProcessIn(max(time.Until(dwell.EnteredAt.Add(after)), 0)),
+			asynq.TaskID(escalationTaskID(dwell, step)),
+		)
+		if err != nil && !errors.Is(err, asynq.ErrTaskIDConflict) {
+			enqueueErrs = append(enqueueErrs, fmt.Errorf("%s: %w", step, err))
+		}
+	}
+	return errors.Join(enqueueErrs...)
+}
+
+// CancelEscalations removes the follow-ups still scheduled for the stays. Ones already running
+// find the stay over and do nothing.
+func (q *Client) CancelEscalations(ctx context.Context, dwells []models.ZoneDwell) error {
+	var cancelErrs []error
+	for _, d := range dwells {
+		for _, step := range models.EscalationSteps {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			err := q.inspector.DeleteTask(defaultQueue, escalationTaskID(&d, step))
+			if err != nil && !errors.Is(err, asynq.ErrTaskNotFound) && !errors.Is(err, asynq.ErrQueueNotFound) {
+				cancelErrs = append(cancelErrs, fmt.Errorf("user %s, incident %d, %s: %w", d.UserID, d.IncidentID, step, err))
+			}
+		}
+	}
+	return errors.Join(cancelErrs...)
+}
+
+// EnqueueEscalationCancel queues the cancellation of the follow-ups about the zone of the incident.
+func (q *Client) EnqueueEscalationCancel(ctx context.Context, incidentID int64) error {
+	_, err := q.enqueue(ctx, TypeEscalationCancel, EscalationCancelTask{IncidentID: incidentID})
+	return err
+}
+
+// EnqueueEscalation tells the duty officers that the user is still inside the zone of the incident,
+// over every channel that has recipients, whatever alert types they get otherwise.
+func (q *Client) EnqueueEscalation(ctx context.Context, dwell *models.ZoneDwell, incident models.IncidentShort) error {
+	_, err := q.notifyDuty(ctx, WebhookPayload{
+		UserID:      dwell.UserID,
+		Latitude:    dwell.Latitude,
+		Longitude:   dwell.Longitude,
+		AlertType:   models.EventEscalation,
+		Incidents:   []models.IncidentShort{incident},
+		InsideSince: &dwell.EnteredAt,
+	})
+	return err
+}
@@ -0,0 +1,103 @@
+package queue
+
+import (
+	"slices"
+
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/utils/geo"
+)
+
+// matchesSubscription reports whether the alert passes the subscription's area, severity and category filters.
+// The area is matched against the user's location, severity and category must hold for at least one of the
+// incidents the alert is about.
+func matchesSubscription(sub models.WebhookSubscription, p WebhookPayload) bool {
+	if sub.Area != nil && !areaContains(sub.Area, p.Latitude, p.Longitude) {
+		return false
+	}
+	if sub.MinSeverity == 0 && len(sub.Categories) == 0 {
+		return true
+	}
+
+	for _, inc := range p.AllIncidents() {
+		if inc.Severity < sub.MinSeverity {
+			continue
+		}
+		if len(sub.Categories) > 0 && !slices.Contains(sub.Categories, inc.Category) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// matchesIncidentEvent reports whether the incident passes the subscription's filters before or after the change,
+// so that subscribers also learn about an incident leaving their area or dropping below their severity.
+func matchesIncidentEvent(sub models.WebhookSubscription, p *IncidentEventPayload) bool {
+	if matchesIncident(sub, &p.Incident) {
+		return true
+	}
+	return p.Previous != nil && matchesIncident(sub, p.Previous)
+}
+
+// matchesIncident checks the incident's center against the area, its severity and its category.
+func matchesIncident(sub models.WebhookSubscription, inc *models.Incident) bool {
+	if sub.Area != nil && !areaContains(sub.Area, inc.Latitude, inc.Longitude) {
+		return false
+	}
+	if inc.Severity < sub.MinSeverity {
+		return false
+	}
+	return len(sub.Categories) == 0 || slices.Contains(sub.Categories, inc.Category)
+}
+
+func areaContains(a *models.Area, lat, lon float64) bool {
+	switch a.Type {
+	case models.AreaBBox:
+		if a.SouthWest == nil || a.NorthEast == nil {
+			return false
+		}
+		return geo.InBBox(lat, lon, a.SouthWest.Latitude, a.SouthWest.Longitude, a.NorthEast.Latitude, a.NorthEast.Longitude)
+	case models.AreaPolygon:
+		lats := make([]float64, len(a.Points))
+		lons := make([]float64, len(a.Points))
+		for i, p := range a.Points {
+			lats[i], lons[i] = p.Latitude, p.Longitude
+		}
+		return geo.InPolygon(lat, lon, lats, lons)
+	case models.AreaCircle:
+		if a.Center == nil {
+			return false
+		}
+		circle := geo.Circle{Latitude: a.Center.Latitude, Longitude: a.Center.Longitude, Radius: float64(a.Radius)}
+		return circle.Contains(lat, lon)
+	}
+	return false
+}
+
+// AllIncidents returns every incident the alert refers to, whatever its type.
+func (p WebhookPayload) AllIncidents() []models.IncidentShort {
+	incidents := slices.Clone(p.Incidents)
+	for _, n := range p.Nearby {
+		incidents = append(incidents, n.IncidentShort)
+	}
+	for _, pr := range p.Predicted {
+		incidents = append(incidents, pr.IncidentShort)
+	}
+	return append(incidents, p.Crossed...)
+}
+
+// withoutMuted returns the alert with only the incidents the user hasn't muted, and whether any are left.
+func (p WebhookPayload) withoutMuted() (WebhookPayload, bool) {
+	if len(p.Muted) == 0 {
+		return p, true
+	}
+
+	muted := func(inc models.IncidentShort) bool { return slices.Contains(p.Muted, inc.ID) }
+	p.Incidents = slices.DeleteFunc(slices.Clone(p.Incidents), muted)
+	p.Crossed = slices.DeleteFunc(slices.Clone(p.Crossed), muted)
+	p.Nearby = slices.DeleteFunc(slices.Clone(p.Nearby), func(n models.NearbyIncident) bool { return muted(n.IncidentShort) })
+	p.Predicted = slices.DeleteFunc(slices.Clone(p.Predicted), func(pr models.PredictedIncident) bool { return muted(pr.IncidentShort) })
+	p.Muted = nil
+
+	return p, len(p.Incidents)+len(p.Crossed)+len(p.Nearby)+len(p.Predicted) > 0
+}
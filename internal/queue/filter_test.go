@@ -0,0 +1,65 @@
+package queue
+
+import (
+	"testing"
+
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+)
+
+func TestMatchesIncidentEvent(t *testing.T) {
+	area := &models.Area{
+		Type:      models.AreaBBox,
+		SouthWest: &models.GeoPoint{Latitude: 55, Longitude: 37},
+		NorthEast: &models.GeoPoint{Latitude: 56, Longitude: 38},
+	}
+	inside := models.Incident{ID: 1, Latitude: 55.5, Longitude: 37.5, Severity: 3, Category: "fire"}
+	outside := models.Incident{ID: 1, Latitude: 59.9, Longitude: 30.3, Severity: 3, Category: "fire"}
+	minor := models.Incident{ID: 1, Latitude: 55.5, Longitude: 37.5, Severity: 1, Category: "fire"}
+
+	tests := []struct {
+		name     string
+		sub      models.WebhookSubscription
+		incident models.Incident
+		previous *models.Incident
+		want     bool
+	}{
+		{"No filters", models.WebhookSubscription{}, outside, nil, true},
+		{"Inside the area", models.WebhookSubscription{Area: area}, inside, nil, true},
+		{"Outside the area", models.WebhookSubscription{Area: area}, outside, nil, false},
+		{"Moved out of the area", models.WebhookSubscription{Area: area}, outside, &inside, true},
+		{"Moved into the area", models.WebhookSubscription{Area: area}, inside, &outside, true},
+		{"Below min severity", models.WebhookSubscription{MinSeverity: 2}, minor, nil, false},
+		{"Dropped below min severity", models.WebhookSubscription{MinSeverity: 2}, minor, &inside, true},
+		{"Category listed", models.WebhookSubscription{Categories: []string{"flood", "fire"}}, inside, nil, true},
+		{"Category not listed", models.WebhookSubscription{Categories: []string{"flood"}}, inside, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &IncidentEventPayload{Event: models.EventIncidentUpdated, Incident: tt.incident, Previous: tt.previous}
+			if got := matchesIncidentEvent(tt.sub, p); got != tt.want {
+				t.Errorf("matchesIncidentEvent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebhookTaskData(t *testing.T) {
+	alert := WebhookTask{Payload: WebhookPayload{UserID: "u1", AlertType: models.EventDanger}}
+	data, err := alert.Data()
+	if err != nil {
+		t.Fatalf("Data() error = %v", err)
+	}
+	if want := `{"user_id":"u1","latitude":0,"longitude":0,"alert_type":"danger"}`; string(data) != want {
+		t.Errorf("Data() = %s, want %s", data, want)
+	}
+
+	incident := WebhookTask{Incident: &IncidentEventPayload{Event: models.EventIncidentCreated, Incident: models.Incident{ID: 7}}}
+	data, err = incident.Data()
+	if err != nil {
+		t.Fatalf("Data() error = %v", err)
+	}
+	if want := `{"event":"incident.created","incident":{"id":7,`; len(data) < len(want) || string(data[:len(want)]) != want {
+		t.Errorf("Data() = %s, want it to start with %s", data, want)
+	}
+}
@@ -0,0 +1,236 @@
+package queue
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/ocenb/geo-alerts/internal/config"
+	"github.com/ocenb/geo-alerts/internal/domain/errs"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+)
+
+// inspectPageSize is how many tasks are read from redis at a time while scanning.
+const inspectPageSize = 100
+
+type Inspector struct {
+	inspector *asynq.Inspector
+}
+
+func NewInspector(redisCfg config.RedisConfig) *Inspector {
+	return &Inspector{
+		inspector: asynq.NewInspector(asynq.RedisClientOpt{
+			Addr:         redisCfg.Addr,
+			Password:     redisCfg.Password,
+			DB:           redisCfg.DBQueue,
+			DialTimeout:  redisCfg.DialTimeout,
+			ReadTimeout:  redisCfg.ReadTimeout,
+			WriteTimeout: redisCfg.WriteTimeout,
+		}),
+	}
+}
+
+func (i *Inspector) Queues() ([]models.QueueInfo, error) {
+	names, err := i.inspector.Queues()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list queues: %w", err)
+	}
+
+	queues := make([]models.QueueInfo, 0, len(names))
+	for _, name := range names {
+		info, err := i.inspector.GetQueueInfo(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get queue info: %w", mapErr(err))
+		}
+		queues = append(queues, models.QueueInfo{
+			Queue:     info.Queue,
+			Size:      info.Size,
+			Pending:   info.Pending,
+			Active:    info.Active,
+			Scheduled: info.Scheduled,
+			Retry:     info.Retry,
+			Archived:  info.Archived,
+			Processed: info.Processed,
+			Failed:    info.Failed,
+			LatencyMs: info.Latency.Milliseconds(),
+			Paused:    info.Paused,
+		})
+	}
+
+	return queues, nil
+}
+
+// ListTasks returns tasks in the state that match the filter, in the order asynq keeps them.
+func (i *Inspector) ListTasks(queue, state string, filter models.QueueTaskFilter, limit, offset int) ([]models.QueueTask, error) {
+	tasks := make([]models.QueueTask, 0, limit)
+	skipped := 0
+	err := i.scan(queue, state, func(t *asynq.TaskInfo) bool {
+		if !matchesFilter(t, filter) {
+			return true
+		}
+		if skipped < offset {
+			skipped++
+			return true
+		}
+		tasks = append(tasks, toQueueTask(t))
+		return len(tasks) < limit
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+// RunTask moves a scheduled, retry or archived task to pending.
+func (i *Inspector) RunTask(queue, id string) error {
+	if err := i.checkState(queue, id, asynq.TaskStatePending, asynq.TaskStateActive); err != nil {
+		return err
+	}
+	if err := i.inspector.RunTask(queue, id); err != nil {
+		return fmt.Errorf("failed to run task: %w", mapErr(err))
+	}
+	return nil
+}
+
+// DeleteTask removes a task that isn't being processed.
+func (i *Inspector) DeleteTask(queue, id string) error {
+	if err := i.checkState(queue, id, asynq.TaskStateActive); err != nil {
+		return err
+	}
+	if err := i.inspector.DeleteTask(queue, id); err != nil {
+		return fmt.Errorf("failed to delete task: %w", mapErr(err))
+	}
+	return nil
+}
+
+// checkState fails with ErrTaskState if the task is in one of the forbidden states,
+// asynq reports that case only as an untyped error.
+func (i *Inspector) checkState(queue, id string, forbidden ...asynq.TaskState) error {
+	info, err := i.inspector.GetTaskInfo(queue, id)
+	if err != nil {
+		return fmt.Errorf("failed to get task info: %w", mapErr(err))
+	}
+	if slices.Contains(forbidden, info.State) {
+		return errs.ErrTaskState
+	}
+	return nil
+}
+
+// ReplayArchived moves the archived tasks matching the filter back to pending.
+// Tasks removed concurrently, e.g. by another replay, are skipped.
+func (i *Inspector) ReplayArchived(queue string, filter models.QueueTaskFilter) (int, error) {
+	// Running a task removes it from the archive, so collect the IDs before paging on.
+	var ids []string
+	err := i.scan(queue, models.TaskStateArchived, func(t *asynq.TaskInfo) bool {
+		if matchesFilter(t, filter) {
+			ids = append(ids, t.ID)
+		}
+		return true
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	replayed := 0
+	for _, id := range ids {
+		if err := i.inspector.RunTask(queue, id); err != nil {
+			if errors.Is(err, asynq.ErrTaskNotFound) {
+				continue
+			}
+			return replayed, fmt.Errorf("failed to run task %s: %w", id, mapErr(err))
+		}
+		replayed++
+	}
+
+	return replayed, nil
+}
+
+func (i *Inspector) Close() error {
+	return i.inspector.Close()
+}
+
+// scan pages through the tasks in the state until fn returns false.
+func (i *Inspector) scan(queue, state string, fn func(t *asynq.TaskInfo) bool) error {
+	list := i.inspector.ListPendingTasks
+	switch state {
+	case models.TaskStateRetry:
+		list = i.inspector.ListRetryTasks
+	case models.TaskStateArchived:
+		list = i.inspector.ListArchivedTasks
+	}
+
+	for page := 1; ; page++ {
+		tasks, err := list(queue, asynq.Page(page), asynq.PageSize(inspectPageSize))
+		if err != nil {
+			return fmt.Errorf("failed to list %s tasks: %w", state, mapErr(err))
+		}
+		for _, t := range tasks {
+			if !fn(t) {
+				return nil
+			}
+		}
+		if len(tasks) < inspectPageSize {
+			return nil
+		}
+	}
+}
+
+func matchesFilter(t *asynq.TaskInfo, f models.QueueTaskFilter) bool {
+	if f.Error != "" && !strings.Contains(t.LastErr, f.Error) {
+		return false
+	}
+	if f.From != nil && t.LastFailedAt.Before(*f.From) {
+		return false
+	}
+	if f.To != nil && !t.LastFailedAt.Before(*f.To) {
+		return false
+	}
+	return true
+}
+
+func toQueueTask(t *asynq.TaskInfo) models.QueueTask {
+	task := models.QueueTask{
+		ID:            t.ID,
+		Queue:         t.Queue,
+		Type:          t.Type,
+		State:         t.State.String(),
+		Payload:       json.RawMessage(t.Payload),
+		MaxRetry:      t.MaxRetry,
+		Retried:       t.Retried,
+		LastError:     t.LastErr,
+		LastFailedAt:  nonZero(t.LastFailedAt),
+		NextProcessAt: nonZero(t.NextProcessAt),
+	}
+
+	// Other task types have payloads of their own, they are shown as they are stored.
+	if slices.Contains(webhookTaskTypes, t.Type) {
+		var decoded WebhookTask
+		if err := json.Unmarshal(t.Payload, &decoded); err == nil {
+			task.Payload = decoded
+		}
+	}
+
+	return task
+}
+
+func nonZero(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+func mapErr(err error) error {
+	switch {
+	case errors.Is(err, asynq.ErrQueueNotFound):
+		return errs.ErrQueueNotFound
+	case errors.Is(err, asynq.ErrTaskNotFound):
+		return errs.ErrTaskNotFound
+	}
+	return err
+}
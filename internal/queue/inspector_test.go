@@ -0,0 +1,35 @@
+package queue
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hibiken/asynq"
+)
+
+func TestToQueueTask_Payload(t *testing.T) {
+	tests := []struct {
+		name        string
+		taskType    string
+		payload     string
+		wantWebhook bool
+	}{
+		{"Webhook task", TypeDangerWebhook, `{"subscription_id":1}`, true},
+		{"Push notification", TypePushNotification, `{"device_id":"dev-1"}`, false},
+		{"Retroactive alert", TypeRetroactiveAlert, `{"incident_id":1}`, false},
+		{"Undecodable webhook task", TypeDigestWebhook, `not json`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := toQueueTask(&asynq.TaskInfo{Type: tt.taskType, State: asynq.TaskStateArchived, Payload: []byte(tt.payload)})
+
+			if _, ok := task.Payload.(WebhookTask); ok != tt.wantWebhook {
+				t.Fatalf("payload decoded as WebhookTask = %v, want %v", ok, tt.wantWebhook)
+			}
+			if raw, ok := task.Payload.(json.RawMessage); ok && string(raw) != tt.payload {
+				t.Errorf("raw payload = %s, want %s", raw, tt.payload)
+			}
+		})
+	}
+}
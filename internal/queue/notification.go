@@ -0,0 +1,388 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ocenb/geo-alerts/internal/config"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+)
+
+// Notification is a message to one recipient over one channel.
+type Notification struct {
+	ID        string          `json:"id"` // the same for all recipients of the event and across retries
+	Event     string          `json:"event"`
+	Time      time.Time       `json:"time"`
+	Recipient string          `json:"recipient"`          // URL, email address, phone number or push token, depending on the channel
+	Platform  string          `json:"platform,omitempty"` // of the device a push goes to
+	Subject   string          `json:"subject"`            // one line, also the whole text of an SMS
+	Text      string          `json:"text"`
+	Data      json.RawMessage `json:"data,omitempty"` // event data for receivers that aren't people
+
+	BroadcastRecipientID int64 `json:"broadcast_recipient_id,omitempty"` // whose delivery is reported back to the broadcast
+}
+
+// enqueueNotification sends the notification to each recipient over the channel of the task type,
+// one task per recipient so that a failing recipient doesn't hold up or repeat the others.
+// With a delivery key, a recipient already sent the notification isn't sent it again. Returns how many
+// recipients have it queued.
+func (q *Client) enqueueNotification(ctx context.Context, taskType string, recipients []string, n Notification, key string) (int, error) {
+	if n.ID == "" {
+		n.ID = uuid.NewString()
+	}
+	if n.Time.IsZero() {
+		n.Time = time.Now().UTC()
+	}
+
+	queued := 0
+	var enqueueErrs []error
+	for _, r := range recipients {
+		t := n
+		t.Recipient = r
+		if err := q.enqueueOnce(ctx, taskType, t, key, taskType+":"+r); err != nil {
+			enqueueErrs = append(enqueueErrs, fmt.Errorf("%s to %s: %w", taskType, r, err))
+			continue
+		}
+		queued++
+	}
+	return queued, errors.Join(enqueueErrs...)
+}
+
+// notifyDuty tells the duty officers about the alert over every channel that has recipients.
+// Returns how many notifications are queued.
+func (q *Client) notifyDuty(ctx context.Context, p WebhookPayload) (int, error) {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal notification data: %w", err)
+	}
+
+	subject, text := alertMessage(p)
+	n := Notification{
+		ID:      uuid.NewString(),
+		Event:   p.AlertType,
+		Time:    time.Now().UTC(),
+		Subject: subject,
+		Text:    text,
+		Data:    data,
+	}
+
+	queued := 0
+	var notifyErrs []error
+	for _, taskType := range notificationTaskTypes {
+		if recipients := q.dutyRecipients[taskType]; len(recipients) > 0 {
+			sent, err := q.enqueueNotification(ctx, taskType, recipients, n, subKey(deliveryKey(p), "duty"))
+			queued += sent
+			notifyErrs = append(notifyErrs, err)
+		}
+	}
+	return queued, errors.Join(notifyErrs...)
+}
+
+// notifyGuardians tells the guardians of the groups in which the user consented to it about the alert.
+// A guardian listed in several of the user's groups is notified once. Returns how many notifications are queued.
+func (q *Client) notifyGuardians(ctx context.Context, p WebhookPayload) (int, error) {
+	guardians, err := q.guardians.ListGuardiansOfMember(ctx, p.UserID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list guardians: %w", err)
+	}
+	if len(guardians) == 0 {
+		return 0, nil
+	}
+
+	data, err := json.Marshal(p)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal notification data: %w", err)
+	}
+
+	subject, text := alertMessage(p)
+	n := Notification{
+		ID:      uuid.NewString(),
+		Event:   p.AlertType,
+		Time:    time.Now().UTC(),
+		Subject: subject,
+		Text:    text,
+		Data:    data,
+	}
+
+	recipients := guardianRecipients(guardians)
+	queued := 0
+	var notifyErrs []error
+	for _, taskType := range q.guardianTypes {
+		if len(recipients[taskType]) > 0 {
+			sent, err := q.enqueueNotification(ctx, taskType, recipients[taskType], n, subKey(deliveryKey(p), "guardian"))
+			queued += sent
+			notifyErrs = append(notifyErrs, err)
+		}
+	}
+	return queued, errors.Join(notifyErrs...)
+}
+
+// guardianTaskTypes maps guardian channels to the notification task types they are sent with.
+var guardianTaskTypes = map[string]string{
+	models.GuardianWebhook: TypeWebhookNotification,
+	models.GuardianEmail:   TypeEmailNotification,
+	models.GuardianSMS:     TypeSMSNotification,
+}
+
+// guardianNotificationTypes returns the notification task types whose channel is configured.
+// Guardians on other channels are left out rather than failing every time.
+func guardianNotificationTypes(cfg config.NotifyConfig) []string {
+	taskTypes := []string{TypeWebhookNotification}
+	if cfg.SMTPHost != "" && cfg.SMTPFrom != "" {
+		taskTypes = append(taskTypes, TypeEmailNotification)
+	}
+	if cfg.SMSGatewayURL != "" {
+		taskTypes = append(taskTypes, TypeSMSNotification)
+	}
+	return taskTypes
+}
+
+// guardianRecipients groups the addresses of the guardians by notification task type, without repeats.
+func guardianRecipients(guardians []models.Guardian) map[string][]string {
+	recipients := make(map[string][]string)
+	for _, g := range guardians {
+		taskType, ok := guardianTaskTypes[g.Channel]
+		if !ok || slices.Contains(recipients[taskType], g.Address) {
+			continue
+		}
+		recipients[taskType] = append(recipients[taskType], g.Address)
+	}
+	return recipients
+}
+
+// alertMessage renders an alert for people to read.
+func alertMessage(p WebhookPayload) (subject, text string) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "User %s at %.6f, %.6f\n", p.UserID, p.Latitude, p.Longitude)
+
+	switch p.AlertType {
+	case models.EventDanger:
+		subject = fmt.Sprintf("Danger: user %s is inside %s", p.UserID, incidentCount(len(p.Incidents)))
+		writeIncidents(&b, "Inside", p.Incidents)
+	case models.EventPathCrossing:
+		subject = fmt.Sprintf("Danger: user %s passed through %s", p.UserID, incidentCount(len(p.Crossed)))
+		writeIncidents(&b, "Passed through", p.Crossed)
+	case models.EventPlaceDanger:
+		subject = fmt.Sprintf("Danger: %s reaches into a place of user %s", incidentCount(len(p.Incidents)), p.UserID)
+		if p.Place != nil {
+			fmt.Fprintf(&b, "Place %q, radius %d m\n", p.Place.Name, p.Place.Radius)
+		}
+		writeIncidents(&b, "Reaching into it", p.Incidents)
+	case models.EventEscalation:
+		subject = fmt.Sprintf("Escalation: user %s is still inside %s", p.UserID, incidentCount(len(p.Incidents)))
+		if p.InsideSince != nil {
+			fmt.Fprintf(&b, "Inside since %s\n", p.InsideSince.UTC().Format(time.RFC3339))
+		}
+		writeIncidents(&b, "Inside", p.Incidents)
+	case models.EventUnacknowledged:
+		subject = fmt.Sprintf("Unacknowledged: nobody acknowledged alert %s about user %s", p.AlertID, p.UserID)
+		if p.Place != nil {
+			fmt.Fprintf(&b, "Place %q, radius %d m\n", p.Place.Name, p.Place.Radius)
+		}
+		writeIncidents(&b, "About", p.Incidents)
+	case models.EventProximity:
+		subject = fmt.Sprintf("Warning: user %s is approaching %s", p.UserID, incidentCount(len(p.Nearby)))
+		b.WriteString("Approaching:\n")
+		for _, inc := range p.Nearby {
+			writeIncident(&b, inc.IncidentShort)
+			fmt.Fprintf(&b, ", %.0f m away\n", inc.DistanceToEdge)
+		}
+	case models.EventPredictedDanger:
+		subject = fmt.Sprintf("Warning: user %s is expected to enter %s", p.UserID, incidentCount(len(p.Predicted)))
+		b.WriteString("Expected to enter:\n")
+		for _, inc := range p.Predicted {
+			writeIncident(&b, inc.IncidentShort)
+			fmt.Fprintf(&b, ", in %.0f s\n", inc.TimeToEntry)
+		}
+	default:
+		subject = fmt.Sprintf("Alert %s for user %s", p.AlertType, p.UserID)
+	}
+	return subject, b.String()
+}
+
+func writeIncidents(b *strings.Builder, title string, incidents []models.IncidentShort) {
+	b.WriteString(title + ":\n")
+	for _, inc := range incidents {
+		writeIncident(b, inc)
+		b.WriteString("\n")
+	}
+}
+
+func writeIncident(b *strings.Builder, inc models.IncidentShort) {
+	fmt.Fprintf(b, "- incident %d (%s, severity %d) at %.6f, %.6f, radius %d m",
+		inc.ID, inc.Category, inc.Severity, inc.Latitude, inc.Longitude, inc.Radius)
+}
+
+func incidentCount(n int) string {
+	if n == 1 {
+		return "1 incident zone"
+	}
+	return fmt.Sprintf("%d incident zones", n)
+}
+
+// notifyDevices pushes the alert to the devices the user registered, each in its own language.
+// Incidents the user muted are left out of the push, and nothing is pushed if that leaves none.
+// Returns how many pushes are queued.
+func (q *Client) notifyDevices(ctx context.Context, p WebhookPayload) (int, error) {
+	key := subKey(deliveryKey(p), "push")
+	p, ok := p.withoutMuted()
+	if !ok {
+		return 0, nil
+	}
+
+	devices, err := q.devices.ListByUser(ctx, p.UserID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list devices: %w", err)
+	}
+
+	data, err := json.Marshal(p)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal notification data: %w", err)
+	}
+	id, now := uuid.NewString(), time.Now().UTC()
+
+	queued := 0
+	var enqueueErrs []error
+	for _, d := range devices {
+		if !slices.Contains(q.pushPlatforms, d.Platform) {
+			continue
+		}
+
+		title, body := pushMessage(p, d.Locale)
+		n := Notification{
+			ID:        id,
+			Event:     p.AlertType,
+			Time:      now,
+			Recipient: d.Token,
+			Platform:  d.Platform,
+			Subject:   title,
+			Text:      body,
+			Data:      data,
+		}
+		if err := q.enqueueOnce(ctx, TypePushNotification, n, key, strconv.FormatInt(d.ID, 10)); err != nil {
+			enqueueErrs = append(enqueueErrs, fmt.Errorf("device %d: %w", d.ID, err))
+			continue
+		}
+		queued++
+	}
+	return queued, errors.Join(enqueueErrs...)
+}
+
+// pushTexts are the words of pushes in one language. Bodies take the list of zones.
+type pushTexts struct {
+	titles   map[string]string // by alert type
+	bodies   map[string]string // by alert type
+	zone     string            // category and severity
+	distance string            // meters to the zone edge
+	entry    string            // seconds until the zone is entered
+
+	reminderTitle string // asks a user seen in a zone to check in
+	reminderBody  string // takes the zone
+}
+
+const defaultPushLocale = "en"
+
+var pushLocales = map[string]pushTexts{
+	"en": {
+		titles: map[string]string{
+			models.EventDanger:          "You are in a danger zone",
+			models.EventPathCrossing:    "You passed through a danger zone",
+			models.EventProximity:       "Danger zone nearby",
+			models.EventPredictedDanger: "Danger zone ahead",
+			models.EventPlaceDanger:     "Danger near a saved place",
+		},
+		bodies: map[string]string{
+			models.EventDanger:          "Leave the area: %s.",
+			models.EventPathCrossing:    "Your route crossed: %s.",
+			models.EventProximity:       "You are approaching: %s.",
+			models.EventPredictedDanger: "On your current course you will enter: %s.",
+			models.EventPlaceDanger:     "Near %s: %s.",
+		},
+		zone:     "%s (severity %d)",
+		distance: "%s, %.0f m away",
+		entry:    "%s in %.0f s",
+
+		reminderTitle: "Are you safe?",
+		reminderBody:  "You were recently in a danger zone: %s. Please let us know how you are.",
+	},
+	"ru": {
+		titles: map[string]string{
+			models.EventDanger:          "Вы в опасной зоне",
+			models.EventPathCrossing:    "Вы прошли через опасную зону",
+			models.EventProximity:       "Рядом опасная зона",
+			models.EventPredictedDanger: "Впереди опасная зона",
+			models.EventPlaceDanger:     "Опасность рядом с сохранённым местом",
+		},
+		bodies: map[string]string{
+			models.EventDanger:          "Покиньте район: %s.",
+			models.EventPathCrossing:    "Ваш маршрут пересёк: %s.",
+			models.EventProximity:       "Вы приближаетесь: %s.",
+			models.EventPredictedDanger: "При текущем курсе вы войдёте в зону: %s.",
+			models.EventPlaceDanger:     "Рядом с «%s»: %s.",
+		},
+		zone:     "%s (уровень опасности %d)",
+		distance: "%s, %.0f м",
+		entry:    "%s через %.0f с",
+
+		reminderTitle: "Вы в безопасности?",
+		reminderBody:  "Недавно вы были в опасной зоне: %s. Пожалуйста, сообщите, как вы.",
+	},
+}
+
+// localeTexts returns the push texts in the language of the locale, or in English if there are none for it.
+func localeTexts(locale string) pushTexts {
+	lang, _, _ := strings.Cut(strings.ToLower(locale), "-")
+	lang, _, _ = strings.Cut(lang, "_")
+	texts, ok := pushLocales[lang]
+	if !ok {
+		texts = pushLocales[defaultPushLocale]
+	}
+	return texts
+}
+
+// pushMessage renders an alert for the user it is about, in the language of the locale
+// or in English if there are no texts for it.
+func pushMessage(p WebhookPayload, locale string) (title, body string) {
+	texts := localeTexts(locale)
+
+	zone := func(inc models.IncidentShort) string {
+		return fmt.Sprintf(texts.zone, inc.Category, inc.Severity)
+	}
+	var zones []string
+	switch p.AlertType {
+	case models.EventDanger, models.EventPlaceDanger:
+		for _, inc := range p.Incidents {
+			zones = append(zones, zone(inc))
+		}
+	case models.EventPathCrossing:
+		for _, inc := range p.Crossed {
+			zones = append(zones, zone(inc))
+		}
+	case models.EventProximity:
+		for _, inc := range p.Nearby {
+			zones = append(zones, fmt.Sprintf(texts.distance, zone(inc.IncidentShort), inc.DistanceToEdge))
+		}
+	case models.EventPredictedDanger:
+		for _, inc := range p.Predicted {
+			zones = append(zones, fmt.Sprintf(texts.entry, zone(inc.IncidentShort), inc.TimeToEntry))
+		}
+	}
+
+	body, args := texts.bodies[p.AlertType], []any{strings.Join(zones, ", ")}
+	switch {
+	case p.Place != nil:
+		// Bodies of place alerts name the place first.
+		args = append([]any{p.Place.Name}, args...)
+	case p.AlertType == models.EventPlaceDanger:
+		// Without the place there is nothing to name, the zones are listed as in a danger alert.
+		body = texts.bodies[models.EventDanger]
+	}
+	return texts.titles[p.AlertType], fmt.Sprintf(body, args...)
+}
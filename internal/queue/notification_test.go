@@ -0,0 +1,180 @@
+package queue
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+)
+
+func TestAlertMessage(t *testing.T) {
+	fire := models.IncidentShort{ID: 1, Latitude: 55.75, Longitude: 37.62, Radius: 500, Severity: 4, Category: "fire"}
+	flood := models.IncidentShort{ID: 2, Latitude: 55.76, Longitude: 37.63, Radius: 300, Severity: 2, Category: "flood"}
+	entered := time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		payload     WebhookPayload
+		wantSubject string
+		wantText    []string
+	}{
+		{
+			name:        "Danger",
+			payload:     WebhookPayload{UserID: "u1", AlertType: models.EventDanger, Incidents: []models.IncidentShort{fire, flood}},
+			wantSubject: "Danger: user u1 is inside 2 incident zones",
+			wantText:    []string{"Inside:", "incident 1 (fire, severity 4)", "incident 2 (flood, severity 2)"},
+		},
+		{
+			name:        "Crossing",
+			payload:     WebhookPayload{UserID: "u1", AlertType: models.EventPathCrossing, Crossed: []models.IncidentShort{fire}},
+			wantSubject: "Danger: user u1 passed through 1 incident zone",
+			wantText:    []string{"Passed through:", "incident 1"},
+		},
+		{
+			name:        "Proximity",
+			payload:     WebhookPayload{UserID: "u1", AlertType: models.EventProximity, Nearby: []models.NearbyIncident{{IncidentShort: fire, DistanceToEdge: 120}}},
+			wantSubject: "Warning: user u1 is approaching 1 incident zone",
+			wantText:    []string{"incident 1", "120 m away"},
+		},
+		{
+			name:        "Predicted",
+			payload:     WebhookPayload{UserID: "u1", AlertType: models.EventPredictedDanger, Predicted: []models.PredictedIncident{{IncidentShort: flood, TimeToEntry: 90}}},
+			wantSubject: "Warning: user u1 is expected to enter 1 incident zone",
+			wantText:    []string{"incident 2", "in 90 s"},
+		},
+		{
+			name:        "Escalation",
+			payload:     WebhookPayload{UserID: "u1", AlertType: models.EventEscalation, Incidents: []models.IncidentShort{fire}, InsideSince: &entered},
+			wantSubject: "Escalation: user u1 is still inside 1 incident zone",
+			wantText:    []string{"Inside since 2026-05-01T10:00:00Z", "incident 1 (fire, severity 4)"},
+		},
+		{
+			name:        "Unacknowledged",
+			payload:     WebhookPayload{AlertID: "a1", UserID: "u1", AlertType: models.EventUnacknowledged, Incidents: []models.IncidentShort{flood}},
+			wantSubject: "Unacknowledged: nobody acknowledged alert a1 about user u1",
+			wantText:    []string{"About:", "incident 2 (flood, severity 2)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subject, text := alertMessage(tt.payload)
+
+			if subject != tt.wantSubject {
+				t.Errorf("subject = %q, want %q", subject, tt.wantSubject)
+			}
+			for _, want := range tt.wantText {
+				if !strings.Contains(text, want) {
+					t.Errorf("text = %q, want it to contain %q", text, want)
+				}
+			}
+		})
+	}
+}
+
+func TestPushMessage(t *testing.T) {
+	fire := models.IncidentShort{ID: 1, Severity: 4, Category: "fire"}
+	flood := models.IncidentShort{ID: 2, Severity: 2, Category: "flood"}
+	danger := WebhookPayload{UserID: "u1", AlertType: models.EventDanger, Incidents: []models.IncidentShort{fire, flood}}
+
+	tests := []struct {
+		name      string
+		payload   WebhookPayload
+		locale    string
+		wantTitle string
+		wantBody  string
+	}{
+		{"English", danger, "en", "You are in a danger zone", "Leave the area: fire (severity 4), flood (severity 2)."},
+		{"Russian with region", danger, "ru-RU", "Вы в опасной зоне", "Покиньте район: fire (уровень опасности 4), flood (уровень опасности 2)."},
+		{"Underscore separator", danger, "RU_ru", "Вы в опасной зоне", "Покиньте район: fire (уровень опасности 4), flood (уровень опасности 2)."},
+		{"Unknown locale falls back to English", danger, "de-DE", "You are in a danger zone", "Leave the area: fire (severity 4), flood (severity 2)."},
+		{
+			name:      "Proximity",
+			payload:   WebhookPayload{AlertType: models.EventProximity, Nearby: []models.NearbyIncident{{IncidentShort: fire, DistanceToEdge: 120}}},
+			locale:    "en",
+			wantTitle: "Danger zone nearby",
+			wantBody:  "You are approaching: fire (severity 4), 120 m away.",
+		},
+		{
+			name:      "Predicted",
+			payload:   WebhookPayload{AlertType: models.EventPredictedDanger, Predicted: []models.PredictedIncident{{IncidentShort: flood, TimeToEntry: 90}}},
+			locale:    "ru",
+			wantTitle: "Впереди опасная зона",
+			wantBody:  "При текущем курсе вы войдёте в зону: flood (уровень опасности 2) через 90 с.",
+		},
+		{
+			name:      "Saved place",
+			payload:   WebhookPayload{AlertType: models.EventPlaceDanger, Incidents: []models.IncidentShort{fire}, Place: &models.SavedPlace{Name: "Home"}},
+			locale:    "en",
+			wantTitle: "Danger near a saved place",
+			wantBody:  "Near Home: fire (severity 4).",
+		},
+		{
+			name:      "Saved place missing",
+			payload:   WebhookPayload{AlertType: models.EventPlaceDanger, Incidents: []models.IncidentShort{fire}},
+			locale:    "en",
+			wantTitle: "Danger near a saved place",
+			wantBody:  "Leave the area: fire (severity 4).",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			title, body := pushMessage(tt.payload, tt.locale)
+
+			if title != tt.wantTitle || body != tt.wantBody {
+				t.Errorf("pushMessage() = (%q, %q), want (%q, %q)", title, body, tt.wantTitle, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestReminderMessage(t *testing.T) {
+	fire := &models.Incident{ID: 1, Severity: 4, Category: "fire"}
+
+	tests := []struct {
+		locale    string
+		wantTitle string
+		wantBody  string
+	}{
+		{"en-US", "Are you safe?", "You were recently in a danger zone: fire (severity 4). Please let us know how you are."},
+		{"ru", "Вы в безопасности?", "Недавно вы были в опасной зоне: fire (уровень опасности 4). Пожалуйста, сообщите, как вы."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			title, body := reminderMessage(fire, tt.locale)
+
+			if title != tt.wantTitle || body != tt.wantBody {
+				t.Errorf("reminderMessage() = (%q, %q), want (%q, %q)", title, body, tt.wantTitle, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestGuardianRecipients(t *testing.T) {
+	guardians := []models.Guardian{
+		{ID: 1, GroupID: 1, Channel: models.GuardianEmail, Address: "parent@example.com"},
+		{ID: 2, GroupID: 1, Channel: models.GuardianSMS, Address: "+15550100"},
+		{ID: 3, GroupID: 2, Channel: models.GuardianEmail, Address: "parent@example.com"},
+		{ID: 4, GroupID: 2, Channel: models.GuardianWebhook, Address: "https://school.example.com/alerts"},
+		{ID: 5, GroupID: 2, Channel: "pager", Address: "12345"},
+	}
+
+	got := guardianRecipients(guardians)
+
+	want := map[string][]string{
+		TypeEmailNotification:   {"parent@example.com"},
+		TypeSMSNotification:     {"+15550100"},
+		TypeWebhookNotification: {"https://school.example.com/alerts"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d task types, want %d: %v", len(got), len(want), got)
+	}
+	for taskType, addrs := range want {
+		if strings.Join(got[taskType], ",") != strings.Join(addrs, ",") {
+			t.Errorf("%s recipients = %v, want %v", taskType, got[taskType], addrs)
+		}
+	}
+}
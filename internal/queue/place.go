@@ -0,0 +1,68 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+)
+
+// PlaceAlertTask asks to alert the owners of the saved places the zone of an incident reaches into.
+type PlaceAlertTask struct {
+	IncidentID int64 `json:"incident_id"`
+}
+
+// EnqueuePlaceAlertScan queues the lookup of the saved places near the incident.
+func (q *Client) EnqueuePlaceAlertScan(ctx context.Context, incidentID int64) error {
+	_, err := q.enqueue(ctx, TypePlaceAlert, PlaceAlertTask{IncidentID: incidentID})
+	return err
+}
+
+// EnqueuePlaceAlert alerts the owner of the place about the incidents whose zones reach into it.
+// The alert carries the location of the place rather than of the user.
+func (q *Client) EnqueuePlaceAlert(ctx context.Context, place *models.SavedPlace, incidents []models.IncidentShort) error {
+	return q.enqueueAlert(ctx, TypePlaceWebhook, WebhookPayload{
+		UserID:    place.UserID,
+		Latitude:  place.Latitude,
+		Longitude: place.Longitude,
+		AlertType: models.EventPlaceDanger,
+		Incidents: incidents,
+		Place:     place,
+	})
+}
+
+// EnqueuePlaceAlerts sends one alert per place, about all of its matching incidents.
+// Returns the matches whose alert couldn't be enqueued.
+func (q *Client) EnqueuePlaceAlerts(ctx context.Context, matches []models.PlaceMatch) ([]models.PlaceMatch, error) {
+	var failed []models.PlaceMatch
+	var enqueueErrs []error
+	for _, group := range groupByPlace(matches) {
+		place := group[0].Place
+		incidents := make([]models.IncidentShort, len(group))
+		for i, m := range group {
+			incidents[i] = m.Incident
+		}
+		if err := q.EnqueuePlaceAlert(ctx, &place, incidents); err != nil {
+			failed = append(failed, group...)
+			enqueueErrs = append(enqueueErrs, fmt.Errorf("place %d: %w", place.ID, err))
+		}
+	}
+	return failed, errors.Join(enqueueErrs...)
+}
+
+// groupByPlace splits the matches into runs of the same place, keeping their order.
+func groupByPlace(matches []models.PlaceMatch) [][]models.PlaceMatch {
+	var groups [][]models.PlaceMatch
+	index := make(map[int64]int)
+	for _, m := range matches {
+		i, ok := index[m.Place.ID]
+		if !ok {
+			i = len(groups)
+			index[m.Place.ID] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], m)
+	}
+	return groups
+}
@@ -0,0 +1,59 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/ocenb/geo-alerts/internal/domain/errs"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/logger/logattr"
+)
+
+type PreferenceSource interface {
+	Get(ctx context.Context, userID string) (*models.UserPreferences, error)
+	RecordSuppression(ctx context.Context, s *models.AlertSuppression) error
+}
+
+// mutedIncidents returns the incidents of the alert the user's preferences hold back from their own devices,
+// and records why. Guardians, duty officers and subscribers are alerted regardless. Preferences that can't
+// be read mute nothing, missing an alert is worse than getting one the user didn't want.
+func (q *Client) mutedIncidents(ctx context.Context, log *slog.Logger, p WebhookPayload) []int64 {
+	prefs, err := q.prefs.Get(ctx, p.UserID)
+	if err != nil {
+		if !errors.Is(err, errs.ErrPreferencesNotFound) {
+			log.Warn("failed to get preferences for user, alerting regardless", logattr.Err(err))
+		}
+		return nil
+	}
+
+	now := time.Now()
+	var muted []int64
+	byReason := make(map[string][]int64)
+	for _, inc := range p.AllIncidents() {
+		if slices.Contains(muted, inc.ID) {
+			continue
+		}
+		if reason := prefs.Suppresses(inc, now); reason != "" {
+			muted = append(muted, inc.ID)
+			byReason[reason] = append(byReason[reason], inc.ID)
+		}
+	}
+
+	for _, reason := range slices.Sorted(maps.Keys(byReason)) {
+		log.Info("alert suppressed by user preferences", slog.String("reason", reason), slog.Int("incidents", len(byReason[reason])))
+		err := q.prefs.RecordSuppression(ctx, &models.AlertSuppression{
+			UserID:      p.UserID,
+			AlertType:   p.AlertType,
+			IncidentIDs: byReason[reason],
+			Reason:      reason,
+		})
+		if err != nil {
+			log.Warn("failed to record alert suppression", logattr.Err(err))
+		}
+	}
+	return muted
+}
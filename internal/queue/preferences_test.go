@@ -0,0 +1,134 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/ocenb/geo-alerts/internal/domain/errs"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/logger"
+)
+
+type fakePrefs struct {
+	prefs      *models.UserPreferences
+	err        error
+	suppressed []models.AlertSuppression
+}
+
+func (f *fakePrefs) Get(_ context.Context, _ string) (*models.UserPreferences, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	if f.prefs == nil {
+		return nil, errs.ErrPreferencesNotFound
+	}
+	return f.prefs, nil
+}
+
+func (f *fakePrefs) RecordSuppression(_ context.Context, s *models.AlertSuppression) error {
+	f.suppressed = append(f.suppressed, *s)
+	return nil
+}
+
+func TestMutedIncidents(t *testing.T) {
+	fire := models.IncidentShort{ID: 1, Severity: 4, Category: "fire"}
+	minor := models.IncidentShort{ID: 2, Severity: 1, Category: "fire"}
+	jam := models.IncidentShort{ID: 3, Severity: 5, Category: "traffic"}
+	danger := WebhookPayload{UserID: "u1", AlertType: models.EventDanger, Incidents: []models.IncidentShort{fire, minor, jam}}
+
+	tests := []struct {
+		name        string
+		prefs       *fakePrefs
+		payload     WebhookPayload
+		wantMuted   []int64
+		wantReasons []string
+	}{
+		{"No preferences", &fakePrefs{}, danger, nil, nil},
+		{"Preferences can't be read", &fakePrefs{err: errors.New("db error")}, danger, nil, nil},
+		{
+			"Opted out",
+			&fakePrefs{prefs: &models.UserPreferences{UserID: "u1", OptedOut: true}},
+			danger, []int64{1, 2, 3}, []string{models.SuppressedOptedOut},
+		},
+		{
+			"Severity and category",
+			&fakePrefs{prefs: &models.UserPreferences{UserID: "u1", MinSeverity: 3, MutedCategories: []string{"traffic"}}},
+			danger, []int64{2, 3}, []string{models.SuppressedMinSeverity, models.SuppressedCategory},
+		},
+		{
+			"Incident listed twice is muted once",
+			&fakePrefs{prefs: &models.UserPreferences{UserID: "u1", MutedCategories: []string{"fire"}}},
+			WebhookPayload{UserID: "u1", AlertType: models.EventPathCrossing, Incidents: []models.IncidentShort{fire}, Crossed: []models.IncidentShort{fire}},
+			[]int64{1}, []string{models.SuppressedCategory},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := &Client{prefs: tt.prefs}
+
+			muted := q.mutedIncidents(context.Background(), logger.NewDiscard(), tt.payload)
+
+			if !slices.Equal(muted, tt.wantMuted) {
+				t.Errorf("muted = %v, want %v", muted, tt.wantMuted)
+			}
+			reasons := make([]string, 0, len(tt.prefs.suppressed))
+			for _, s := range tt.prefs.suppressed {
+				if s.UserID != "u1" || s.AlertType != tt.payload.AlertType {
+					t.Errorf("suppression = %+v, want one of user u1 for %s", s, tt.payload.AlertType)
+				}
+				reasons = append(reasons, s.Reason)
+			}
+			if !slices.Equal(reasons, tt.wantReasons) {
+				t.Errorf("suppression reasons = %v, want %v", reasons, tt.wantReasons)
+			}
+		})
+	}
+}
+
+func TestWithoutMuted(t *testing.T) {
+	fire := models.IncidentShort{ID: 1, Category: "fire"}
+	flood := models.IncidentShort{ID: 2, Category: "flood"}
+
+	tests := []struct {
+		name    string
+		payload WebhookPayload
+		wantIDs []int64
+		wantOK  bool
+	}{
+		{"Nothing muted", WebhookPayload{Incidents: []models.IncidentShort{fire, flood}}, []int64{1, 2}, true},
+		{"Some muted", WebhookPayload{Incidents: []models.IncidentShort{fire, flood}, Muted: []int64{1}}, []int64{2}, true},
+		{"All muted", WebhookPayload{Incidents: []models.IncidentShort{fire, flood}, Muted: []int64{1, 2}}, []int64{}, false},
+		{
+			"Nearby and predicted",
+			WebhookPayload{
+				Nearby:    []models.NearbyIncident{{IncidentShort: fire}},
+				Predicted: []models.PredictedIncident{{IncidentShort: flood}},
+				Muted:     []int64{2},
+			},
+			[]int64{1}, true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.payload.withoutMuted()
+
+			if ok != tt.wantOK {
+				t.Errorf("withoutMuted() ok = %v, want %v", ok, tt.wantOK)
+			}
+			ids := []int64{}
+			for _, inc := range got.AllIncidents() {
+				ids = append(ids, inc.ID)
+			}
+			if !slices.Equal(ids, tt.wantIDs) {
+				t.Errorf("incidents left = %v, want %v", ids, tt.wantIDs)
+			}
+			if len(got.Muted) != 0 {
+				t.Errorf("muted = %v, want none", got.Muted)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package queue
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/ocenb/geo-alerts/internal/config"
+)
+
+// ErrDeferred marks a task put off without being attempted, e.g. while its receiver's circuit breaker is open.
+// Such retries don't count towards the task's retry limit.
+var ErrDeferred = errors.New("task deferred")
+
+// RetryAfterError asks for the next attempt not to happen before Delay, e.g. when a receiver rate limits us.
+type RetryAfterError struct {
+	Delay time.Duration
+	Err   error
+}
+
+func (e *RetryAfterError) Error() string {
+	return fmt.Sprintf("%v (retry after %s)", e.Err, e.Delay)
+}
+
+func (e *RetryAfterError) Unwrap() error {
+	return e.Err
+}
+
+// NewRetryDelayFunc honours RetryAfterError up to the max delay, so a receiver can't park a task for days,
+// and otherwise backs off exponentially from the base delay up to the max delay, with jitter so that tasks
+// failed together don't retry together.
+func NewRetryDelayFunc(cfg config.QueueConfig) asynq.RetryDelayFunc {
+	return func(n int, err error, _ *asynq.Task) time.Duration {
+		var retryAfter *RetryAfterError
+		if errors.As(err, &retryAfter) && retryAfter.Delay > 0 {
+			return min(retryAfter.Delay, cfg.RetryMaxDelay)
+		}
+		return backoff(n, cfg.RetryBaseDelay, cfg.RetryMaxDelay, cfg.RetryJitter, rand.Float64())
+	}
+}
+
+// isFailure tells asynq which errors use up a retry.
+func isFailure(err error) bool {
+	return !errors.Is(err, ErrDeferred)
+}
+
+// backoff returns base * 2^n capped at maxDelay, of which the jitter fraction is scaled by r in [0, 1).
+func backoff(n int, base, maxDelay time.Duration, jitter, r float64) time.Duration {
+	delay := maxDelay
+	if n < 62 && base < maxDelay>>n {
+		delay = base << n
+	}
+	return delay - time.Duration(float64(delay)*jitter*r)
+}
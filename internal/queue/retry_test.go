@@ -0,0 +1,64 @@
+package queue
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ocenb/geo-alerts/internal/config"
+)
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		name   string
+		n      int
+		jitter float64
+		r      float64
+		want   time.Duration
+	}{
+		{"First retry", 0, 0.5, 0, 10 * time.Second},
+		{"Doubles each retry", 3, 0.5, 0, 80 * time.Second},
+		{"Capped at max delay", 10, 0.5, 0, time.Hour},
+		{"Huge retry count doesn't overflow", 100, 0.5, 0, time.Hour},
+		{"Jitter takes off up to its fraction", 3, 0.5, 0.5, 60 * time.Second},
+		{"No jitter", 3, 0, 0.99, 80 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := backoff(tt.n, 10*time.Second, time.Hour, tt.jitter, tt.r); got != tt.want {
+				t.Errorf("backoff() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryDelayFunc(t *testing.T) {
+	delay := NewRetryDelayFunc(config.QueueConfig{RetryBaseDelay: 10 * time.Second, RetryMaxDelay: time.Hour, RetryJitter: 0.5})
+
+	retryAfter := &RetryAfterError{Delay: 2 * time.Minute, Err: errors.New("status: 429")}
+	if got := delay(0, retryAfter, nil); got != 2*time.Minute {
+		t.Errorf("with Retry-After: delay = %v, want %v", got, 2*time.Minute)
+	}
+
+	longRetryAfter := &RetryAfterError{Delay: 30 * 24 * time.Hour, Err: errors.New("status: 503")}
+	if got := delay(0, longRetryAfter, nil); got != time.Hour {
+		t.Errorf("with Retry-After past the max delay: delay = %v, want %v", got, time.Hour)
+	}
+
+	got := delay(2, errors.New("status: 502"), nil)
+	if got <= 20*time.Second || got > 40*time.Second {
+		t.Errorf("without Retry-After: delay = %v, want in (20s, 40s]", got)
+	}
+}
+
+func TestIsFailure(t *testing.T) {
+	deferred := &RetryAfterError{Delay: time.Minute, Err: fmt.Errorf("breaker open: %w", ErrDeferred)}
+	if isFailure(deferred) {
+		t.Error("isFailure(deferred) = true, want false")
+	}
+	if !isFailure(&RetryAfterError{Delay: time.Minute, Err: errors.New("status: 429")}) {
+		t.Error("isFailure(rate limited) = false, want true")
+	}
+}
@@ -9,7 +9,61 @@ import (
 	"github.com/ocenb/geo-alerts/internal/logger/logattr"
 )
 
-const TypeDangerWebhook = "webhook:danger"
+const (
+	TypeDangerWebhook    = "webhook:danger"
+	TypeProximityWebhook = "webhook:proximity"
+	TypePredictedWebhook = "webhook:predicted"
+	TypeCrossingWebhook  = "webhook:crossing"
+	TypePlaceWebhook     = "webhook:place_danger"
+
+	TypeIncidentCreatedWebhook     = "webhook:incident_created"
+	TypeIncidentUpdatedWebhook     = "webhook:incident_updated"
+	TypeIncidentDeactivatedWebhook = "webhook:incident_deactivated"
+
+	TypeDigestWebhook = "webhook:digest"
+	TypeDigestFlush   = "digest:flush"
+
+	TypeWebhookNotification = "notify:webhook"
+	TypeEmailNotification   = "notify:email"
+	TypeSMSNotification     = "notify:sms"
+	TypePushNotification    = "notify:push"
+
+	TypeBroadcastResolve = "broadcast:resolve"
+
+	TypeRetroactiveAlert = "incident:retroactive_alert"
+	TypeCheckinReminder  = "incident:checkin_reminder"
+	TypePlaceAlert       = "incident:place_alert"
+	TypeEscalation       = "incident:escalation"
+	TypeEscalationCancel = "incident:escalation_cancel"
+
+	TypeAlertAckCheck = "alert:ack_check"
+)
+
+// webhookTaskTypes are the task types that carry a WebhookTask.
+var webhookTaskTypes = []string{
+	TypeDangerWebhook, TypeProximityWebhook, TypePredictedWebhook, TypeCrossingWebhook, TypePlaceWebhook,
+	TypeIncidentCreatedWebhook, TypeIncidentUpdatedWebhook, TypeIncidentDeactivatedWebhook, TypeDigestWebhook,
+}
+
+// notificationTaskTypes are the channels duty officers are notified over, in order.
+var notificationTaskTypes = []string{TypeWebhookNotification, TypeEmailNotification, TypeSMSNotification}
+
+// Workers handle the task types of the server.
+type Workers struct {
+	Webhook             asynq.Handler
+	Digest              asynq.Handler
+	WebhookNotification asynq.Handler
+	EmailNotification   asynq.Handler
+	SMSNotification     asynq.Handler
+	PushNotification    asynq.Handler
+	Broadcast           asynq.Handler
+	RetroactiveAlert    asynq.Handler
+	CheckinReminder     asynq.Handler
+	PlaceAlert          asynq.Handler
+	Escalation          asynq.Handler
+	EscalationCancel    asynq.Handler
+	AlertAck            asynq.Handler
+}
 
 type Server struct {
 	log    *slog.Logger
@@ -17,7 +71,7 @@ type Server struct {
 	mux    *asynq.ServeMux
 }
 
-func NewServer(log *slog.Logger, asynqlog asynq.Logger, redisCfg config.RedisConfig, queueCfg config.QueueConfig, webhookWorker asynq.Handler) *Server {
+func NewServer(log *slog.Logger, asynqlog asynq.Logger, redisCfg config.RedisConfig, queueCfg config.QueueConfig, workers Workers) *Server {
 	server := asynq.NewServer(
 		asynq.RedisClientOpt{
 			Addr:         redisCfg.Addr,
@@ -28,12 +82,18 @@ func NewServer(log *slog.Logger, asynqlog asynq.Logger, redisCfg config.RedisCon
 			ReadTimeout:  -1,
 		},
 		asynq.Config{
-			Concurrency: queueCfg.Concurrency,
-			Logger:      asynqlog,
+			Concurrency:    queueCfg.Concurrency,
+			Logger:         asynqlog,
+			RetryDelayFunc: NewRetryDelayFunc(queueCfg),
+			IsFailure:      isFailure,
 			Queues: map[string]int{
-				"default": 1,
+				defaultQueue: 1,
 			},
 			ErrorHandler: asynq.ErrorHandlerFunc(func(ctx context.Context, task *asynq.Task, err error) {
+				if !isFailure(err) {
+					log.Debug("task deferred", slog.String("type", task.Type()), logattr.Err(err))
+					return
+				}
 				log.Error("process task failed",
 					slog.String("type", task.Type()),
 					logattr.Err(err),
@@ -43,7 +103,27 @@ func NewServer(log *slog.Logger, asynqlog asynq.Logger, redisCfg config.RedisCon
 	)
 
 	mux := asynq.NewServeMux()
-	mux.Handle(TypeDangerWebhook, webhookWorker)
+	mux.Handle(TypeDangerWebhook, workers.Webhook)
+	mux.Handle(TypeProximityWebhook, workers.Webhook)
+	mux.Handle(TypePredictedWebhook, workers.Webhook)
+	mux.Handle(TypeCrossingWebhook, workers.Webhook)
+	mux.Handle(TypePlaceWebhook, workers.Webhook)
+	mux.Handle(TypeIncidentCreatedWebhook, workers.Webhook)
+	mux.Handle(TypeIncidentUpdatedWebhook, workers.Webhook)
+	mux.Handle(TypeIncidentDeactivatedWebhook, workers.Webhook)
+	mux.Handle(TypeDigestWebhook, workers.Webhook)
+	mux.Handle(TypeDigestFlush, workers.Digest)
+	mux.Handle(TypeWebhookNotification, workers.WebhookNotification)
+	mux.Handle(TypeEmailNotification, workers.EmailNotification)
+	mux.Handle(TypeSMSNotification, workers.SMSNotification)
+	mux.Handle(TypePushNotification, workers.PushNotification)
+	mux.Handle(TypeBroadcastResolve, workers.Broadcast)
+	mux.Handle(TypeRetroactiveAlert, workers.RetroactiveAlert)
+	mux.Handle(TypeCheckinReminder, workers.CheckinReminder)
+	mux.Handle(TypePlaceAlert, workers.PlaceAlert)
+	mux.Handle(TypeEscalation, workers.Escalation)
+	mux.Handle(TypeEscalationCancel, workers.EscalationCancel)
+	mux.Handle(TypeAlertAckCheck, workers.AlertAck)
 
 	return &Server{
 		log:    log,
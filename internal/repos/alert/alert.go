@@ -0,0 +1,199 @@
+package alert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/ocenb/geo-alerts/internal/domain/errs"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/storage/transactor"
+)
+
+type Repo struct {
+	tm *transactor.Manager
+}
+
+func New(tm *transactor.Manager) *Repo {
+	return &Repo{tm}
+}
+
+const alertColumns = `
+	id,
+	user_id,
+	alert_type,
+	incident_ids,
+	created_at,
+	acknowledged_at,
+	time_to_ack_seconds,
+	followed_up_at,
+	payload
+`
+
+func scanAlert(row pgx.Row) (*models.Alert, error) {
+	var a models.Alert
+	err := row.Scan(
+		&a.ID,
+		&a.UserID,
+		&a.AlertType,
+		&a.IncidentIDs,
+		&a.CreatedAt,
+		&a.AcknowledgedAt,
+		&a.TimeToAck,
+		&a.FollowedUpAt,
+		&a.Payload,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// Record stores the alert. Recording an alert again, as a retried delivery does, keeps the first record.
+func (r *Repo) Record(ctx context.Context, alert *models.Alert) error {
+	q := r.tm.GetQueryEngine(ctx)
+
+	query := `
+		INSERT INTO alerts (id, user_id, alert_type, incident_ids, payload)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (id) DO NOTHING
+	`
+	if _, err := q.Exec(ctx, query, alert.ID, alert.UserID, alert.AlertType, alert.IncidentIDs, alert.Payload); err != nil {
+		return fmt.Errorf("failed to record alert: %w", err)
+	}
+	return nil
+}
+
+// Forget deletes the record of an alert that couldn't be delivered.
+func (r *Repo) Forget(ctx context.Context, id string) error {
+	q := r.tm.GetQueryEngine(ctx)
+
+	if _, err := q.Exec(ctx, `DELETE FROM alerts WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("failed to forget alert: %w", err)
+	}
+	return nil
+}
+
+func (r *Repo) GetByID(ctx context.Context, id string) (*models.Alert, error) {
+	q := r.tm.GetQueryEngine(ctx)
+
+	a, err := scanAlert(q.QueryRow(ctx, `SELECT `+alertColumns+` FROM alerts WHERE id = $1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.ErrAlertNotFound
+		}
+		return nil, fmt.Errorf("failed to get alert: %w", err)
+	}
+
+	return a, nil
+}
+
+// Acknowledge stores the time to acknowledge the alert. Acknowledging it again changes nothing,
+// the first acknowledgement counts.
+func (r *Repo) Acknowledge(ctx context.Context, id string) (*models.Alert, error) {
+	q := r.tm.GetQueryEngine(ctx)
+
+	query := `
+		UPDATE alerts
+		SET acknowledged_at = NOW(),
+			time_to_ack_seconds = EXTRACT(EPOCH FROM NOW() - created_at)
+		WHERE id = $1 AND acknowledged_at IS NULL
+		RETURNING ` + alertColumns
+
+	a, err := scanAlert(q.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return r.GetByID(ctx, id)
+		}
+		return nil, fmt.Errorf("failed to acknowledge alert: %w", err)
+	}
+
+	return a, nil
+}
+
+// ClaimFollowUp marks the alert as followed up if nobody has acknowledged it or followed it up yet,
+// and returns errs.ErrAlertNotFound otherwise.
+func (r *Repo) ClaimFollowUp(ctx context.Context, id string) (*models.Alert, error) {
+	q := r.tm.GetQueryEngine(ctx)
+
+	query := `
+		UPDATE alerts
+		SET followed_up_at = NOW()
+		WHERE id = $1 AND acknowledged_at IS NULL AND followed_up_at IS NULL
+		RETURNING ` + alertColumns
+
+	a, err := scanAlert(q.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.ErrAlertNotFound
+		}
+		return nil, fmt.Errorf("failed to claim alert follow-up: %w", err)
+	}
+
+	return a, nil
+}
+
+// ReleaseFollowUp forgets a follow-up that couldn't be sent, so that the alert can be claimed again.
+func (r *Repo) ReleaseFollowUp(ctx context.Context, id string) error {
+	q := r.tm.GetQueryEngine(ctx)
+
+	if _, err := q.Exec(ctx, `UPDATE alerts SET followed_up_at = NULL WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("failed to release alert follow-up: %w", err)
+	}
+	return nil
+}
+
+// CountAcks returns how many alerts about the incident were sent and acknowledged,
+// and the average time to acknowledge them, nil while none are.
+func (r *Repo) CountAcks(ctx context.Context, incidentID int64) (sent, acknowledged int, avgTimeToAck *float64, err error) {
+	q := r.tm.GetQueryEngine(ctx)
+
+	query := `
+		SELECT
+			COUNT(*),
+			COUNT(acknowledged_at),
+			AVG(time_to_ack_seconds)
+		FROM alerts
+		WHERE incident_ids @> ARRAY[$1::bigint]
+	`
+	if err := q.QueryRow(ctx, query, incidentID).Scan(&sent, &acknowledged, &avgTimeToAck); err != nil {
+		return 0, 0, nil, fmt.Errorf("failed to count alert acknowledgements: %w", err)
+	}
+
+	return sent, acknowledged, avgTimeToAck, nil
+}
+
+// ListUnacknowledged returns the alerts about the incident nobody acknowledged, oldest first.
+func (r *Repo) ListUnacknowledged(ctx context.Context, incidentID int64, limit, offset int) ([]models.Alert, error) {
+	q := r.tm.GetQueryEngine(ctx)
+
+	query := `
+		SELECT ` + alertColumns + `
+		FROM alerts
+		WHERE incident_ids @> ARRAY[$1::bigint] AND acknowledged_at IS NULL
+		ORDER BY created_at, id
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := q.Query(ctx, query, incidentID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list unacknowledged alerts: %w", err)
+	}
+	defer rows.Close()
+
+	alerts := make([]models.Alert, 0)
+
+	for rows.Next() {
+		a, err := scanAlert(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan alert: %w", err)
+		}
+		alerts = append(alerts, *a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return alerts, nil
+}
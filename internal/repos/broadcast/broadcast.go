@@ -0,0 +1,283 @@
+package broadcast
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/ocenb/geo-alerts/internal/domain/errs"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/storage/transactor"
+)
+
+type Repo struct {
+	tm *transactor.Manager
+}
+
+func New(tm *transactor.Manager) *Repo {
+	return &Repo{tm}
+}
+
+const broadcastColumns = `
+	id,
+	incident_id,
+	area,
+	title,
+	message,
+	recency_seconds,
+	status,
+	users,
+	unreachable,
+	total,
+	sent,
+	failed,
+	error,
+	created_at,
+	updated_at,
+	completed_at
+`
+
+func scanBroadcast(row pgx.Row) (*models.Broadcast, error) {
+	var b models.Broadcast
+	err := row.Scan(
+		&b.ID,
+		&b.IncidentID,
+		&b.Area,
+		&b.Title,
+		&b.Message,
+		&b.Recency,
+		&b.Status,
+		&b.Users,
+		&b.Unreachable,
+		&b.Total,
+		&b.Sent,
+		&b.Failed,
+		&b.Error,
+		&b.CreatedAt,
+		&b.UpdatedAt,
+		&b.CompletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+const recipientColumns = `id, broadcast_id, user_id, device_id, token, platform, status, attempts, error, updated_at`
+
+func scanRecipient(row pgx.Row) (*models.BroadcastRecipient, error) {
+	var r models.BroadcastRecipient
+	err := row.Scan(&r.ID, &r.BroadcastID, &r.UserID, &r.DeviceID, &r.Token, &r.Platform, &r.Status, &r.Attempts, &r.Error, &r.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+func (r *Repo) Create(ctx context.Context, params *models.CreateBroadcastParams) (*models.Broadcast, error) {
+	q := r.tm.GetQueryEngine(ctx)
+
+	query := `
+		INSERT INTO broadcasts (incident_id, area, title, message, recency_seconds)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING ` + broadcastColumns
+
+	b, err := scanBroadcast(q.QueryRow(ctx, query,
+		params.IncidentID,
+		params.Area,
+		params.Title,
+		params.Message,
+		int(params.Recency.Seconds()),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create broadcast: %w", err)
+	}
+
+	return b, nil
+}
+
+func (r *Repo) GetByID(ctx context.Context, id int64) (*models.Broadcast, error) {
+	q := r.tm.GetQueryEngine(ctx)
+
+	b, err := scanBroadcast(q.QueryRow(ctx, `SELECT `+broadcastColumns+` FROM broadcasts WHERE id = $1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.ErrBroadcastNotFound
+		}
+		return nil, fmt.Errorf("failed to get broadcast: %w", err)
+	}
+
+	return b, nil
+}
+
+func (r *Repo) List(ctx context.Context, limit, offset int) ([]models.Broadcast, error) {
+	q := r.tm.GetQueryEngine(ctx)
+
+	query := `
+		SELECT ` + broadcastColumns + `
+		FROM broadcasts
+		ORDER BY id DESC
+		LIMIT $1 OFFSET $2
+	`
+	rows, err := q.Query(ctx, query, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list broadcasts: %w", err)
+	}
+	defer rows.Close()
+
+	broadcasts := make([]models.Broadcast, 0)
+
+	for rows.Next() {
+		b, err := scanBroadcast(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan broadcast: %w", err)
+		}
+		broadcasts = append(broadcasts, *b)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return broadcasts, nil
+}
+
+// Fail gives up on a broadcast whose recipients couldn't be looked up.
+func (r *Repo) Fail(ctx context.Context, id int64, reason string) error {
+	q := r.tm.GetQueryEngine(ctx)
+
+	query := `
+		UPDATE broadcasts
+		SET status = 'failed', error = $2, completed_at = NOW()
+		WHERE id = $1 AND status = 'pending'
+	`
+	if _, err := q.Exec(ctx, query, id, reason); err != nil {
+		return fmt.Errorf("failed to fail broadcast: %w", err)
+	}
+
+	return nil
+}
+
+// Resolve saves the recipients found for a pending broadcast and moves it on to sending,
+// or straight to completed if there is nobody to send to. Returns the saved recipients.
+func (r *Repo) Resolve(ctx context.Context, id int64, users, unreachable int, recipients []models.BroadcastRecipient) ([]models.BroadcastRecipient, error) {
+	saved := make([]models.BroadcastRecipient, 0, len(recipients))
+
+	err := r.tm.Run(ctx, func(ctx context.Context) error {
+		q := r.tm.GetQueryEngine(ctx)
+
+		query := `
+			UPDATE broadcasts
+			SET status = CASE WHEN $4 = 0 THEN 'completed' ELSE 'sending' END,
+				users = $2,
+				unreachable = $3,
+				total = $4,
+				completed_at = CASE WHEN $4 = 0 THEN NOW() END
+			WHERE id = $1 AND status = 'pending'
+		`
+		tag, err := q.Exec(ctx, query, id, users, unreachable, len(recipients))
+		if err != nil {
+			return fmt.Errorf("failed to update broadcast: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return errs.ErrBroadcastNotFound
+		}
+		if len(recipients) == 0 {
+			return nil
+		}
+
+		userIDs := make([]string, len(recipients))
+		deviceIDs := make([]int64, len(recipients))
+		tokens := make([]string, len(recipients))
+		platforms := make([]string, len(recipients))
+		for i, rc := range recipients {
+			userIDs[i], deviceIDs[i], tokens[i], platforms[i] = rc.UserID, rc.DeviceID, rc.Token, rc.Platform
+		}
+
+		query = `
+			INSERT INTO broadcast_recipients (broadcast_id, user_id, device_id, token, platform)
+			SELECT $1, * FROM unnest($2::text[], $3::bigint[], $4::text[], $5::text[])
+			RETURNING ` + recipientColumns
+		rows, err := q.Query(ctx, query, id, userIDs, deviceIDs, tokens, platforms)
+		if err != nil {
+			return fmt.Errorf("failed to save broadcast recipients: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			rc, err := scanRecipient(rows)
+			if err != nil {
+				return fmt.Errorf("failed to scan broadcast recipient: %w", err)
+			}
+			saved = append(saved, *rc)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return saved, nil
+}
+
+// ListRecipients returns the recipients of a broadcast, all of them if the limit is 0.
+func (r *Repo) ListRecipients(ctx context.Context, params *models.ListBroadcastRecipientsParams) ([]models.BroadcastRecipient, error) {
+	q := r.tm.GetQueryEngine(ctx)
+
+	query := `
+		SELECT ` + recipientColumns + `
+		FROM broadcast_recipients
+		WHERE broadcast_id = $1 AND ($2 = '' OR status = $2)
+		ORDER BY id
+		LIMIT NULLIF($3, 0) OFFSET $4
+	`
+	rows, err := q.Query(ctx, query, params.BroadcastID, params.Status, params.Limit, params.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list broadcast recipients: %w", err)
+	}
+	defer rows.Close()
+
+	recipients := make([]models.BroadcastRecipient, 0)
+
+	for rows.Next() {
+		rc, err := scanRecipient(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan broadcast recipient: %w", err)
+		}
+		recipients = append(recipients, *rc)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return recipients, nil
+}
+
+// RecordDelivery saves the final outcome of a notification and counts it towards its broadcast,
+// which is completed with the last one. Outcomes of recipients that already have one are ignored.
+func (r *Repo) RecordDelivery(ctx context.Context, recipientID int64, status string, attempts int, reason string) error {
+	q := r.tm.GetQueryEngine(ctx)
+
+	query := `
+		WITH recipient AS (
+			UPDATE broadcast_recipients
+			SET status = $2, attempts = $3, error = $4, updated_at = NOW()
+			WHERE id = $1 AND status = 'pending'
+			RETURNING broadcast_id
+		)
+		UPDATE broadcasts b
+		SET sent = b.sent + CASE WHEN $2 = 'sent' THEN 1 ELSE 0 END,
+			failed = b.failed + CASE WHEN $2 = 'failed' THEN 1 ELSE 0 END,
+			status = CASE WHEN b.sent + b.failed + 1 >= b.total THEN 'completed' ELSE b.status END,
+			completed_at = CASE WHEN b.sent + b.failed + 1 >= b.total THEN NOW() ELSE b.completed_at END
+		FROM recipient
+		WHERE b.id = recipient.broadcast_id
+	`
+	if _, err := q.Exec(ctx, query, recipientID, status, attempts, reason); err != nil {
+		return fmt.Errorf("failed to record broadcast delivery: %w", err)
+	}
+
+	return nil
+}
@@ -20,7 +20,10 @@ func New(cfg config.CacheConfig, client *redis.Client) *Repo {
 	return &Repo{cfg, client}
 }
 
-const KeyActiveIncidents = "incidents:active"
+const (
+	KeyActiveIncidents    = "incidents:active"
+	KeyLastLocationPrefix = "users:last_location:"
+)
 
 func (r *Repo) InvalidateActiveIncidents(ctx context.Context) error {
 	return r.client.Del(ctx, KeyActiveIncidents).Err()
@@ -49,3 +52,51 @@ func (r *Repo) SetActiveIncidents(ctx context.Context, incidents []models.Incide
 	}
 	return r.client.Set(ctx, KeyActiveIncidents, data, r.cfg.IncidentsTTL).Err()
 }
+
+// lastLocation is a stored location with its recording time in a form the swap script can compare.
+type lastLocation struct {
+	models.UserLocation
+	RecordedAtMs int64 `json:"recorded_at_ms"`
+}
+
+// swapLastLocation replaces the stored location (KEYS[1]) with ARGV[1] unless the stored one was recorded later
+// than ARGV[2] milliseconds, and returns the stored one either way. ARGV[3] is the TTL in milliseconds.
+// Locations stored without the time in milliseconds are always replaced.
+var swapLastLocation = redis.NewScript(`
+	local prev = redis.call('GET', KEYS[1])
+	if prev then
+		local ok, decoded = pcall(cjson.decode, prev)
+		if ok and type(decoded) == 'table' and tonumber(decoded.recorded_at_ms) and tonumber(decoded.recorded_at_ms) > tonumber(ARGV[2]) then
+			return prev
+		end
+	end
+	redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[3])
+	return prev
+`)
+
+// SwapLastLocation stores the user's location and returns the previous one in a single atomic call.
+// A location recorded before the stored one doesn't replace it, so delayed checks can't move the user back.
+func (r *Repo) SwapLastLocation(ctx context.Context, userID string, loc *models.UserLocation) (*models.UserLocation, error) {
+	recordedAtMs := loc.RecordedAt.UnixMilli()
+	data, err := json.Marshal(lastLocation{UserLocation: *loc, RecordedAtMs: recordedAtMs})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal location: %w", err)
+	}
+
+	val, err := swapLastLocation.Run(ctx, r.client,
+		[]string{KeyLastLocationPrefix + userID},
+		data, recordedAtMs, r.cfg.LastLocationTTL.Milliseconds(),
+	).Text()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, errs.ErrCacheMiss
+		}
+		return nil, err
+	}
+
+	var prev models.UserLocation
+	if err := json.Unmarshal([]byte(val), &prev); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal location: %w", err)
+	}
+	return &prev, nil
+}
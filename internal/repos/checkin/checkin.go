@@ -0,0 +1,143 @@
+package checkin
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/storage/transactor"
+)
+
+type Repo struct {
+	tm *transactor.Manager
+}
+
+func New(tm *transactor.Manager) *Repo {
+	return &Repo{tm}
+}
+
+// Upsert saves the user's status for the incident, replacing an earlier one.
+func (r *Repo) Upsert(ctx context.Context, params *models.CheckinParams) (*models.Checkin, error) {
+	q := r.tm.GetQueryEngine(ctx)
+
+	query := `
+		INSERT INTO incident_checkins (incident_id, user_id, status, note)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (incident_id, user_id) DO UPDATE
+		SET status = EXCLUDED.status, note = EXCLUDED.note
+		RETURNING incident_id, user_id, status, note, created_at, updated_at
+	`
+	var c models.Checkin
+	err := q.QueryRow(ctx, query, params.IncidentID, params.UserID, params.Status, params.Note).Scan(
+		&c.IncidentID,
+		&c.UserID,
+		&c.Status,
+		&c.Note,
+		&c.CreatedAt,
+		&c.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save check-in: %w", err)
+	}
+
+	return &c, nil
+}
+
+// CountByStatus counts the check-ins of the incident. Unaccounted is left to CountUnaccounted.
+func (r *Repo) CountByStatus(ctx context.Context, incidentID int64) (models.CheckinCounts, error) {
+	q := r.tm.GetQueryEngine(ctx)
+
+	query := `
+		SELECT
+			COUNT(*) FILTER (WHERE status = 'safe'),
+			COUNT(*) FILTER (WHERE status = 'need_help'),
+			COUNT(*) FILTER (WHERE status = 'evacuated')
+		FROM incident_checkins
+		WHERE incident_id = $1
+	`
+	var counts models.CheckinCounts
+	if err := q.QueryRow(ctx, query, incidentID).Scan(&counts.Safe, &counts.NeedHelp, &counts.Evacuated); err != nil {
+		return models.CheckinCounts{}, fmt.Errorf("failed to count check-ins: %w", err)
+	}
+
+	return counts, nil
+}
+
+// seenInZone selects, for every user checked inside the zone of incident $1 within the last $2 seconds,
+// the last such check. $3 is true to count checks whose accuracy circle only overlaps the zone,
+// the same as the incident stats.
+const seenInZone = `
+	SELECT DISTINCT ON (l.user_id) l.user_id, l.location, l.created_at
+	FROM incidents i
+	JOIN location_checks l ON
+		ST_DWithin(i.location::geography, l.location::geography, i.radius_meters + COALESCE(l.accuracy_meters, 0))
+		AND ($3 OR ST_Distance(i.location::geography, l.location::geography) + COALESCE(l.accuracy_meters, 0) <= i.radius_meters)
+		AND l.created_at >= NOW() - ($2 * INTERVAL '1 second')
+	WHERE i.id = $1
+	ORDER BY l.user_id, l.created_at DESC
+`
+
+// CountUnaccounted returns how many users were seen in the zone and how many of them haven't checked in.
+func (r *Repo) CountUnaccounted(ctx context.Context, params *models.ListUnaccountedParams) (int, int, error) {
+	q := r.tm.GetQueryEngine(ctx)
+
+	query := `
+		WITH seen AS (` + seenInZone + `)
+		SELECT
+			COUNT(*),
+			COUNT(*) FILTER (WHERE NOT EXISTS (
+				SELECT 1 FROM incident_checkins c WHERE c.incident_id = $1 AND c.user_id = seen.user_id
+			))
+		FROM seen
+	`
+	var seen, unaccounted int
+	err := q.QueryRow(ctx, query, params.IncidentID, params.Window.Seconds(), params.CountPossiblyInside).Scan(&seen, &unaccounted)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to count unaccounted users: %w", err)
+	}
+
+	return seen, unaccounted, nil
+}
+
+// ListUnaccounted returns the users seen in the zone who haven't checked in, most recently seen first.
+func (r *Repo) ListUnaccounted(ctx context.Context, params *models.ListUnaccountedParams) ([]models.UnaccountedUser, error) {
+	q := r.tm.GetQueryEngine(ctx)
+
+	query := `
+		WITH seen AS (` + seenInZone + `)
+		SELECT user_id, ST_Y(location), ST_X(location), created_at
+		FROM seen
+		WHERE NOT EXISTS (
+			SELECT 1 FROM incident_checkins c WHERE c.incident_id = $1 AND c.user_id = seen.user_id
+		)
+		ORDER BY created_at DESC, user_id
+		LIMIT NULLIF($4, 0) OFFSET $5
+	`
+	rows, err := q.Query(ctx, query,
+		params.IncidentID,
+		params.Window.Seconds(),
+		params.CountPossiblyInside,
+		params.Limit,
+		params.Offset,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list unaccounted users: %w", err)
+	}
+	defer rows.Close()
+
+	users := make([]models.UnaccountedUser, 0)
+
+	for rows.Next() {
+		var u models.UnaccountedUser
+		if err := rows.Scan(&u.UserID, &u.Latitude, &u.Longitude, &u.LastSeenAt); err != nil {
+			return nil, fmt.Errorf("failed to scan unaccounted user: %w", err)
+		}
+		users = append(users, u)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return users, nil
+}
@@ -0,0 +1,112 @@
+package device
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/ocenb/geo-alerts/internal/domain/errs"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/storage/transactor"
+)
+
+type Repo struct {
+	tm *transactor.Manager
+}
+
+func New(tm *transactor.Manager) *Repo {
+	return &Repo{tm}
+}
+
+const deviceColumns = `id, user_id, token, platform, locale, created_at, updated_at`
+
+func scanDevice(row pgx.Row) (*models.Device, error) {
+	var d models.Device
+	if err := row.Scan(&d.ID, &d.UserID, &d.Token, &d.Platform, &d.Locale, &d.CreatedAt, &d.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// Register saves the device or, if its token is known already, updates it. A token belongs
+// to one app install, so registering it for another user takes it away from the previous one.
+func (r *Repo) Register(ctx context.Context, params *models.RegisterDeviceParams) (*models.Device, error) {
+	q := r.tm.GetQueryEngine(ctx)
+
+	query := `
+		INSERT INTO devices (user_id, token, platform, locale)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (token) DO UPDATE
+		SET user_id = EXCLUDED.user_id,
+			platform = EXCLUDED.platform,
+			locale = EXCLUDED.locale
+		RETURNING ` + deviceColumns
+
+	d, err := scanDevice(q.QueryRow(ctx, query, params.UserID, params.Token, params.Platform, params.Locale))
+	if err != nil {
+		return nil, fmt.Errorf("failed to register device: %w", err)
+	}
+
+	return d, nil
+}
+
+func (r *Repo) Unregister(ctx context.Context, userID, token string) error {
+	q := r.tm.GetQueryEngine(ctx)
+
+	tag, err := q.Exec(ctx, `DELETE FROM devices WHERE user_id = $1 AND token = $2`, userID, token)
+	if err != nil {
+		return fmt.Errorf("failed to unregister device: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return errs.ErrDeviceNotFound
+	}
+
+	return nil
+}
+
+// DeleteByToken drops a token the push service reported as no longer valid.
+func (r *Repo) DeleteByToken(ctx context.Context, token string) error {
+	q := r.tm.GetQueryEngine(ctx)
+
+	if _, err := q.Exec(ctx, `DELETE FROM devices WHERE token = $1`, token); err != nil {
+		return fmt.Errorf("failed to delete device: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repo) ListByUser(ctx context.Context, userID string) ([]models.Device, error) {
+	return r.query(ctx, `SELECT `+deviceColumns+` FROM devices WHERE user_id = $1 ORDER BY id`, userID)
+}
+
+// ListByUsers returns the devices of all the users, ordered by user.
+func (r *Repo) ListByUsers(ctx context.Context, userIDs []string) ([]models.Device, error) {
+	return r.query(ctx, `SELECT `+deviceColumns+` FROM devices WHERE user_id = ANY($1) ORDER BY user_id, id`, userIDs)
+}
+
+func (r *Repo) query(ctx context.Context, query string, args ...any) ([]models.Device, error) {
+	q := r.tm.GetQueryEngine(ctx)
+
+	rows, err := q.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list devices: %w", err)
+	}
+	defer rows.Close()
+
+	devices := make([]models.Device, 0)
+
+	for rows.Next() {
+		d, err := scanDevice(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan device: %w", err)
+		}
+		devices = append(devices, *d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return devices, nil
+}
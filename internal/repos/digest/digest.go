@@ -0,0 +1,99 @@
+package digest
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Repo buffers alerts of digest subscribers until they are flushed as one delivery.
+type Repo struct {
+	client *redis.Client
+}
+
+func New(client *redis.Client) *Repo {
+	return &Repo{client}
+}
+
+const keyPrefix = "webhooks:digest:"
+
+func bufferKey(subID int64) string {
+	return keyPrefix + strconv.FormatInt(subID, 10)
+}
+
+func batchKey(subID int64) string {
+	return bufferKey(subID) + ":batch"
+}
+
+func batchIDKey(subID int64) string {
+	return bufferKey(subID) + ":batch_id"
+}
+
+// Append adds an alert to the subscriber's buffer and returns how many alerts it holds now.
+func (r *Repo) Append(ctx context.Context, subID int64, alert []byte) (int64, error) {
+	n, err := r.client.RPush(ctx, bufferKey(subID), alert).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to append digest alert: %w", err)
+	}
+	return n, nil
+}
+
+// takeScript moves up to ARGV[1] alerts from the buffer into a batch named ARGV[2]. A batch that
+// wasn't acknowledged, because its flush failed half way, is returned again instead of a new one.
+var takeScript = redis.NewScript(`
+local id = redis.call("GET", KEYS[3])
+if id then
+	return {id, redis.call("LRANGE", KEYS[2], 0, -1), redis.call("LLEN", KEYS[1])}
+end
+local alerts = redis.call("LRANGE", KEYS[1], 0, tonumber(ARGV[1]) - 1)
+if #alerts == 0 then
+	return {"", {}, 0}
+end
+redis.call("LTRIM", KEYS[1], #alerts, -1)
+redis.call("RPUSH", KEYS[2], unpack(alerts))
+redis.call("SET", KEYS[3], ARGV[2])
+return {ARGV[2], alerts, redis.call("LLEN", KEYS[1])}
+`)
+
+// Take starts a batch of up to limit alerts, which stays stored until Ack. Returns the batch ID,
+// which is empty if there was nothing to take, its alerts and how many are left in the buffer.
+func (r *Repo) Take(ctx context.Context, subID int64, limit int, newBatchID string) (string, [][]byte, int64, error) {
+	keys := []string{bufferKey(subID), batchKey(subID), batchIDKey(subID)}
+	res, err := takeScript.Run(ctx, r.client, keys, limit, newBatchID).Slice()
+	if err != nil {
+		return "", nil, 0, fmt.Errorf("failed to take digest batch: %w", err)
+	}
+	if len(res) != 3 {
+		return "", nil, 0, fmt.Errorf("unexpected digest batch reply: %v", res)
+	}
+
+	batchID, _ := res[0].(string)
+	items, _ := res[1].([]any)
+	remaining, _ := res[2].(int64)
+
+	alerts := make([][]byte, 0, len(items))
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			alerts = append(alerts, []byte(s))
+		}
+	}
+	return batchID, alerts, remaining, nil
+}
+
+// Ack drops a batch once it has been handed over for delivery.
+func (r *Repo) Ack(ctx context.Context, subID int64) error {
+	if err := r.client.Del(ctx, batchKey(subID), batchIDKey(subID)).Err(); err != nil {
+		return fmt.Errorf("failed to ack digest batch: %w", err)
+	}
+	return nil
+}
+
+// Discard drops everything buffered for a subscriber.
+func (r *Repo) Discard(ctx context.Context, subID int64) error {
+	if err := r.client.Del(ctx, bufferKey(subID), batchKey(subID), batchIDKey(subID)).Err(); err != nil {
+		return fmt.Errorf("failed to discard digest: %w", err)
+	}
+	return nil
+}
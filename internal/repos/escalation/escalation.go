@@ -0,0 +1,213 @@
+package escalation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/ocenb/geo-alerts/internal/domain/errs"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/storage/transactor"
+)
+
+type Repo struct {
+	tm *transactor.Manager
+}
+
+func New(tm *transactor.Manager) *Repo {
+	return &Repo{tm}
+}
+
+const policyColumns = `severity, realert_after_seconds, escalate_after_seconds, created_at, updated_at`
+
+func scanPolicy(row pgx.Row) (*models.EscalationPolicy, error) {
+	var p models.EscalationPolicy
+	if err := row.Scan(&p.Severity, &p.RealertAfter, &p.EscalateAfter, &p.CreatedAt, &p.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *Repo) ListPolicies(ctx context.Context) ([]models.EscalationPolicy, error) {
+	q := r.tm.GetQueryEngine(ctx)
+
+	rows, err := q.Query(ctx, `SELECT `+policyColumns+` FROM escalation_policies ORDER BY severity`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list escalation policies: %w", err)
+	}
+	defer rows.Close()
+
+	policies := make([]models.EscalationPolicy, 0)
+
+	for rows.Next() {
+		p, err := scanPolicy(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan escalation policy: %w", err)
+		}
+		policies = append(policies, *p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return policies, nil
+}
+
+func (r *Repo) UpsertPolicy(ctx context.Context, params *models.UpdateEscalationPolicyParams) (*models.EscalationPolicy, error) {
+	q := r.tm.GetQueryEngine(ctx)
+
+	query := `
+		INSERT INTO escalation_policies (severity, realert_after_seconds, escalate_after_seconds)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (severity) DO UPDATE
+		SET realert_after_seconds = EXCLUDED.realert_after_seconds,
+			escalate_after_seconds = EXCLUDED.escalate_after_seconds
+		RETURNING ` + policyColumns
+
+	p, err := scanPolicy(q.QueryRow(ctx, query, params.Severity, params.RealertAfter, params.EscalateAfter))
+	if err != nil {
+		return nil, fmt.Errorf("failed to save escalation policy: %w", err)
+	}
+
+	return p, nil
+}
+
+func (r *Repo) DeletePolicy(ctx context.Context, severity int) error {
+	q := r.tm.GetQueryEngine(ctx)
+
+	tag, err := q.Exec(ctx, `DELETE FROM escalation_policies WHERE severity = $1`, severity)
+	if err != nil {
+		return fmt.Errorf("failed to delete escalation policy: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return errs.ErrEscalationPolicyNotFound
+	}
+
+	return nil
+}
+
+const dwellColumns = `
+	user_id,
+	incident_id,
+	entered_at,
+	last_seen_at,
+	ST_Y(location::geometry),
+	ST_X(location::geometry)
+`
+
+func scanDwell(row pgx.Row) (*models.ZoneDwell, error) {
+	var d models.ZoneDwell
+	if err := row.Scan(&d.UserID, &d.IncidentID, &d.EnteredAt, &d.LastSeenAt, &d.Latitude, &d.Longitude); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func collectDwells(rows pgx.Rows) ([]models.ZoneDwell, error) {
+	defer rows.Close()
+
+	dwells := make([]models.ZoneDwell, 0)
+
+	for rows.Next() {
+		d, err := scanDwell(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan zone dwell: %w", err)
+		}
+		dwells = append(dwells, *d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return dwells, nil
+}
+
+// TrackDwells records that the check found the user inside the zones of the incidents. It returns the dwells
+// the check starts, in zones the user wasn't inside at the previous check, and the ones it ends,
+// in zones the user was inside and isn't anymore.
+func (r *Repo) TrackDwells(ctx context.Context, userID string, latitude, longitude float64, incidentIDs []int64, at time.Time) (entered, exited []models.ZoneDwell, err error) {
+	if incidentIDs == nil {
+		// A NULL array would match no dwell to end.
+		incidentIDs = []int64{}
+	}
+
+	err = r.tm.Run(ctx, func(ctx context.Context) error {
+		q := r.tm.GetQueryEngine(ctx)
+
+		rows, err := q.Query(ctx, `
+			DELETE FROM zone_dwells
+			WHERE user_id = $1 AND NOT (incident_id = ANY($2))
+			RETURNING `+dwellColumns, userID, incidentIDs)
+		if err != nil {
+			return fmt.Errorf("failed to end zone dwells: %w", err)
+		}
+		if exited, err = collectDwells(rows); err != nil {
+			return err
+		}
+
+		if len(incidentIDs) == 0 {
+			entered = make([]models.ZoneDwell, 0)
+			return nil
+		}
+
+		_, err = q.Exec(ctx, `
+			UPDATE zone_dwells
+			SET last_seen_at = $3,
+				location = ST_SetSRID(ST_MakePoint($4, $5), 4326)
+			WHERE user_id = $1 AND incident_id = ANY($2) AND last_seen_at < $3
+		`, userID, incidentIDs, at, longitude, latitude)
+		if err != nil {
+			return fmt.Errorf("failed to update zone dwells: %w", err)
+		}
+
+		rows, err = q.Query(ctx, `
+			INSERT INTO zone_dwells (user_id, incident_id, entered_at, last_seen_at, location)
+			SELECT $1, id, $3, $3, ST_SetSRID(ST_MakePoint($4, $5), 4326)
+			FROM unnest($2::bigint[]) AS id
+			ON CONFLICT DO NOTHING
+			RETURNING `+dwellColumns, userID, incidentIDs, at, longitude, latitude)
+		if err != nil {
+			return fmt.Errorf("failed to start zone dwells: %w", err)
+		}
+		entered, err = collectDwells(rows)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return entered, exited, nil
+}
+
+func (r *Repo) GetDwell(ctx context.Context, userID string, incidentID int64) (*models.ZoneDwell, error) {
+	q := r.tm.GetQueryEngine(ctx)
+
+	query := `SELECT ` + dwellColumns + ` FROM zone_dwells WHERE user_id = $1 AND incident_id = $2`
+
+	d, err := scanDwell(q.QueryRow(ctx, query, userID, incidentID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.ErrDwellNotFound
+		}
+		return nil, fmt.Errorf("failed to get zone dwell: %w", err)
+	}
+
+	return d, nil
+}
+
+// EndIncidentDwells forgets everyone inside the zone of the incident, e.g. once it is deactivated.
+func (r *Repo) EndIncidentDwells(ctx context.Context, incidentID int64) ([]models.ZoneDwell, error) {
+	q := r.tm.GetQueryEngine(ctx)
+
+	rows, err := q.Query(ctx, `DELETE FROM zone_dwells WHERE incident_id = $1 RETURNING `+dwellColumns, incidentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to end zone dwells: %w", err)
+	}
+
+	return collectDwells(rows)
+}
@@ -0,0 +1,305 @@
+package guardian
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/ocenb/geo-alerts/internal/domain/errs"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/storage/transactor"
+)
+
+type Repo struct {
+	tm *transactor.Manager
+}
+
+func New(tm *transactor.Manager) *Repo {
+	return &Repo{tm}
+}
+
+const groupColumns = `id, name, created_at, updated_at`
+
+func scanGroup(row pgx.Row) (*models.GuardianGroup, error) {
+	g := models.GuardianGroup{Guardians: []models.Guardian{}, Members: []models.GroupMember{}}
+	if err := row.Scan(&g.ID, &g.Name, &g.CreatedAt, &g.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &g, nil
+}
+
+const guardianColumns = `id, group_id, name, channel, address`
+
+func scanGuardian(row pgx.Row) (*models.Guardian, error) {
+	var g models.Guardian
+	if err := row.Scan(&g.ID, &g.GroupID, &g.Name, &g.Channel, &g.Address); err != nil {
+		return nil, err
+	}
+	return &g, nil
+}
+
+const memberColumns = `group_id, user_id, status, consented_at, created_at, updated_at`
+
+func scanMember(row pgx.Row) (*models.GroupMember, error) {
+	var m models.GroupMember
+	if err := row.Scan(&m.GroupID, &m.UserID, &m.Status, &m.ConsentedAt, &m.CreatedAt, &m.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// Create saves the group with its guardians and members, who are pending until they consent.
+func (r *Repo) Create(ctx context.Context, params *models.CreateGuardianGroupParams) (*models.GuardianGroup, error) {
+	var group *models.GuardianGroup
+
+	err := r.tm.Run(ctx, func(ctx context.Context) error {
+		q := r.tm.GetQueryEngine(ctx)
+
+		g, err := scanGroup(q.QueryRow(ctx, `INSERT INTO guardian_groups (name) VALUES ($1) RETURNING `+groupColumns, params.Name))
+		if err != nil {
+			return fmt.Errorf("failed to create guardian group: %w", err)
+		}
+
+		names := make([]string, len(params.Guardians))
+		channels := make([]string, len(params.Guardians))
+		addresses := make([]string, len(params.Guardians))
+		for i, gp := range params.Guardians {
+			names[i], channels[i], addresses[i] = gp.Name, gp.Channel, gp.Address
+		}
+
+		query := `
+			INSERT INTO guardians (group_id, name, channel, address)
+			SELECT $1, * FROM unnest($2::text[], $3::text[], $4::text[])
+			RETURNING ` + guardianColumns
+		g.Guardians, err = r.queryGuardians(ctx, query, g.ID, names, channels, addresses)
+		if err != nil {
+			return err
+		}
+
+		if err := r.AddMembers(ctx, g.ID, params.UserIDs); err != nil {
+			return err
+		}
+		if g.Members, err = r.listMembers(ctx, g.ID); err != nil {
+			return err
+		}
+
+		group = g
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+func (r *Repo) GetByID(ctx context.Context, id int64) (*models.GuardianGroup, error) {
+	q := r.tm.GetQueryEngine(ctx)
+
+	g, err := scanGroup(q.QueryRow(ctx, `SELECT `+groupColumns+` FROM guardian_groups WHERE id = $1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.ErrGuardianGroupNotFound
+		}
+		return nil, fmt.Errorf("failed to get guardian group: %w", err)
+	}
+
+	g.Guardians, err = r.queryGuardians(ctx, `SELECT `+guardianColumns+` FROM guardians WHERE group_id = $1 ORDER BY id`, id)
+	if err != nil {
+		return nil, err
+	}
+	if g.Members, err = r.listMembers(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+// List returns the groups with their guardians, leaving members out, as there may be many.
+func (r *Repo) List(ctx context.Context, limit, offset int) ([]models.GuardianGroup, error) {
+	q := r.tm.GetQueryEngine(ctx)
+
+	rows, err := q.Query(ctx, `SELECT `+groupColumns+` FROM guardian_groups ORDER BY id DESC LIMIT $1 OFFSET $2`, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list guardian groups: %w", err)
+	}
+	defer rows.Close()
+
+	groups := make([]models.GuardianGroup, 0)
+	ids := make([]int64, 0)
+
+	for rows.Next() {
+		g, err := scanGroup(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan guardian group: %w", err)
+		}
+		groups = append(groups, *g)
+		ids = append(ids, g.ID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	guardians, err := r.queryGuardians(ctx, `SELECT `+guardianColumns+` FROM guardians WHERE group_id = ANY($1) ORDER BY id`, ids)
+	if err != nil {
+		return nil, err
+	}
+	byGroup := make(map[int64]int, len(groups))
+	for i, g := range groups {
+		byGroup[g.ID] = i
+	}
+	for _, g := range guardians {
+		i := byGroup[g.GroupID]
+		groups[i].Guardians = append(groups[i].Guardians, g)
+	}
+
+	return groups, nil
+}
+
+func (r *Repo) Delete(ctx context.Context, id int64) error {
+	q := r.tm.GetQueryEngine(ctx)
+
+	tag, err := q.Exec(ctx, `DELETE FROM guardian_groups WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete guardian group: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return errs.ErrGuardianGroupNotFound
+	}
+
+	return nil
+}
+
+// AddMembers adds the users to the group as pending. Users already in it keep their consent,
+// so adding someone who revoked it again doesn't opt them back in.
+func (r *Repo) AddMembers(ctx context.Context, groupID int64, userIDs []string) error {
+	q := r.tm.GetQueryEngine(ctx)
+
+	query := `
+		INSERT INTO guardian_group_members (group_id, user_id)
+		SELECT g.id, u.user_id
+		FROM guardian_groups g, unnest($2::text[]) AS u (user_id)
+		WHERE g.id = $1
+		ON CONFLICT (group_id, user_id) DO NOTHING
+	`
+	if _, err := q.Exec(ctx, query, groupID, userIDs); err != nil {
+		return fmt.Errorf("failed to add group members: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repo) RemoveMember(ctx context.Context, groupID int64, userID string) error {
+	q := r.tm.GetQueryEngine(ctx)
+
+	tag, err := q.Exec(ctx, `DELETE FROM guardian_group_members WHERE group_id = $1 AND user_id = $2`, groupID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to remove group member: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return errs.ErrGroupMemberNotFound
+	}
+
+	return nil
+}
+
+// SetConsent records whether the member agrees to their guardians being alerted.
+func (r *Repo) SetConsent(ctx context.Context, groupID int64, userID string, consent bool) (*models.GroupMember, error) {
+	q := r.tm.GetQueryEngine(ctx)
+
+	query := `
+		UPDATE guardian_group_members
+		SET status = CASE WHEN $3 THEN 'consented' ELSE 'revoked' END,
+			consented_at = CASE WHEN $3 THEN COALESCE(consented_at, NOW()) END
+		WHERE group_id = $1 AND user_id = $2
+		RETURNING ` + memberColumns
+
+	m, err := scanMember(q.QueryRow(ctx, query, groupID, userID, consent))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.ErrGroupMemberNotFound
+		}
+		return nil, fmt.Errorf("failed to set consent: %w", err)
+	}
+
+	return m, nil
+}
+
+// ListMemberships returns the groups the user was added to, whatever their consent.
+func (r *Repo) ListMemberships(ctx context.Context, userID string) ([]models.GroupMember, error) {
+	return r.queryMembers(ctx, `SELECT `+memberColumns+` FROM guardian_group_members WHERE user_id = $1 ORDER BY group_id`, userID)
+}
+
+// ListGuardiansOfMember returns the guardians of the groups in which the user consented to alerts.
+func (r *Repo) ListGuardiansOfMember(ctx context.Context, userID string) ([]models.Guardian, error) {
+	query := `
+		SELECT ` + guardianColumns + `
+		FROM guardians
+		WHERE group_id IN (
+			SELECT group_id FROM guardian_group_members
+			WHERE user_id = $1 AND status = 'consented'
+		)
+		ORDER BY id
+	`
+	return r.queryGuardians(ctx, query, userID)
+}
+
+func (r *Repo) listMembers(ctx context.Context, groupID int64) ([]models.GroupMember, error) {
+	return r.queryMembers(ctx, `SELECT `+memberColumns+` FROM guardian_group_members WHERE group_id = $1 ORDER BY user_id`, groupID)
+}
+
+func (r *Repo) queryGuardians(ctx context.Context, query string, args ...any) ([]models.Guardian, error) {
+	q := r.tm.GetQueryEngine(ctx)
+
+	rows, err := q.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list guardians: %w", err)
+	}
+	defer rows.Close()
+
+	guardians := make([]models.Guardian, 0)
+
+	for rows.Next() {
+		g, err := scanGuardian(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan guardian: %w", err)
+		}
+		guardians = append(guardians, *g)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return guardians, nil
+}
+
+func (r *Repo) queryMembers(ctx context.Context, query string, args ...any) ([]models.GroupMember, error) {
+	q := r.tm.GetQueryEngine(ctx)
+
+	rows, err := q.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list group members: %w", err)
+	}
+	defer rows.Close()
+
+	members := make([]models.GroupMember, 0)
+
+	for rows.Next() {
+		m, err := scanMember(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan group member: %w", err)
+		}
+		members = append(members, *m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return members, nil
+}
@@ -29,6 +29,9 @@ func (r *Repo) GetByID(ctx context.Context, id int64) (*models.Incident, error)
 			ST_Y(location::geometry) as latitude,
 			ST_X(location::geometry) as longitude,
 			radius_meters,
+			approach_buffer_meters,
+			severity,
+			category,
 			is_active, 
 			created_at, 
 			updated_at
@@ -43,6 +46,9 @@ func (r *Repo) GetByID(ctx context.Context, id int64) (*models.Incident, error)
 		&inc.Latitude,
 		&inc.Longitude,
 		&inc.Radius,
+		&inc.ApproachBuffer,
+		&inc.Severity,
+		&inc.Category,
 		&inc.IsActive,
 		&inc.CreatedAt,
 		&inc.UpdatedAt,
@@ -62,10 +68,13 @@ func (r *Repo) Create(ctx context.Context, params *models.CreateIncidentParams)
 	q := r.tm.GetQueryEngine(ctx)
 
 	query := `
-		INSERT INTO incidents (location, radius_meters, is_active)
+		INSERT INTO incidents (location, radius_meters, approach_buffer_meters, severity, category, is_active)
 		SELECT 
 			ST_SetSRID(ST_MakePoint($1, $2), 4326),
 			$3, 
+			$4,
+			$5,
+			$6,
 			TRUE
 		WHERE NOT EXISTS (
 			SELECT 1 FROM incidents 
@@ -80,6 +89,9 @@ func (r *Repo) Create(ctx context.Context, params *models.CreateIncidentParams)
 			ST_Y(location::geometry),
 			ST_X(location::geometry),
 			radius_meters, 
+			approach_buffer_meters,
+			severity,
+			category,
 			is_active, 
 			created_at, 
 			updated_at
@@ -91,11 +103,17 @@ func (r *Repo) Create(ctx context.Context, params *models.CreateIncidentParams)
 		params.Longitude,
 		params.Latitude,
 		params.Radius,
+		params.ApproachBuffer,
+		params.Severity,
+		params.Category,
 	).Scan(
 		&created.ID,
 		&created.Latitude,
 		&created.Longitude,
 		&created.Radius,
+		&created.ApproachBuffer,
+		&created.Severity,
+		&created.Category,
 		&created.IsActive,
 		&created.CreatedAt,
 		&created.UpdatedAt,
@@ -111,50 +129,101 @@ func (r *Repo) Create(ctx context.Context, params *models.CreateIncidentParams)
 	return created, nil
 }
 
-func (r *Repo) Update(ctx context.Context, params *models.UpdateIncidentParams) (*models.Incident, error) {
+// Update returns the incident as updated along with the state it replaced, read under the row lock
+// taken by the update, so concurrent updates each see the state the other left.
+func (r *Repo) Update(ctx context.Context, params *models.UpdateIncidentParams) (updated, previous *models.Incident, err error) {
 	q := r.tm.GetQueryEngine(ctx)
 
 	query := `
-		UPDATE incidents
+		WITH previous AS (
+			SELECT 
+				id, 
+				ST_Y(location::geometry) as latitude,
+				ST_X(location::geometry) as longitude,
+				radius_meters,
+				approach_buffer_meters,
+				severity,
+				category,
+				is_active, 
+				created_at, 
+				updated_at
+			FROM incidents
+			WHERE id = $4
+			FOR UPDATE
+		)
+		UPDATE incidents i
 		SET 
 			location = ST_SetSRID(ST_MakePoint($1, $2), 4326),
 			radius_meters = $3,
+			approach_buffer_meters = CASE WHEN $8 THEN NULL ELSE COALESCE($5, i.approach_buffer_meters) END,
+			severity = COALESCE($6, i.severity),
+			category = COALESCE($7, i.category),
 			updated_at = NOW()
-		WHERE id = $4
+		FROM previous p
+		WHERE i.id = p.id
 		  AND NOT EXISTS (
-			SELECT 1 FROM incidents 
-			WHERE id != $4
+			SELECT 1 FROM incidents other
+			WHERE other.id != $4
 			AND ST_DWithin(
-				location::geography, 
+				other.location::geography, 
 				ST_SetSRID(ST_MakePoint($1, $2), 4326)::geography, 
 				1.0 
 			)
 		  )
 		RETURNING 
-			id, 
-			ST_Y(location::geometry),
-			ST_X(location::geometry),
-			radius_meters, 
-			is_active, 
-			created_at, 
-			updated_at
+			i.id, 
+			ST_Y(i.location::geometry),
+			ST_X(i.location::geometry),
+			i.radius_meters, 
+			i.approach_buffer_meters,
+			i.severity,
+			i.category,
+			i.is_active, 
+			i.created_at, 
+			i.updated_at,
+			p.latitude,
+			p.longitude,
+			p.radius_meters,
+			p.approach_buffer_meters,
+			p.severity,
+			p.category,
+			p.is_active,
+			p.created_at,
+			p.updated_at
 	`
 
-	updated := &models.Incident{}
+	updated = &models.Incident{}
+	previous = &models.Incident{}
 
-	err := q.QueryRow(ctx, query,
+	err = q.QueryRow(ctx, query,
 		params.Longitude,
 		params.Latitude,
 		params.Radius,
 		params.ID,
+		params.ApproachBuffer,
+		params.Severity,
+		params.Category,
+		params.ClearApproachBuffer,
 	).Scan(
 		&updated.ID,
 		&updated.Latitude,
 		&updated.Longitude,
 		&updated.Radius,
+		&updated.ApproachBuffer,
+		&updated.Severity,
+		&updated.Category,
 		&updated.IsActive,
 		&updated.CreatedAt,
 		&updated.UpdatedAt,
+		&previous.Latitude,
+		&previous.Longitude,
+		&previous.Radius,
+		&previous.ApproachBuffer,
+		&previous.Severity,
+		&previous.Category,
+		&previous.IsActive,
+		&previous.CreatedAt,
+		&previous.UpdatedAt,
 	)
 
 	if err != nil {
@@ -162,48 +231,74 @@ func (r *Repo) Update(ctx context.Context, params *models.UpdateIncidentParams)
 			var exists bool
 			checkQuery := `SELECT EXISTS(SELECT 1 FROM incidents WHERE id = $1)`
 			if checkErr := q.QueryRow(ctx, checkQuery, params.ID).Scan(&exists); checkErr != nil {
-				return nil, fmt.Errorf("failed to check incident existence: %w", checkErr)
+				return nil, nil, fmt.Errorf("failed to check incident existence: %w", checkErr)
 			}
 			if !exists {
-				return nil, errs.ErrIncidentNotFound
+				return nil, nil, errs.ErrIncidentNotFound
 			}
-			return nil, errs.ErrIncidentExists
+			return nil, nil, errs.ErrIncidentExists
 		}
-		return nil, fmt.Errorf("failed to update incident: %w", err)
+		return nil, nil, fmt.Errorf("failed to update incident: %w", err)
 	}
+	previous.ID = updated.ID
 
-	return updated, nil
+	return updated, previous, nil
 }
 
-func (r *Repo) Deactivate(ctx context.Context, id int64) error {
+// Deactivate returns the deactivated incident, or nil if it was already inactive.
+func (r *Repo) Deactivate(ctx context.Context, id int64) (*models.Incident, error) {
 	q := r.tm.GetQueryEngine(ctx)
 
 	query := `
 		UPDATE incidents
 		SET is_active = FALSE, updated_at = NOW()
 		WHERE id = $1 AND is_active = TRUE
+		RETURNING 
+			id, 
+			ST_Y(location::geometry),
+			ST_X(location::geometry),
+			radius_meters, 
+			approach_buffer_meters,
+			severity,
+			category,
+			is_active, 
+			created_at, 
+			updated_at
 	`
 
-	tag, err := q.Exec(ctx, query, id)
+	deactivated := &models.Incident{}
+
+	err := q.QueryRow(ctx, query, id).Scan(
+		&deactivated.ID,
+		&deactivated.Latitude,
+		&deactivated.Longitude,
+		&deactivated.Radius,
+		&deactivated.ApproachBuffer,
+		&deactivated.Severity,
+		&deactivated.Category,
+		&deactivated.IsActive,
+		&deactivated.CreatedAt,
+		&deactivated.UpdatedAt,
+	)
+
 	if err != nil {
-		return fmt.Errorf("failed to deactivate incident: %w", err)
-	}
+		if errors.Is(err, pgx.ErrNoRows) {
+			var exists bool
+			checkQuery := `SELECT EXISTS(SELECT 1 FROM incidents WHERE id = $1)`
+			if err := q.QueryRow(ctx, checkQuery, id).Scan(&exists); err != nil {
+				return nil, fmt.Errorf("failed to check incident existence: %w", err)
+			}
 
-	if tag.RowsAffected() == 0 {
-		var exists bool
-		checkQuery := `SELECT EXISTS(SELECT 1 FROM incidents WHERE id = $1)`
-		if err := q.QueryRow(ctx, checkQuery, id).Scan(&exists); err != nil {
-			return fmt.Errorf("failed to check incident existence: %w", err)
-		}
+			if !exists {
+				return nil, errs.ErrIncidentNotFound
+			}
 
-		if !exists {
-			return errs.ErrIncidentNotFound
+			return nil, nil
 		}
-
-		return nil
+		return nil, fmt.Errorf("failed to deactivate incident: %w", err)
 	}
 
-	return nil
+	return deactivated, nil
 }
 
 func (r *Repo) List(ctx context.Context, limit, offset int) ([]models.Incident, error) {
@@ -215,6 +310,9 @@ func (r *Repo) List(ctx context.Context, limit, offset int) ([]models.Incident,
 			ST_Y(location::geometry) as latitude,
 			ST_X(location::geometry) as longitude,
 			radius_meters,
+			approach_buffer_meters,
+			severity,
+			category,
 			is_active, 
 			created_at, 
 			updated_at
@@ -238,6 +336,9 @@ func (r *Repo) List(ctx context.Context, limit, offset int) ([]models.Incident,
 			&inc.Latitude,
 			&inc.Longitude,
 			&inc.Radius,
+			&inc.ApproachBuffer,
+			&inc.Severity,
+			&inc.Category,
 			&inc.IsActive,
 			&inc.CreatedAt,
 			&inc.UpdatedAt,
@@ -254,7 +355,10 @@ func (r *Repo) List(ctx context.Context, limit, offset int) ([]models.Incident,
 	return incidents, nil
 }
 
-func (r *Repo) GetStats(ctx context.Context, window time.Duration) ([]models.Stats, error) {
+// GetStats counts unique users per active incident within the window. A check with GPS accuracy counts
+// only if its whole uncertainty circle is inside the zone, or if it merely overlaps it when countPossiblyInside is set.
+// A circle wider than the zone is never wholly inside it, the same as geo.Circle.Classify.
+func (r *Repo) GetStats(ctx context.Context, window time.Duration, countPossiblyInside bool) ([]models.Stats, error) {
 	q := r.tm.GetQueryEngine(ctx)
 
 	query := `
@@ -265,14 +369,15 @@ func (r *Repo) GetStats(ctx context.Context, window time.Duration) ([]models.Sta
 			COUNT(DISTINCT l.user_id) as user_count
 		FROM incidents i
 		LEFT JOIN location_checks l ON 
-			ST_DWithin(i.location::geography, l.location::geography, i.radius_meters)
+			ST_DWithin(i.location::geography, l.location::geography, i.radius_meters + COALESCE(l.accuracy_meters, 0))
+			AND ($2 OR ST_Distance(i.location::geography, l.location::geography) + COALESCE(l.accuracy_meters, 0) <= i.radius_meters)
 			AND l.created_at >= NOW() - ($1 * INTERVAL '1 second')
 		WHERE i.is_active = TRUE
 		GROUP BY i.id
 		ORDER BY i.id ASC
 	`
 
-	rows, err := q.Query(ctx, query, window.Seconds())
+	rows, err := q.Query(ctx, query, window.Seconds(), countPossiblyInside)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query incident stats: %w", err)
 	}
@@ -308,7 +413,10 @@ func (r *Repo) GetActive(ctx context.Context) ([]models.IncidentShort, error) {
 			id, 
 			ST_Y(location::geometry) as latitude,
 			ST_X(location::geometry) as longitude,
-			radius_meters
+			radius_meters,
+			approach_buffer_meters,
+			severity,
+			category
 		FROM incidents
 		WHERE is_active = TRUE
 		ORDER BY id ASC
@@ -329,6 +437,9 @@ func (r *Repo) GetActive(ctx context.Context) ([]models.IncidentShort, error) {
 			&item.Latitude,
 			&item.Longitude,
 			&item.Radius,
+			&item.ApproachBuffer,
+			&item.Severity,
+			&item.Category,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan active incident: %w", err)
 		}
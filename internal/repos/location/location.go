@@ -3,6 +3,9 @@ package location
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/ocenb/geo-alerts/internal/domain/models"
 	"github.com/ocenb/geo-alerts/internal/storage/transactor"
@@ -20,12 +23,189 @@ func (r *Repo) SaveCheckLog(ctx context.Context, check *models.CheckLocationResu
 	q := r.tm.GetQueryEngine(ctx)
 
 	query := `
-		INSERT INTO location_checks (user_id, location, has_danger, created_at)
-		VALUES ($1, ST_SetSRID(ST_MakePoint($2, $3), 4326), $4, NOW())
+		INSERT INTO location_checks (user_id, location, accuracy_meters, has_danger, created_at)
+		VALUES ($1, ST_SetSRID(ST_MakePoint($2, $3), 4326), $4, $5, NOW())
 	`
-	_, err := q.Exec(ctx, query, check.UserID, check.Longitude, check.Latitude, check.HasDanger)
+	_, err := q.Exec(ctx, query, check.UserID, check.Longitude, check.Latitude, check.Accuracy, check.HasDanger)
 	if err != nil {
 		return fmt.Errorf("failed to save check log: %w", err)
 	}
 	return nil
 }
+
+// ListUsersInArea returns the users whose last location, if checked since the given time, is inside the area.
+func (r *Repo) ListUsersInArea(ctx context.Context, area *models.Area, since time.Time) ([]string, error) {
+	q := r.tm.GetQueryEngine(ctx)
+
+	cond, args, err := areaCondition(area, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT user_id
+		FROM (
+			SELECT DISTINCT ON (user_id) user_id, location
+			FROM location_checks
+			WHERE created_at >= $1
+			ORDER BY user_id, created_at DESC
+		) last_checks
+		WHERE ` + cond + `
+		ORDER BY user_id
+	`
+	rows, err := q.Query(ctx, query, append([]any{since}, args...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users in area: %w", err)
+	}
+	defer rows.Close()
+
+	users := make([]string, 0)
+
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("failed to scan user id: %w", err)
+		}
+		users = append(users, userID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return users, nil
+}
+
+// RecordAlerts remembers that the user was alerted about the incidents.
+func (r *Repo) RecordAlerts(ctx context.Context, userID string, incidentIDs []int64) error {
+	q := r.tm.GetQueryEngine(ctx)
+
+	query := `
+		INSERT INTO incident_alerts (incident_id, user_id)
+		SELECT id, $1 FROM unnest($2::bigint[]) AS id
+		ON CONFLICT DO NOTHING
+	`
+	if _, err := q.Exec(ctx, query, userID, incidentIDs); err != nil {
+		return fmt.Errorf("failed to record alerts: %w", err)
+	}
+	return nil
+}
+
+// ClaimRecentInZone returns the users whose last location, if checked since the given time, is inside the incident zone
+// and who haven't been alerted about the incident, recording them as alerted. Concurrent claims never return the same user.
+func (r *Repo) ClaimRecentInZone(ctx context.Context, incident *models.IncidentShort, since time.Time) ([]models.LastCheck, error) {
+	q := r.tm.GetQueryEngine(ctx)
+
+	cond, args, err := areaCondition(&models.Area{
+		Type:   models.AreaCircle,
+		Center: &models.GeoPoint{Latitude: incident.Latitude, Longitude: incident.Longitude},
+		Radius: incident.Radius,
+	}, 3)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		WITH in_zone AS (
+			SELECT user_id, location, created_at
+			FROM (
+				SELECT DISTINCT ON (user_id) user_id, location, created_at
+				FROM location_checks
+				WHERE created_at >= $2
+				ORDER BY user_id, created_at DESC
+			) last_checks
+			WHERE ` + cond + `
+		), claimed AS (
+			INSERT INTO incident_alerts (incident_id, user_id)
+			SELECT $1, user_id FROM in_zone
+			ON CONFLICT DO NOTHING
+			RETURNING user_id
+		)
+		SELECT z.user_id, ST_Y(z.location), ST_X(z.location), z.created_at
+		FROM in_zone z
+		JOIN claimed c ON c.user_id = z.user_id
+		ORDER BY z.user_id
+	`
+	rows, err := q.Query(ctx, query, append([]any{incident.ID, since}, args...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim users in zone: %w", err)
+	}
+	defer rows.Close()
+
+	checks := make([]models.LastCheck, 0)
+
+	for rows.Next() {
+		var c models.LastCheck
+		if err := rows.Scan(&c.UserID, &c.Latitude, &c.Longitude, &c.CheckedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan last check: %w", err)
+		}
+		checks = append(checks, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return checks, nil
+}
+
+// ReleaseAlerts forgets alerts about the incident that couldn't be sent, so that the users can be claimed again.
+func (r *Repo) ReleaseAlerts(ctx context.Context, incidentID int64, userIDs []string) error {
+	q := r.tm.GetQueryEngine(ctx)
+
+	query := `DELETE FROM incident_alerts WHERE incident_id = $1 AND user_id = ANY($2)`
+	if _, err := q.Exec(ctx, query, incidentID, userIDs); err != nil {
+		return fmt.Errorf("failed to release alerts: %w", err)
+	}
+	return nil
+}
+
+// areaCondition returns an SQL condition on the location column that holds inside the area,
+// with its arguments numbered from the given one. It matches how subscription areas are checked:
+// circles are measured on the spheroid, boxes and polygons in plain coordinates.
+func areaCondition(area *models.Area, firstArg int) (string, []any, error) {
+	arg := func(i int) string {
+		return "$" + strconv.Itoa(firstArg+i)
+	}
+
+	switch area.Type {
+	case models.AreaCircle:
+		if area.Center == nil {
+			return "", nil, fmt.Errorf("circle area without a center")
+		}
+		cond := fmt.Sprintf("ST_DWithin(location::geography, ST_SetSRID(ST_MakePoint(%s, %s), 4326)::geography, %s)", arg(0), arg(1), arg(2))
+		return cond, []any{area.Center.Longitude, area.Center.Latitude, area.Radius}, nil
+	case models.AreaBBox:
+		if area.SouthWest == nil || area.NorthEast == nil {
+			return "", nil, fmt.Errorf("bbox area without corners")
+		}
+		sw, ne := area.SouthWest, area.NorthEast
+		envelope := "ST_Intersects(location, ST_MakeEnvelope(%s, %s, %s, %s, 4326))"
+		if sw.Longitude <= ne.Longitude {
+			return fmt.Sprintf(envelope, arg(0), arg(1), arg(2), arg(3)), []any{sw.Longitude, sw.Latitude, ne.Longitude, ne.Latitude}, nil
+		}
+		// The box crosses the antimeridian, so it's two boxes on either side of it.
+		cond := "(" + fmt.Sprintf(envelope, arg(0), arg(1), "180", arg(2)) + " OR " + fmt.Sprintf(envelope, "-180", arg(1), arg(3), arg(2)) + ")"
+		return cond, []any{sw.Longitude, sw.Latitude, ne.Latitude, ne.Longitude}, nil
+	case models.AreaPolygon:
+		if len(area.Points) < 3 {
+			return "", nil, fmt.Errorf("polygon area with fewer than 3 points")
+		}
+		return fmt.Sprintf("ST_Covers(ST_GeomFromText(%s, 4326), location)", arg(0)), []any{polygonWKT(area.Points)}, nil
+	}
+	return "", nil, fmt.Errorf("unknown area type %q", area.Type)
+}
+
+// polygonWKT writes the ring as WKT, closing it.
+func polygonWKT(points []models.GeoPoint) string {
+	var b strings.Builder
+	b.WriteString("POLYGON((")
+	for _, p := range append(points, points[0]) {
+		if b.Len() > len("POLYGON((") {
+			b.WriteString(", ")
+		}
+		b.WriteString(strconv.FormatFloat(p.Longitude, 'f', -1, 64) + " " + strconv.FormatFloat(p.Latitude, 'f', -1, 64))
+	}
+	b.WriteString("))")
+	return b.String()
+}
@@ -0,0 +1,267 @@
+package place
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/ocenb/geo-alerts/internal/domain/errs"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/storage/transactor"
+)
+
+type Repo struct {
+	tm *transactor.Manager
+}
+
+func New(tm *transactor.Manager) *Repo {
+	return &Repo{tm}
+}
+
+const placeColumns = `
+	id,
+	user_id,
+	name,
+	ST_Y(location::geometry),
+	ST_X(location::geometry),
+	radius_meters,
+	created_at,
+	updated_at
+`
+
+func scanPlace(row pgx.Row) (*models.SavedPlace, error) {
+	var p models.SavedPlace
+	err := row.Scan(&p.ID, &p.UserID, &p.Name, &p.Latitude, &p.Longitude, &p.Radius, &p.CreatedAt, &p.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *Repo) Create(ctx context.Context, params *models.SavePlaceParams) (*models.SavedPlace, error) {
+	q := r.tm.GetQueryEngine(ctx)
+
+	query := `
+		INSERT INTO saved_places (user_id, name, location, radius_meters)
+		VALUES ($1, $2, ST_SetSRID(ST_MakePoint($3, $4), 4326), $5)
+		ON CONFLICT (user_id, name) DO NOTHING
+		RETURNING ` + placeColumns
+
+	p, err := scanPlace(q.QueryRow(ctx, query, params.UserID, params.Name, params.Longitude, params.Latitude, params.Radius))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.ErrPlaceExists
+		}
+		return nil, fmt.Errorf("failed to create saved place: %w", err)
+	}
+
+	return p, nil
+}
+
+// Update moves or renames the place. Alerts about it are forgotten, so that the owner hears about
+// the incidents near where the place is now, even those they were alerted about before.
+func (r *Repo) Update(ctx context.Context, params *models.SavePlaceParams) (*models.SavedPlace, error) {
+	var place *models.SavedPlace
+
+	err := r.tm.Run(ctx, func(ctx context.Context) error {
+		q := r.tm.GetQueryEngine(ctx)
+
+		query := `
+			UPDATE saved_places
+			SET name = $3,
+				location = ST_SetSRID(ST_MakePoint($4, $5), 4326),
+				radius_meters = $6
+			WHERE id = $1 AND user_id = $2
+				AND NOT EXISTS (
+					SELECT 1 FROM saved_places
+					WHERE user_id = $2 AND name = $3 AND id <> $1
+				)
+			RETURNING ` + placeColumns
+
+		p, err := scanPlace(q.QueryRow(ctx, query,
+			params.ID,
+			params.UserID,
+			params.Name,
+			params.Longitude,
+			params.Latitude,
+			params.Radius,
+		))
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return r.updateError(ctx, params)
+			}
+			return fmt.Errorf("failed to update saved place: %w", err)
+		}
+
+		if _, err := q.Exec(ctx, `DELETE FROM saved_place_alerts WHERE place_id = $1`, p.ID); err != nil {
+			return fmt.Errorf("failed to reset place alerts: %w", err)
+		}
+
+		place = p
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return place, nil
+}
+
+// updateError tells why no place was updated.
+func (r *Repo) updateError(ctx context.Context, params *models.SavePlaceParams) error {
+	q := r.tm.GetQueryEngine(ctx)
+
+	var exists bool
+	err := q.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM saved_places WHERE id = $1 AND user_id = $2)`, params.ID, params.UserID).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("failed to check saved place: %w", err)
+	}
+	if !exists {
+		return errs.ErrPlaceNotFound
+	}
+	return errs.ErrPlaceExists
+}
+
+func (r *Repo) Delete(ctx context.Context, userID string, id int64) error {
+	q := r.tm.GetQueryEngine(ctx)
+
+	tag, err := q.Exec(ctx, `DELETE FROM saved_places WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete saved place: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return errs.ErrPlaceNotFound
+	}
+
+	return nil
+}
+
+func (r *Repo) ListByUser(ctx context.Context, userID string) ([]models.SavedPlace, error) {
+	q := r.tm.GetQueryEngine(ctx)
+
+	rows, err := q.Query(ctx, `SELECT `+placeColumns+` FROM saved_places WHERE user_id = $1 ORDER BY id`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list saved places: %w", err)
+	}
+	defer rows.Close()
+
+	places := make([]models.SavedPlace, 0)
+
+	for rows.Next() {
+		p, err := scanPlace(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan saved place: %w", err)
+		}
+		places = append(places, *p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return places, nil
+}
+
+// ClaimAffected returns the saved places an active incident zone reaches into, of the user if userID is set
+// and near the incident if incidentID is, leaving out places whose owners were alerted about the incident
+// and recording the rest as alerted. Concurrent claims never return the same pair.
+func (r *Repo) ClaimAffected(ctx context.Context, userID string, incidentID int64) ([]models.PlaceMatch, error) {
+	q := r.tm.GetQueryEngine(ctx)
+
+	query := `
+		WITH affected AS (
+			SELECT p.id AS place_id, i.id AS incident_id
+			FROM saved_places p
+			JOIN incidents i ON i.is_active
+				AND ST_DWithin(p.location::geography, i.location::geography, p.radius_meters + i.radius_meters)
+			WHERE ($1 = '' OR p.user_id = $1)
+				AND ($2 = 0 OR i.id = $2)
+		), claimed AS (
+			INSERT INTO saved_place_alerts (place_id, incident_id)
+			SELECT place_id, incident_id FROM affected
+			ON CONFLICT DO NOTHING
+			RETURNING place_id, incident_id
+		)
+		SELECT
+			p.id,
+			p.user_id,
+			p.name,
+			ST_Y(p.location::geometry),
+			ST_X(p.location::geometry),
+			p.radius_meters,
+			p.created_at,
+			p.updated_at,
+			i.id,
+			ST_Y(i.location::geometry),
+			ST_X(i.location::geometry),
+			i.radius_meters,
+			i.approach_buffer_meters,
+			i.severity,
+			i.category
+		FROM claimed c
+		JOIN saved_places p ON p.id = c.place_id
+		JOIN incidents i ON i.id = c.incident_id
+		ORDER BY p.user_id, p.id, i.id
+	`
+	rows, err := q.Query(ctx, query, userID, incidentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim affected places: %w", err)
+	}
+	defer rows.Close()
+
+	matches := make([]models.PlaceMatch, 0)
+
+	for rows.Next() {
+		var m models.PlaceMatch
+		err := rows.Scan(
+			&m.Place.ID,
+			&m.Place.UserID,
+			&m.Place.Name,
+			&m.Place.Latitude,
+			&m.Place.Longitude,
+			&m.Place.Radius,
+			&m.Place.CreatedAt,
+			&m.Place.UpdatedAt,
+			&m.Incident.ID,
+			&m.Incident.Latitude,
+			&m.Incident.Longitude,
+			&m.Incident.Radius,
+			&m.Incident.ApproachBuffer,
+			&m.Incident.Severity,
+			&m.Incident.Category,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan place match: %w", err)
+		}
+		matches = append(matches, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return matches, nil
+}
+
+// ReleaseAlerts forgets alerts that couldn't be sent, so that the places can be claimed again.
+func (r *Repo) ReleaseAlerts(ctx context.Context, matches []models.PlaceMatch) error {
+	q := r.tm.GetQueryEngine(ctx)
+
+	placeIDs := make([]int64, len(matches))
+	incidentIDs := make([]int64, len(matches))
+	for i, m := range matches {
+		placeIDs[i], incidentIDs[i] = m.Place.ID, m.Incident.ID
+	}
+
+	query := `
+		DELETE FROM saved_place_alerts a
+		USING unnest($1::bigint[], $2::bigint[]) AS r (place_id, incident_id)
+		WHERE a.place_id = r.place_id AND a.incident_id = r.incident_id
+	`
+	if _, err := q.Exec(ctx, query, placeIDs, incidentIDs); err != nil {
+		return fmt.Errorf("failed to release place alerts: %w", err)
+	}
+	return nil
+}
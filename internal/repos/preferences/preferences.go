@@ -0,0 +1,104 @@
+package preferences
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/ocenb/geo-alerts/internal/domain/errs"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/storage/transactor"
+)
+
+type Repo struct {
+	tm *transactor.Manager
+}
+
+func New(tm *transactor.Manager) *Repo {
+	return &Repo{tm}
+}
+
+const preferencesColumns = `user_id, opted_out, min_severity, muted_categories, quiet_start, quiet_end, timezone, updated_at`
+
+func scanPreferences(row pgx.Row) (*models.UserPreferences, error) {
+	var p models.UserPreferences
+	var quietStart, quietEnd *string
+	err := row.Scan(&p.UserID, &p.OptedOut, &p.MinSeverity, &p.MutedCategories, &quietStart, &quietEnd, &p.Timezone, &p.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if quietStart != nil && quietEnd != nil {
+		p.QuietHours = &models.QuietHours{Start: *quietStart, End: *quietEnd}
+	}
+	return &p, nil
+}
+
+func (r *Repo) Get(ctx context.Context, userID string) (*models.UserPreferences, error) {
+	q := r.tm.GetQueryEngine(ctx)
+
+	p, err := scanPreferences(q.QueryRow(ctx, `SELECT `+preferencesColumns+` FROM user_preferences WHERE user_id = $1`, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.ErrPreferencesNotFound
+		}
+		return nil, fmt.Errorf("failed to get preferences: %w", err)
+	}
+
+	return p, nil
+}
+
+// Upsert replaces the preferences of the user.
+func (r *Repo) Upsert(ctx context.Context, params *models.UpdatePreferencesParams) (*models.UserPreferences, error) {
+	q := r.tm.GetQueryEngine(ctx)
+
+	var quietStart, quietEnd *string
+	if params.QuietHours != nil {
+		quietStart, quietEnd = &params.QuietHours.Start, &params.QuietHours.End
+	}
+	categories := params.MutedCategories
+	if categories == nil {
+		categories = []string{}
+	}
+
+	query := `
+		INSERT INTO user_preferences (user_id, opted_out, min_severity, muted_categories, quiet_start, quiet_end, timezone)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (user_id) DO UPDATE
+		SET opted_out = EXCLUDED.opted_out,
+			min_severity = EXCLUDED.min_severity,
+			muted_categories = EXCLUDED.muted_categories,
+			quiet_start = EXCLUDED.quiet_start,
+			quiet_end = EXCLUDED.quiet_end,
+			timezone = EXCLUDED.timezone
+		RETURNING ` + preferencesColumns
+
+	p, err := scanPreferences(q.QueryRow(ctx, query,
+		params.UserID,
+		params.OptedOut,
+		params.MinSeverity,
+		categories,
+		quietStart,
+		quietEnd,
+		params.Timezone,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to save preferences: %w", err)
+	}
+
+	return p, nil
+}
+
+func (r *Repo) RecordSuppression(ctx context.Context, s *models.AlertSuppression) error {
+	q := r.tm.GetQueryEngine(ctx)
+
+	query := `
+		INSERT INTO alert_suppressions (user_id, alert_type, incident_ids, reason)
+		VALUES ($1, $2, $3, $4)
+	`
+	if _, err := q.Exec(ctx, query, s.UserID, s.AlertType, s.IncidentIDs, s.Reason); err != nil {
+		return fmt.Errorf("failed to record alert suppression: %w", err)
+	}
+
+	return nil
+}
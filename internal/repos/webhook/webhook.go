@@ -0,0 +1,379 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/ocenb/geo-alerts/internal/domain/errs"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/storage/transactor"
+)
+
+type Repo struct {
+	tm *transactor.Manager
+}
+
+func New(tm *transactor.Manager) *Repo {
+	return &Repo{tm}
+}
+
+const subscriptionColumns = `
+	id,
+	url,
+	secret,
+	previous_secret,
+	previous_secret_expires_at,
+	is_enabled,
+	event_types,
+	area,
+	min_severity,
+	categories,
+	rate_limit_rps,
+	payload_format,
+	digest_interval_seconds,
+	digest_max_alerts,
+	created_at,
+	updated_at
+`
+
+func scanSubscription(row pgx.Row) (*models.WebhookSubscription, error) {
+	var sub models.WebhookSubscription
+	err := row.Scan(
+		&sub.ID,
+		&sub.URL,
+		&sub.Secret,
+		&sub.PreviousSecret,
+		&sub.PreviousSecretExpiresAt,
+		&sub.IsEnabled,
+		&sub.Events,
+		&sub.Area,
+		&sub.MinSeverity,
+		&sub.Categories,
+		&sub.RateLimit,
+		&sub.PayloadFormat,
+		&sub.Digest.Interval,
+		&sub.Digest.MaxAlerts,
+		&sub.CreatedAt,
+		&sub.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &sub, nil
+}
+
+func (r *Repo) Create(ctx context.Context, params *models.CreateWebhookSubscriptionParams) (*models.WebhookSubscription, error) {
+	q := r.tm.GetQueryEngine(ctx)
+
+	query := `
+		INSERT INTO webhook_subscriptions (
+			url, secret, is_enabled, event_types, area, min_severity, categories, rate_limit_rps, payload_format,
+			digest_interval_seconds, digest_max_alerts
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING ` + subscriptionColumns
+
+	sub, err := scanSubscription(q.QueryRow(ctx, query,
+		params.URL,
+		params.Secret,
+		params.IsEnabled,
+		params.Events,
+		params.Area,
+		params.MinSeverity,
+		params.Categories,
+		params.RateLimit,
+		params.PayloadFormat,
+		params.Digest.Interval,
+		params.Digest.MaxAlerts,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+
+	return sub, nil
+}
+
+func (r *Repo) GetByID(ctx context.Context, id int64) (*models.WebhookSubscription, error) {
+	q := r.tm.GetQueryEngine(ctx)
+
+	query := `SELECT ` + subscriptionColumns + ` FROM webhook_subscriptions WHERE id = $1`
+
+	sub, err := scanSubscription(q.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.ErrSubscriptionNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook subscription by id: %w", err)
+	}
+
+	return sub, nil
+}
+
+func (r *Repo) Update(ctx context.Context, params *models.UpdateWebhookSubscriptionParams) (*models.WebhookSubscription, error) {
+	q := r.tm.GetQueryEngine(ctx)
+
+	// A new secret moves the current one to previous_secret, which keeps signing deliveries for the grace period.
+	query := `
+		UPDATE webhook_subscriptions
+		SET
+			url = $2,
+			previous_secret = CASE WHEN $3::text <> secret THEN secret ELSE previous_secret END,
+			previous_secret_expires_at = CASE
+				WHEN $3::text <> secret THEN NOW() + ($9 * INTERVAL '1 second')
+				ELSE previous_secret_expires_at
+			END,
+			secret = COALESCE($3, secret),
+			is_enabled = $4,
+			event_types = $5,
+			area = $6,
+			min_severity = $7,
+			categories = $8,
+			rate_limit_rps = $10,
+			payload_format = $11,
+			digest_interval_seconds = $12,
+			digest_max_alerts = $13,
+			updated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + subscriptionColumns
+
+	sub, err := scanSubscription(q.QueryRow(ctx, query,
+		params.ID,
+		params.URL,
+		params.Secret,
+		params.IsEnabled,
+		params.Events,
+		params.Area,
+		params.MinSeverity,
+		params.Categories,
+		params.SecretRotationGrace.Seconds(),
+		params.RateLimit,
+		params.PayloadFormat,
+		params.Digest.Interval,
+		params.Digest.MaxAlerts,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.ErrSubscriptionNotFound
+		}
+		return nil, fmt.Errorf("failed to update webhook subscription: %w", err)
+	}
+
+	return sub, nil
+}
+
+func (r *Repo) Delete(ctx context.Context, id int64) error {
+	q := r.tm.GetQueryEngine(ctx)
+
+	tag, err := q.Exec(ctx, `DELETE FROM webhook_subscriptions WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook subscription: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return errs.ErrSubscriptionNotFound
+	}
+
+	return nil
+}
+
+func (r *Repo) List(ctx context.Context, limit, offset int) ([]models.WebhookSubscription, error) {
+	query := `
+		SELECT ` + subscriptionColumns + `
+		FROM webhook_subscriptions
+		ORDER BY id ASC
+		LIMIT $1 OFFSET $2
+	`
+	return r.query(ctx, query, limit, offset)
+}
+
+// ListEnabledForEvent returns enabled subscriptions whose event filter is empty or contains the event.
+// Area, severity and category filters are left to the caller, which knows the alert.
+func (r *Repo) ListEnabledForEvent(ctx context.Context, event string) ([]models.WebhookSubscription, error) {
+	query := `
+		SELECT ` + subscriptionColumns + `
+		FROM webhook_subscriptions
+		WHERE is_enabled = TRUE
+		  AND (cardinality(event_types) = 0 OR $1 = ANY(event_types))
+		ORDER BY id ASC
+	`
+	return r.query(ctx, query, event)
+}
+
+func (r *Repo) query(ctx context.Context, query string, args ...any) ([]models.WebhookSubscription, error) {
+	q := r.tm.GetQueryEngine(ctx)
+
+	rows, err := q.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	subs := make([]models.WebhookSubscription, 0)
+
+	for rows.Next() {
+		sub, err := scanSubscription(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook subscription: %w", err)
+		}
+		subs = append(subs, *sub)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return subs, nil
+}
+
+const deliveryColumns = `
+	id,
+	task_id,
+	task_type,
+	subscription_id,
+	redelivery_of,
+	event_id,
+	event_time,
+	url,
+	payload,
+	payload_hash,
+	attempt,
+	success,
+	status_code,
+	latency_ms,
+	response_body,
+	error,
+	created_at
+`
+
+func scanDelivery(row pgx.Row) (*models.WebhookDelivery, error) {
+	var d models.WebhookDelivery
+	err := row.Scan(
+		&d.ID,
+		&d.TaskID,
+		&d.TaskType,
+		&d.SubscriptionID,
+		&d.RedeliveryOf,
+		&d.EventID,
+		&d.EventTime,
+		&d.URL,
+		&d.Payload,
+		&d.PayloadHash,
+		&d.Attempt,
+		&d.Success,
+		&d.StatusCode,
+		&d.LatencyMs,
+		&d.ResponseBody,
+		&d.Error,
+		&d.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (r *Repo) SaveDelivery(ctx context.Context, d *models.WebhookDelivery) error {
+	q := r.tm.GetQueryEngine(ctx)
+
+	query := `
+		INSERT INTO webhook_deliveries (
+			task_id, task_type, subscription_id, redelivery_of, event_id, event_time, url, payload, payload_hash,
+			attempt, success, status_code, latency_ms, response_body, error
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+	`
+
+	_, err := q.Exec(ctx, query,
+		d.TaskID,
+		d.TaskType,
+		d.SubscriptionID,
+		d.RedeliveryOf,
+		d.EventID,
+		d.EventTime,
+		d.URL,
+		d.Payload,
+		d.PayloadHash,
+		d.Attempt,
+		d.Success,
+		d.StatusCode,
+		d.LatencyMs,
+		d.ResponseBody,
+		d.Error,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save webhook delivery: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repo) GetDeliveryByID(ctx context.Context, id int64) (*models.WebhookDelivery, error) {
+	q := r.tm.GetQueryEngine(ctx)
+
+	query := `SELECT ` + deliveryColumns + ` FROM webhook_deliveries WHERE id = $1`
+
+	d, err := scanDelivery(q.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.ErrDeliveryNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook delivery by id: %w", err)
+	}
+
+	return d, nil
+}
+
+// ListDeliveries returns delivery attempts newest first. Unset filters match everything.
+func (r *Repo) ListDeliveries(ctx context.Context, params *models.ListWebhookDeliveriesParams) ([]models.WebhookDelivery, error) {
+	q := r.tm.GetQueryEngine(ctx)
+
+	query := `
+		SELECT ` + deliveryColumns + `
+		FROM webhook_deliveries
+		WHERE ($1::bigint IS NULL OR subscription_id = $1)
+		  AND ($2 = '' OR payload->>'user_id' = $2)
+		  AND ($3 = '' OR task_id = $3)
+		  AND ($4 = '' OR event_id = $4)
+		  AND ($5 = '' OR payload_hash = $5)
+		  AND ($6::boolean IS NULL OR success = $6)
+		  AND ($7::timestamptz IS NULL OR created_at >= $7)
+		  AND ($8::timestamptz IS NULL OR created_at < $8)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $9 OFFSET $10
+	`
+
+	rows, err := q.Query(ctx, query,
+		params.SubscriptionID,
+		params.UserID,
+		params.TaskID,
+		params.EventID,
+		params.PayloadHash,
+		params.Success,
+		params.From,
+		params.To,
+		params.Limit,
+		params.Offset,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := make([]models.WebhookDelivery, 0)
+
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, *d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return deliveries, nil
+}
@@ -0,0 +1,83 @@
+package alert
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/ocenb/geo-alerts/internal/domain/errs"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/logger/logattr"
+)
+
+type AlertRepo interface {
+	Acknowledge(ctx context.Context, id string) (*models.Alert, error)
+	CountAcks(ctx context.Context, incidentID int64) (sent, acknowledged int, avgTimeToAck *float64, err error)
+	ListUnacknowledged(ctx context.Context, incidentID int64, limit, offset int) ([]models.Alert, error)
+}
+
+type IncidentRepo interface {
+	GetByID(ctx context.Context, id int64) (*models.Incident, error)
+}
+
+type Service struct {
+	log       *slog.Logger
+	alertRepo AlertRepo
+	incRepo   IncidentRepo
+}
+
+func New(log *slog.Logger, alertRepo AlertRepo, incRepo IncidentRepo) *Service {
+	return &Service{
+		log:       log,
+		alertRepo: alertRepo,
+		incRepo:   incRepo,
+	}
+}
+
+// Acknowledge records that the alert reached someone. Only the first acknowledgement counts.
+func (s *Service) Acknowledge(ctx context.Context, id string) (*models.Alert, error) {
+	alert, err := s.alertRepo.Acknowledge(ctx, id)
+	if err != nil {
+		if !errors.Is(err, errs.ErrAlertNotFound) {
+			s.log.Error("failed to acknowledge alert", logattr.Op("AlertService.Acknowledge"), slog.String("id", id), logattr.Err(err))
+		}
+		return nil, err
+	}
+
+	return alert, nil
+}
+
+// Unacknowledged returns the alerts about the incident nobody acknowledged, along with acknowledgement totals.
+func (s *Service) Unacknowledged(ctx context.Context, incidentID int64, limit, offset int) (*models.AckReport, error) {
+	log := s.log.With(
+		logattr.Op("AlertService.Unacknowledged"),
+		slog.Int64("incident_id", incidentID),
+	)
+
+	if _, err := s.incRepo.GetByID(ctx, incidentID); err != nil {
+		if !errors.Is(err, errs.ErrIncidentNotFound) {
+			log.Error("failed to get incident", logattr.Err(err))
+		}
+		return nil, err
+	}
+
+	sent, acknowledged, avg, err := s.alertRepo.CountAcks(ctx, incidentID)
+	if err != nil {
+		log.Error("failed to count alert acknowledgements", logattr.Err(err))
+		return nil, err
+	}
+
+	alerts, err := s.alertRepo.ListUnacknowledged(ctx, incidentID, limit, offset)
+	if err != nil {
+		log.Error("failed to list unacknowledged alerts", logattr.Err(err))
+		return nil, err
+	}
+
+	return &models.AckReport{
+		IncidentID:     incidentID,
+		Sent:           sent,
+		Acknowledged:   acknowledged,
+		AvgTimeToAck:   avg,
+		Unacknowledged: alerts,
+	}, nil
+}
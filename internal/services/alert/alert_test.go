@@ -0,0 +1,95 @@
+package alert
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ocenb/geo-alerts/internal/domain/errs"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/logger"
+)
+
+type AlertServiceSuite struct {
+	suite.Suite
+	mockAlertRepo    *MockAlertRepo
+	mockIncidentRepo *MockIncidentRepo
+	service          *Service
+}
+
+func (s *AlertServiceSuite) SetupTest() {
+	s.mockAlertRepo = NewMockAlertRepo(s.T())
+	s.mockIncidentRepo = NewMockIncidentRepo(s.T())
+	s.service = New(logger.NewDiscard(), s.mockAlertRepo, s.mockIncidentRepo)
+}
+
+func TestAlertServiceSuite(t *testing.T) {
+	suite.Run(t, new(AlertServiceSuite))
+}
+
+func (s *AlertServiceSuite) TestAcknowledge_Success() {
+	ctx := context.Background()
+	now := time.Now()
+	timeToAck := 42.5
+	expected := &models.Alert{ID: "a1", UserID: "u1", AcknowledgedAt: &now, TimeToAck: &timeToAck}
+
+	s.mockAlertRepo.On("Acknowledge", ctx, "a1").Return(expected, nil)
+
+	res, err := s.service.Acknowledge(ctx, "a1")
+
+	s.NoError(err)
+	s.Equal(expected, res)
+}
+
+func (s *AlertServiceSuite) TestAcknowledge_NotFound() {
+	ctx := context.Background()
+
+	s.mockAlertRepo.On("Acknowledge", ctx, "a1").Return(nil, errs.ErrAlertNotFound)
+
+	res, err := s.service.Acknowledge(ctx, "a1")
+
+	s.ErrorIs(err, errs.ErrAlertNotFound)
+	s.Nil(res)
+}
+
+func (s *AlertServiceSuite) TestUnacknowledged_Success() {
+	ctx := context.Background()
+	avg := 30.0
+	alerts := []models.Alert{{ID: "a2", UserID: "u2", IncidentIDs: []int64{1}}}
+
+	s.mockIncidentRepo.On("GetByID", ctx, int64(1)).Return(&models.Incident{ID: 1}, nil)
+	s.mockAlertRepo.On("CountAcks", ctx, int64(1)).Return(3, 2, &avg, nil)
+	s.mockAlertRepo.On("ListUnacknowledged", ctx, int64(1), 100, 0).Return(alerts, nil)
+
+	res, err := s.service.Unacknowledged(ctx, 1, 100, 0)
+
+	s.NoError(err)
+	s.Equal(&models.AckReport{IncidentID: 1, Sent: 3, Acknowledged: 2, AvgTimeToAck: &avg, Unacknowledged: alerts}, res)
+}
+
+func (s *AlertServiceSuite) TestUnacknowledged_IncidentNotFound() {
+	ctx := context.Background()
+
+	s.mockIncidentRepo.On("GetByID", ctx, int64(1)).Return(nil, errs.ErrIncidentNotFound)
+
+	res, err := s.service.Unacknowledged(ctx, 1, 100, 0)
+
+	s.ErrorIs(err, errs.ErrIncidentNotFound)
+	s.Nil(res)
+}
+
+func (s *AlertServiceSuite) TestUnacknowledged_RepoError() {
+	ctx := context.Background()
+	dbErr := errors.New("db error")
+
+	s.mockIncidentRepo.On("GetByID", ctx, int64(1)).Return(&models.Incident{ID: 1}, nil)
+	s.mockAlertRepo.On("CountAcks", ctx, int64(1)).Return(0, 0, (*float64)(nil), dbErr)
+
+	res, err := s.service.Unacknowledged(ctx, 1, 100, 0)
+
+	s.ErrorIs(err, dbErr)
+	s.Nil(res)
+}
@@ -0,0 +1,126 @@
+package broadcast
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/ocenb/geo-alerts/internal/domain/errs"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/logger/logattr"
+)
+
+type BroadcastRepo interface {
+	Create(ctx context.Context, params *models.CreateBroadcastParams) (*models.Broadcast, error)
+	GetByID(ctx context.Context, id int64) (*models.Broadcast, error)
+	List(ctx context.Context, limit, offset int) ([]models.Broadcast, error)
+	Fail(ctx context.Context, id int64, reason string) error
+	ListRecipients(ctx context.Context, params *models.ListBroadcastRecipientsParams) ([]models.BroadcastRecipient, error)
+}
+
+type IncidentRepo interface {
+	GetByID(ctx context.Context, id int64) (*models.Incident, error)
+}
+
+type QueueProducer interface {
+	EnqueueBroadcast(ctx context.Context, broadcastID int64) error
+}
+
+type Service struct {
+	log           *slog.Logger
+	recency       time.Duration
+	broadcastRepo BroadcastRepo
+	incidentRepo  IncidentRepo
+	queue         QueueProducer
+}
+
+func New(log *slog.Logger, recency time.Duration, broadcastRepo BroadcastRepo, incidentRepo IncidentRepo, queue QueueProducer) *Service {
+	return &Service{
+		log:           log,
+		recency:       recency,
+		broadcastRepo: broadcastRepo,
+		incidentRepo:  incidentRepo,
+		queue:         queue,
+	}
+}
+
+// Create saves the broadcast and queues the lookup of its recipients. A broadcast targeted by incident
+// goes to the incident zone as it is now. Recency defaults to the configured window.
+func (s *Service) Create(ctx context.Context, params *models.CreateBroadcastParams) (*models.Broadcast, error) {
+	const op = "BroadcastService.Create"
+
+	if params.IncidentID != nil {
+		incident, err := s.incidentRepo.GetByID(ctx, *params.IncidentID)
+		if err != nil {
+			if !errors.Is(err, errs.ErrIncidentNotFound) {
+				s.log.Error("failed to get incident", logattr.Op(op), slog.Int64("incident_id", *params.IncidentID), logattr.Err(err))
+			}
+			return nil, err
+		}
+		params.Area = &models.Area{
+			Type:   models.AreaCircle,
+			Center: &models.GeoPoint{Latitude: incident.Latitude, Longitude: incident.Longitude},
+			Radius: incident.Radius,
+		}
+	}
+	if params.Recency <= 0 {
+		params.Recency = s.recency
+	}
+
+	b, err := s.broadcastRepo.Create(ctx, params)
+	if err != nil {
+		s.log.Error("failed to create broadcast", logattr.Op(op), logattr.Err(err))
+		return nil, err
+	}
+
+	if err := s.queue.EnqueueBroadcast(ctx, b.ID); err != nil {
+		s.log.Error("failed to enqueue broadcast", logattr.Op(op), slog.Int64("broadcast_id", b.ID), logattr.Err(err))
+		if failErr := s.broadcastRepo.Fail(context.WithoutCancel(ctx), b.ID, "failed to enqueue broadcast"); failErr != nil {
+			s.log.Error("failed to mark broadcast failed", logattr.Op(op), slog.Int64("broadcast_id", b.ID), logattr.Err(failErr))
+		}
+		return nil, err
+	}
+
+	s.log.Info("broadcast created", slog.Int64("broadcast_id", b.ID), slog.String("area_type", b.Area.Type))
+	return b, nil
+}
+
+func (s *Service) GetByID(ctx context.Context, id int64) (*models.Broadcast, error) {
+	b, err := s.broadcastRepo.GetByID(ctx, id)
+	if err != nil {
+		if !errors.Is(err, errs.ErrBroadcastNotFound) {
+			s.log.Error("failed to get broadcast", logattr.Op("BroadcastService.GetByID"), slog.Int64("id", id), logattr.Err(err))
+		}
+		return nil, err
+	}
+
+	return b, nil
+}
+
+func (s *Service) List(ctx context.Context, limit, offset int) ([]models.Broadcast, error) {
+	broadcasts, err := s.broadcastRepo.List(ctx, limit, offset)
+	if err != nil {
+		s.log.Error("failed to list broadcasts", logattr.Op("BroadcastService.List"), logattr.Err(err))
+		return nil, err
+	}
+
+	return broadcasts, nil
+}
+
+// ListRecipients returns the delivery report of a broadcast, one entry per device.
+func (s *Service) ListRecipients(ctx context.Context, params *models.ListBroadcastRecipientsParams) ([]models.BroadcastRecipient, error) {
+	const op = "BroadcastService.ListRecipients"
+
+	if _, err := s.GetByID(ctx, params.BroadcastID); err != nil {
+		return nil, err
+	}
+
+	recipients, err := s.broadcastRepo.ListRecipients(ctx, params)
+	if err != nil {
+		s.log.Error("failed to list broadcast recipients", logattr.Op(op), slog.Int64("broadcast_id", params.BroadcastID), logattr.Err(err))
+		return nil, err
+	}
+
+	return recipients, nil
+}
@@ -0,0 +1,135 @@
+package broadcast
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ocenb/geo-alerts/internal/domain/errs"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/logger"
+)
+
+type BroadcastServiceSuite struct {
+	suite.Suite
+	mockBroadcastRepo *MockBroadcastRepo
+	mockIncidentRepo  *MockIncidentRepo
+	mockQueue         *MockQueueProducer
+	service           *Service
+}
+
+func (s *BroadcastServiceSuite) SetupTest() {
+	s.mockBroadcastRepo = NewMockBroadcastRepo(s.T())
+	s.mockIncidentRepo = NewMockIncidentRepo(s.T())
+	s.mockQueue = NewMockQueueProducer(s.T())
+
+	s.service = New(
+		logger.NewDiscard(),
+		30*time.Minute,
+		s.mockBroadcastRepo,
+		s.mockIncidentRepo,
+		s.mockQueue,
+	)
+}
+
+func TestBroadcastServiceSuite(t *testing.T) {
+	suite.Run(t, new(BroadcastServiceSuite))
+}
+
+// --- Tests for Create ---
+
+func (s *BroadcastServiceSuite) TestCreate_Area() {
+	ctx := context.Background()
+	area := &models.Area{Type: models.AreaCircle, Center: &models.GeoPoint{Latitude: 55.75, Longitude: 37.62}, Radius: 500}
+	params := &models.CreateBroadcastParams{Area: area, Title: "Evacuation", Message: "Leave the building"}
+	expected := &models.Broadcast{ID: 1, Area: area, Status: models.BroadcastPending}
+
+	s.mockBroadcastRepo.On("Create", ctx, mock.MatchedBy(func(p *models.CreateBroadcastParams) bool {
+		return p.Area == area && p.Recency == 30*time.Minute
+	})).Return(expected, nil)
+	s.mockQueue.On("EnqueueBroadcast", ctx, int64(1)).Return(nil)
+
+	res, err := s.service.Create(ctx, params)
+
+	s.NoError(err)
+	s.Equal(expected, res)
+}
+
+func (s *BroadcastServiceSuite) TestCreate_Incident() {
+	ctx := context.Background()
+	incidentID := int64(7)
+	params := &models.CreateBroadcastParams{IncidentID: &incidentID, Title: "Fire", Message: "Leave the area", Recency: time.Hour}
+	incident := &models.Incident{ID: 7, Latitude: 55.75, Longitude: 37.62, Radius: 300}
+
+	s.mockIncidentRepo.On("GetByID", ctx, incidentID).Return(incident, nil)
+	s.mockBroadcastRepo.On("Create", ctx, mock.MatchedBy(func(p *models.CreateBroadcastParams) bool {
+		return p.Area.Type == models.AreaCircle && p.Area.Center.Latitude == 55.75 && p.Area.Radius == 300 && p.Recency == time.Hour
+	})).Return(&models.Broadcast{ID: 2, IncidentID: &incidentID, Area: &models.Area{Type: models.AreaCircle}}, nil)
+	s.mockQueue.On("EnqueueBroadcast", ctx, int64(2)).Return(nil)
+
+	res, err := s.service.Create(ctx, params)
+
+	s.NoError(err)
+	s.Equal(int64(2), res.ID)
+}
+
+func (s *BroadcastServiceSuite) TestCreate_IncidentNotFound() {
+	ctx := context.Background()
+	incidentID := int64(7)
+	params := &models.CreateBroadcastParams{IncidentID: &incidentID, Title: "Fire", Message: "Leave the area"}
+
+	s.mockIncidentRepo.On("GetByID", ctx, incidentID).Return(nil, errs.ErrIncidentNotFound)
+
+	res, err := s.service.Create(ctx, params)
+
+	s.ErrorIs(err, errs.ErrIncidentNotFound)
+	s.Nil(res)
+}
+
+func (s *BroadcastServiceSuite) TestCreate_EnqueueError() {
+	ctx := context.Background()
+	area := &models.Area{Type: models.AreaCircle, Center: &models.GeoPoint{}, Radius: 500}
+	params := &models.CreateBroadcastParams{Area: area, Title: "Evacuation", Message: "Leave the building"}
+	queueErr := errors.New("redis down")
+
+	s.mockBroadcastRepo.On("Create", ctx, params).Return(&models.Broadcast{ID: 3, Area: area}, nil)
+	s.mockQueue.On("EnqueueBroadcast", ctx, int64(3)).Return(queueErr)
+	s.mockBroadcastRepo.On("Fail", mock.Anything, int64(3), mock.Anything).Return(nil)
+
+	res, err := s.service.Create(ctx, params)
+
+	s.ErrorIs(err, queueErr)
+	s.Nil(res)
+}
+
+// --- Tests for ListRecipients ---
+
+func (s *BroadcastServiceSuite) TestListRecipients_Success() {
+	ctx := context.Background()
+	params := &models.ListBroadcastRecipientsParams{BroadcastID: 1, Status: models.RecipientFailed, Limit: 10}
+	expected := []models.BroadcastRecipient{{ID: 1, BroadcastID: 1, Status: models.RecipientFailed}}
+
+	s.mockBroadcastRepo.On("GetByID", ctx, int64(1)).Return(&models.Broadcast{ID: 1}, nil)
+	s.mockBroadcastRepo.On("ListRecipients", ctx, params).Return(expected, nil)
+
+	res, err := s.service.ListRecipients(ctx, params)
+
+	s.NoError(err)
+	s.Equal(expected, res)
+}
+
+func (s *BroadcastServiceSuite) TestListRecipients_NotFound() {
+	ctx := context.Background()
+	params := &models.ListBroadcastRecipientsParams{BroadcastID: 9, Limit: 10}
+
+	s.mockBroadcastRepo.On("GetByID", ctx, int64(9)).Return(nil, errs.ErrBroadcastNotFound)
+
+	res, err := s.service.ListRecipients(ctx, params)
+
+	s.ErrorIs(err, errs.ErrBroadcastNotFound)
+	s.Nil(res)
+}
@@ -0,0 +1,147 @@
+package checkin
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/ocenb/geo-alerts/internal/config"
+	"github.com/ocenb/geo-alerts/internal/domain/errs"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/logger/logattr"
+)
+
+type CheckinRepo interface {
+	Upsert(ctx context.Context, params *models.CheckinParams) (*models.Checkin, error)
+	CountByStatus(ctx context.Context, incidentID int64) (models.CheckinCounts, error)
+	CountUnaccounted(ctx context.Context, params *models.ListUnaccountedParams) (int, int, error)
+	ListUnaccounted(ctx context.Context, params *models.ListUnaccountedParams) ([]models.UnaccountedUser, error)
+}
+
+type IncidentRepo interface {
+	GetByID(ctx context.Context, id int64) (*models.Incident, error)
+}
+
+type QueueProducer interface {
+	EnqueueCheckinReminder(ctx context.Context, incidentID int64) error
+}
+
+type Service struct {
+	log         *slog.Logger
+	cfg         config.AppConfig
+	checkinRepo CheckinRepo
+	incRepo     IncidentRepo
+	queue       QueueProducer
+}
+
+func New(log *slog.Logger, cfg config.AppConfig, checkinRepo CheckinRepo, incRepo IncidentRepo, queue QueueProducer) *Service {
+	return &Service{
+		log:         log,
+		cfg:         cfg,
+		checkinRepo: checkinRepo,
+		incRepo:     incRepo,
+		queue:       queue,
+	}
+}
+
+// Checkin saves the status the user reports for an active incident. A later check-in replaces an earlier one.
+func (s *Service) Checkin(ctx context.Context, params *models.CheckinParams) (*models.Checkin, error) {
+	log := s.log.With(
+		logattr.Op("CheckinService.Checkin"),
+		slog.Int64("incident_id", params.IncidentID),
+		slog.String("user_id", params.UserID),
+	)
+
+	if _, err := s.activeIncident(ctx, log, params.IncidentID); err != nil {
+		return nil, err
+	}
+
+	checkin, err := s.checkinRepo.Upsert(ctx, params)
+	if err != nil {
+		log.Error("failed to save check-in", logattr.Err(err))
+		return nil, err
+	}
+
+	log.Info("user checked in", slog.String("status", checkin.Status))
+	return checkin, nil
+}
+
+// Unaccounted reports the check-ins of the incident and lists the users seen in its zone within the stats window
+// who haven't checked in.
+func (s *Service) Unaccounted(ctx context.Context, incidentID int64, limit, offset int) (*models.UnaccountedReport, error) {
+	log := s.log.With(logattr.Op("CheckinService.Unaccounted"), slog.Int64("incident_id", incidentID))
+
+	if _, err := s.incRepo.GetByID(ctx, incidentID); err != nil {
+		if !errors.Is(err, errs.ErrIncidentNotFound) {
+			log.Error("failed to get incident", logattr.Err(err))
+		}
+		return nil, err
+	}
+
+	params := &models.ListUnaccountedParams{
+		IncidentID:          incidentID,
+		Window:              s.cfg.StatsTimeWindow,
+		CountPossiblyInside: s.cfg.StatsCountPossiblyInside,
+		Limit:               limit,
+		Offset:              offset,
+	}
+
+	counts, err := s.checkinRepo.CountByStatus(ctx, incidentID)
+	if err != nil {
+		log.Error("failed to count check-ins", logattr.Err(err))
+		return nil, err
+	}
+	seen, unaccounted, err := s.checkinRepo.CountUnaccounted(ctx, params)
+	if err != nil {
+		log.Error("failed to count unaccounted users", logattr.Err(err))
+		return nil, err
+	}
+	counts.Unaccounted = unaccounted
+
+	users, err := s.checkinRepo.ListUnaccounted(ctx, params)
+	if err != nil {
+		log.Error("failed to list unaccounted users", logattr.Err(err))
+		return nil, err
+	}
+
+	return &models.UnaccountedReport{
+		IncidentID: incidentID,
+		Window:     int(s.cfg.StatsTimeWindow.Seconds()),
+		Seen:       seen,
+		Counts:     counts,
+		Users:      users,
+	}, nil
+}
+
+// Remind queues a push to the unaccounted users of an active incident asking them to check in.
+func (s *Service) Remind(ctx context.Context, incidentID int64) error {
+	log := s.log.With(logattr.Op("CheckinService.Remind"), slog.Int64("incident_id", incidentID))
+
+	if _, err := s.activeIncident(ctx, log, incidentID); err != nil {
+		return err
+	}
+
+	if err := s.queue.EnqueueCheckinReminder(ctx, incidentID); err != nil {
+		if !errors.Is(err, errs.ErrReminderPending) {
+			log.Error("failed to enqueue check-in reminder", logattr.Err(err))
+		}
+		return err
+	}
+
+	log.Info("check-in reminder queued")
+	return nil
+}
+
+func (s *Service) activeIncident(ctx context.Context, log *slog.Logger, id int64) (*models.Incident, error) {
+	incident, err := s.incRepo.GetByID(ctx, id)
+	if err != nil {
+		if !errors.Is(err, errs.ErrIncidentNotFound) {
+			log.Error("failed to get incident", logattr.Err(err))
+		}
+		return nil, err
+	}
+	if !incident.IsActive {
+		return nil, errs.ErrIncidentInactive
+	}
+	return incident, nil
+}
@@ -0,0 +1,153 @@
+package checkin
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ocenb/geo-alerts/internal/config"
+	"github.com/ocenb/geo-alerts/internal/domain/errs"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/logger"
+)
+
+type CheckinServiceSuite struct {
+	suite.Suite
+	mockCheckin *MockCheckinRepo
+	mockInc     *MockIncidentRepo
+	mockQueue   *MockQueueProducer
+	service     *Service
+}
+
+func (s *CheckinServiceSuite) SetupTest() {
+	s.mockCheckin = NewMockCheckinRepo(s.T())
+	s.mockInc = NewMockIncidentRepo(s.T())
+	s.mockQueue = NewMockQueueProducer(s.T())
+
+	cfg := config.AppConfig{
+		StatsTimeWindow: 15 * time.Minute,
+	}
+
+	s.service = New(
+		logger.NewDiscard(),
+		cfg,
+		s.mockCheckin,
+		s.mockInc,
+		s.mockQueue,
+	)
+}
+
+func TestCheckinServiceSuite(t *testing.T) {
+	suite.Run(t, new(CheckinServiceSuite))
+}
+
+// --- Tests for Checkin ---
+
+func (s *CheckinServiceSuite) TestCheckin_Success() {
+	ctx := context.Background()
+	params := &models.CheckinParams{IncidentID: 1, UserID: "u1", Status: models.CheckinSafe}
+	expected := &models.Checkin{IncidentID: 1, UserID: "u1", Status: models.CheckinSafe}
+
+	s.mockInc.On("GetByID", ctx, int64(1)).Return(&models.Incident{ID: 1, IsActive: true}, nil)
+	s.mockCheckin.On("Upsert", ctx, params).Return(expected, nil)
+
+	res, err := s.service.Checkin(ctx, params)
+
+	s.NoError(err)
+	s.Equal(expected, res)
+}
+
+func (s *CheckinServiceSuite) TestCheckin_IncidentNotFound() {
+	ctx := context.Background()
+	params := &models.CheckinParams{IncidentID: 1, UserID: "u1", Status: models.CheckinSafe}
+
+	s.mockInc.On("GetByID", ctx, int64(1)).Return(nil, errs.ErrIncidentNotFound)
+
+	res, err := s.service.Checkin(ctx, params)
+
+	s.ErrorIs(err, errs.ErrIncidentNotFound)
+	s.Nil(res)
+}
+
+func (s *CheckinServiceSuite) TestCheckin_IncidentInactive() {
+	ctx := context.Background()
+	params := &models.CheckinParams{IncidentID: 1, UserID: "u1", Status: models.CheckinEvacuated}
+
+	s.mockInc.On("GetByID", ctx, int64(1)).Return(&models.Incident{ID: 1}, nil)
+
+	res, err := s.service.Checkin(ctx, params)
+
+	s.ErrorIs(err, errs.ErrIncidentInactive)
+	s.Nil(res)
+}
+
+// --- Tests for Unaccounted ---
+
+func (s *CheckinServiceSuite) TestUnaccounted_Success() {
+	ctx := context.Background()
+	users := []models.UnaccountedUser{{UserID: "u3"}}
+	paramsMatch := mock.MatchedBy(func(p *models.ListUnaccountedParams) bool {
+		return p.IncidentID == 1 && p.Window == 15*time.Minute && p.Limit == 10 && p.Offset == 0
+	})
+
+	s.mockInc.On("GetByID", ctx, int64(1)).Return(&models.Incident{ID: 1}, nil)
+	s.mockCheckin.On("CountByStatus", ctx, int64(1)).Return(models.CheckinCounts{Safe: 2, NeedHelp: 1}, nil)
+	s.mockCheckin.On("CountUnaccounted", ctx, paramsMatch).Return(4, 1, nil)
+	s.mockCheckin.On("ListUnaccounted", ctx, paramsMatch).Return(users, nil)
+
+	res, err := s.service.Unaccounted(ctx, 1, 10, 0)
+
+	s.NoError(err)
+	s.Equal(&models.UnaccountedReport{
+		IncidentID: 1,
+		Window:     900,
+		Seen:       4,
+		Counts:     models.CheckinCounts{Safe: 2, NeedHelp: 1, Unaccounted: 1},
+		Users:      users,
+	}, res)
+}
+
+func (s *CheckinServiceSuite) TestUnaccounted_RepoError() {
+	ctx := context.Background()
+	dbErr := errors.New("db error")
+
+	s.mockInc.On("GetByID", ctx, int64(1)).Return(&models.Incident{ID: 1}, nil)
+	s.mockCheckin.On("CountByStatus", ctx, int64(1)).Return(models.CheckinCounts{}, dbErr)
+
+	res, err := s.service.Unaccounted(ctx, 1, 10, 0)
+
+	s.ErrorIs(err, dbErr)
+	s.Nil(res)
+}
+
+// --- Tests for Remind ---
+
+func (s *CheckinServiceSuite) TestRemind_Success() {
+	ctx := context.Background()
+
+	s.mockInc.On("GetByID", ctx, int64(1)).Return(&models.Incident{ID: 1, IsActive: true}, nil)
+	s.mockQueue.On("EnqueueCheckinReminder", ctx, int64(1)).Return(nil)
+
+	s.NoError(s.service.Remind(ctx, 1))
+}
+
+func (s *CheckinServiceSuite) TestRemind_Pending() {
+	ctx := context.Background()
+
+	s.mockInc.On("GetByID", ctx, int64(1)).Return(&models.Incident{ID: 1, IsActive: true}, nil)
+	s.mockQueue.On("EnqueueCheckinReminder", ctx, int64(1)).Return(errs.ErrReminderPending)
+
+	s.ErrorIs(s.service.Remind(ctx, 1), errs.ErrReminderPending)
+}
+
+func (s *CheckinServiceSuite) TestRemind_IncidentInactive() {
+	ctx := context.Background()
+
+	s.mockInc.On("GetByID", ctx, int64(1)).Return(&models.Incident{ID: 1}, nil)
+
+	s.ErrorIs(s.service.Remind(ctx, 1), errs.ErrIncidentInactive)
+}
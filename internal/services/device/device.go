@@ -0,0 +1,50 @@
+package device
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/ocenb/geo-alerts/internal/domain/errs"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/logger/logattr"
+)
+
+type DeviceRepo interface {
+	Register(ctx context.Context, params *models.RegisterDeviceParams) (*models.Device, error)
+	Unregister(ctx context.Context, userID, token string) error
+}
+
+type Service struct {
+	log        *slog.Logger
+	deviceRepo DeviceRepo
+}
+
+func New(log *slog.Logger, deviceRepo DeviceRepo) *Service {
+	return &Service{
+		log:        log,
+		deviceRepo: deviceRepo,
+	}
+}
+
+// Register adds a device the user's alerts are pushed to, or updates it if its token is registered already.
+func (s *Service) Register(ctx context.Context, params *models.RegisterDeviceParams) (*models.Device, error) {
+	device, err := s.deviceRepo.Register(ctx, params)
+	if err != nil {
+		s.log.Error("failed to register device", logattr.Op("DeviceService.Register"), slog.String("user_id", params.UserID), logattr.Err(err))
+		return nil, err
+	}
+
+	return device, nil
+}
+
+func (s *Service) Unregister(ctx context.Context, userID, token string) error {
+	if err := s.deviceRepo.Unregister(ctx, userID, token); err != nil {
+		if !errors.Is(err, errs.ErrDeviceNotFound) {
+			s.log.Error("failed to unregister device", logattr.Op("DeviceService.Unregister"), slog.String("user_id", userID), logattr.Err(err))
+		}
+		return err
+	}
+
+	return nil
+}
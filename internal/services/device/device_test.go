@@ -0,0 +1,82 @@
+package device
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ocenb/geo-alerts/internal/domain/errs"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/logger"
+)
+
+type DeviceServiceSuite struct {
+	suite.Suite
+	mockDeviceRepo *MockDeviceRepo
+	service        *Service
+}
+
+func (s *DeviceServiceSuite) SetupTest() {
+	s.mockDeviceRepo = NewMockDeviceRepo(s.T())
+
+	s.service = New(
+		logger.NewDiscard(),
+		s.mockDeviceRepo,
+	)
+}
+
+func TestDeviceServiceSuite(t *testing.T) {
+	suite.Run(t, new(DeviceServiceSuite))
+}
+
+// --- Tests for Register ---
+
+func (s *DeviceServiceSuite) TestRegister_Success() {
+	ctx := context.Background()
+	params := &models.RegisterDeviceParams{UserID: "u1", Token: "t1", Platform: models.PlatformAndroid, Locale: "ru"}
+	expected := &models.Device{ID: 1, UserID: "u1", Token: "t1", Platform: models.PlatformAndroid, Locale: "ru"}
+
+	s.mockDeviceRepo.On("Register", ctx, params).Return(expected, nil)
+
+	res, err := s.service.Register(ctx, params)
+
+	s.NoError(err)
+	s.Equal(expected, res)
+}
+
+func (s *DeviceServiceSuite) TestRegister_RepoError() {
+	ctx := context.Background()
+	params := &models.RegisterDeviceParams{UserID: "u1", Token: "t1", Platform: models.PlatformIOS, Locale: "en"}
+	dbErr := errors.New("db error")
+
+	s.mockDeviceRepo.On("Register", ctx, params).Return(nil, dbErr)
+
+	res, err := s.service.Register(ctx, params)
+
+	s.ErrorIs(err, dbErr)
+	s.Nil(res)
+}
+
+// --- Tests for Unregister ---
+
+func (s *DeviceServiceSuite) TestUnregister_Success() {
+	ctx := context.Background()
+
+	s.mockDeviceRepo.On("Unregister", ctx, "u1", "t1").Return(nil)
+
+	err := s.service.Unregister(ctx, "u1", "t1")
+
+	s.NoError(err)
+}
+
+func (s *DeviceServiceSuite) TestUnregister_NotFound() {
+	ctx := context.Background()
+
+	s.mockDeviceRepo.On("Unregister", ctx, "u2", "t1").Return(errs.ErrDeviceNotFound)
+
+	err := s.service.Unregister(ctx, "u2", "t1")
+
+	s.ErrorIs(err, errs.ErrDeviceNotFound)
+}
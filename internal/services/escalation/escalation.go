@@ -0,0 +1,67 @@
+package escalation
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/ocenb/geo-alerts/internal/domain/errs"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/logger/logattr"
+)
+
+type PolicyRepo interface {
+	ListPolicies(ctx context.Context) ([]models.EscalationPolicy, error)
+	UpsertPolicy(ctx context.Context, params *models.UpdateEscalationPolicyParams) (*models.EscalationPolicy, error)
+	DeletePolicy(ctx context.Context, severity int) error
+}
+
+type Service struct {
+	log        *slog.Logger
+	policyRepo PolicyRepo
+}
+
+func New(log *slog.Logger, policyRepo PolicyRepo) *Service {
+	return &Service{
+		log:        log,
+		policyRepo: policyRepo,
+	}
+}
+
+func (s *Service) List(ctx context.Context) ([]models.EscalationPolicy, error) {
+	policies, err := s.policyRepo.ListPolicies(ctx)
+	if err != nil {
+		s.log.Error("failed to list escalation policies", logattr.Op("EscalationService.List"), logattr.Err(err))
+		return nil, err
+	}
+
+	return policies, nil
+}
+
+// Update replaces the policy for the severity. Users already inside a zone keep the follow-ups scheduled
+// when they entered it, the policy applies to the ones entering from now on.
+func (s *Service) Update(ctx context.Context, params *models.UpdateEscalationPolicyParams) (*models.EscalationPolicy, error) {
+	policy, err := s.policyRepo.UpsertPolicy(ctx, params)
+	if err != nil {
+		s.log.Error("failed to update escalation policy", logattr.Op("EscalationService.Update"), slog.Int("severity", params.Severity), logattr.Err(err))
+		return nil, err
+	}
+
+	s.log.Info("escalation policy updated",
+		slog.Int("severity", policy.Severity),
+		slog.Int("realert_after", policy.RealertAfter),
+		slog.Int("escalate_after", policy.EscalateAfter),
+	)
+	return policy, nil
+}
+
+func (s *Service) Delete(ctx context.Context, severity int) error {
+	if err := s.policyRepo.DeletePolicy(ctx, severity); err != nil {
+		if !errors.Is(err, errs.ErrEscalationPolicyNotFound) {
+			s.log.Error("failed to delete escalation policy", logattr.Op("EscalationService.Delete"), slog.Int("severity", severity), logattr.Err(err))
+		}
+		return err
+	}
+
+	return nil
+}
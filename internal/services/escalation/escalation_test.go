@@ -0,0 +1,76 @@
+package escalation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ocenb/geo-alerts/internal/domain/errs"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/logger"
+)
+
+type EscalationServiceSuite struct {
+	suite.Suite
+	mockPolicyRepo *MockPolicyRepo
+	service        *Service
+}
+
+func (s *EscalationServiceSuite) SetupTest() {
+	s.mockPolicyRepo = NewMockPolicyRepo(s.T())
+	s.service = New(logger.NewDiscard(), s.mockPolicyRepo)
+}
+
+func TestEscalationServiceSuite(t *testing.T) {
+	suite.Run(t, new(EscalationServiceSuite))
+}
+
+func (s *EscalationServiceSuite) TestList_Success() {
+	ctx := context.Background()
+	expected := []models.EscalationPolicy{{Severity: 4, RealertAfter: 600}, {Severity: 5, RealertAfter: 300, EscalateAfter: 1800}}
+
+	s.mockPolicyRepo.On("ListPolicies", ctx).Return(expected, nil)
+
+	res, err := s.service.List(ctx)
+
+	s.NoError(err)
+	s.Equal(expected, res)
+}
+
+func (s *EscalationServiceSuite) TestUpdate_Success() {
+	ctx := context.Background()
+	params := &models.UpdateEscalationPolicyParams{Severity: 5, RealertAfter: 600, EscalateAfter: 1800}
+	expected := &models.EscalationPolicy{Severity: 5, RealertAfter: 600, EscalateAfter: 1800}
+
+	s.mockPolicyRepo.On("UpsertPolicy", ctx, params).Return(expected, nil)
+
+	res, err := s.service.Update(ctx, params)
+
+	s.NoError(err)
+	s.Equal(expected, res)
+}
+
+func (s *EscalationServiceSuite) TestUpdate_RepoError() {
+	ctx := context.Background()
+	params := &models.UpdateEscalationPolicyParams{Severity: 5, RealertAfter: 600}
+	dbErr := errors.New("db error")
+
+	s.mockPolicyRepo.On("UpsertPolicy", ctx, params).Return(nil, dbErr)
+
+	res, err := s.service.Update(ctx, params)
+
+	s.ErrorIs(err, dbErr)
+	s.Nil(res)
+}
+
+func (s *EscalationServiceSuite) TestDelete_NotFound() {
+	ctx := context.Background()
+
+	s.mockPolicyRepo.On("DeletePolicy", ctx, 3).Return(errs.ErrEscalationPolicyNotFound)
+
+	err := s.service.Delete(ctx, 3)
+
+	s.ErrorIs(err, errs.ErrEscalationPolicyNotFound)
+}
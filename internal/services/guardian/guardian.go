@@ -0,0 +1,133 @@
+package guardian
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/ocenb/geo-alerts/internal/domain/errs"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/logger/logattr"
+)
+
+type GuardianRepo interface {
+	Create(ctx context.Context, params *models.CreateGuardianGroupParams) (*models.GuardianGroup, error)
+	GetByID(ctx context.Context, id int64) (*models.GuardianGroup, error)
+	List(ctx context.Context, limit, offset int) ([]models.GuardianGroup, error)
+	Delete(ctx context.Context, id int64) error
+	AddMembers(ctx context.Context, groupID int64, userIDs []string) error
+	RemoveMember(ctx context.Context, groupID int64, userID string) error
+	SetConsent(ctx context.Context, groupID int64, userID string, consent bool) (*models.GroupMember, error)
+	ListMemberships(ctx context.Context, userID string) ([]models.GroupMember, error)
+}
+
+type Service struct {
+	log          *slog.Logger
+	guardianRepo GuardianRepo
+}
+
+func New(log *slog.Logger, guardianRepo GuardianRepo) *Service {
+	return &Service{
+		log:          log,
+		guardianRepo: guardianRepo,
+	}
+}
+
+// Create saves the group. Its members aren't alerted on until each of them consents.
+func (s *Service) Create(ctx context.Context, params *models.CreateGuardianGroupParams) (*models.GuardianGroup, error) {
+	group, err := s.guardianRepo.Create(ctx, params)
+	if err != nil {
+		s.log.Error("failed to create guardian group", logattr.Op("GuardianService.Create"), logattr.Err(err))
+		return nil, err
+	}
+
+	s.log.Info("guardian group created",
+		slog.Int64("group_id", group.ID),
+		slog.Int("guardians", len(group.Guardians)),
+		slog.Int("members", len(group.Members)),
+	)
+	return group, nil
+}
+
+func (s *Service) GetByID(ctx context.Context, id int64) (*models.GuardianGroup, error) {
+	group, err := s.guardianRepo.GetByID(ctx, id)
+	if err != nil {
+		if !errors.Is(err, errs.ErrGuardianGroupNotFound) {
+			s.log.Error("failed to get guardian group", logattr.Op("GuardianService.GetByID"), slog.Int64("id", id), logattr.Err(err))
+		}
+		return nil, err
+	}
+
+	return group, nil
+}
+
+func (s *Service) List(ctx context.Context, limit, offset int) ([]models.GuardianGroup, error) {
+	groups, err := s.guardianRepo.List(ctx, limit, offset)
+	if err != nil {
+		s.log.Error("failed to list guardian groups", logattr.Op("GuardianService.List"), logattr.Err(err))
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+func (s *Service) Delete(ctx context.Context, id int64) error {
+	if err := s.guardianRepo.Delete(ctx, id); err != nil {
+		if !errors.Is(err, errs.ErrGuardianGroupNotFound) {
+			s.log.Error("failed to delete guardian group", logattr.Op("GuardianService.Delete"), slog.Int64("id", id), logattr.Err(err))
+		}
+		return err
+	}
+
+	s.log.Info("guardian group deleted", slog.Int64("group_id", id))
+	return nil
+}
+
+// AddMembers adds the users to the group pending their consent and returns the group.
+func (s *Service) AddMembers(ctx context.Context, groupID int64, userIDs []string) (*models.GuardianGroup, error) {
+	if _, err := s.GetByID(ctx, groupID); err != nil {
+		return nil, err
+	}
+
+	if err := s.guardianRepo.AddMembers(ctx, groupID, userIDs); err != nil {
+		s.log.Error("failed to add group members", logattr.Op("GuardianService.AddMembers"), slog.Int64("group_id", groupID), logattr.Err(err))
+		return nil, err
+	}
+
+	return s.GetByID(ctx, groupID)
+}
+
+func (s *Service) RemoveMember(ctx context.Context, groupID int64, userID string) error {
+	if err := s.guardianRepo.RemoveMember(ctx, groupID, userID); err != nil {
+		if !errors.Is(err, errs.ErrGroupMemberNotFound) {
+			s.log.Error("failed to remove group member", logattr.Op("GuardianService.RemoveMember"), slog.Int64("group_id", groupID), logattr.Err(err))
+		}
+		return err
+	}
+
+	return nil
+}
+
+// SetConsent opts the member in to their guardians being alerted when they are in danger, or out of it.
+func (s *Service) SetConsent(ctx context.Context, groupID int64, userID string, consent bool) (*models.GroupMember, error) {
+	member, err := s.guardianRepo.SetConsent(ctx, groupID, userID, consent)
+	if err != nil {
+		if !errors.Is(err, errs.ErrGroupMemberNotFound) {
+			s.log.Error("failed to set consent", logattr.Op("GuardianService.SetConsent"), slog.Int64("group_id", groupID), logattr.Err(err))
+		}
+		return nil, err
+	}
+
+	s.log.Info("group member consent changed", slog.Int64("group_id", groupID), slog.String("status", member.Status))
+	return member, nil
+}
+
+func (s *Service) ListMemberships(ctx context.Context, userID string) ([]models.GroupMember, error) {
+	members, err := s.guardianRepo.ListMemberships(ctx, userID)
+	if err != nil {
+		s.log.Error("failed to list memberships", logattr.Op("GuardianService.ListMemberships"), logattr.Err(err))
+		return nil, err
+	}
+
+	return members, nil
+}
@@ -0,0 +1,146 @@
+package guardian
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ocenb/geo-alerts/internal/domain/errs"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/logger"
+)
+
+type GuardianServiceSuite struct {
+	suite.Suite
+	mockGuardianRepo *MockGuardianRepo
+	service          *Service
+}
+
+func (s *GuardianServiceSuite) SetupTest() {
+	s.mockGuardianRepo = NewMockGuardianRepo(s.T())
+	s.service = New(logger.NewDiscard(), s.mockGuardianRepo)
+}
+
+func TestGuardianServiceSuite(t *testing.T) {
+	suite.Run(t, new(GuardianServiceSuite))
+}
+
+// --- Tests for Create ---
+
+func (s *GuardianServiceSuite) TestCreate_Success() {
+	ctx := context.Background()
+	params := &models.CreateGuardianGroupParams{
+		Name:      "Class 5B",
+		Guardians: []models.GuardianParams{{Channel: models.GuardianEmail, Address: "school@example.com"}},
+		UserIDs:   []string{"u1", "u2"},
+	}
+	expected := &models.GuardianGroup{
+		ID:        1,
+		Name:      "Class 5B",
+		Guardians: []models.Guardian{{ID: 1, GroupID: 1, Channel: models.GuardianEmail, Address: "school@example.com"}},
+		Members: []models.GroupMember{
+			{GroupID: 1, UserID: "u1", Status: models.MemberPending},
+			{GroupID: 1, UserID: "u2", Status: models.MemberPending},
+		},
+	}
+
+	s.mockGuardianRepo.On("Create", ctx, params).Return(expected, nil)
+
+	res, err := s.service.Create(ctx, params)
+
+	s.NoError(err)
+	s.Equal(expected, res)
+}
+
+func (s *GuardianServiceSuite) TestCreate_RepoError() {
+	ctx := context.Background()
+	params := &models.CreateGuardianGroupParams{Name: "Class 5B"}
+	dbErr := errors.New("db error")
+
+	s.mockGuardianRepo.On("Create", ctx, params).Return(nil, dbErr)
+
+	res, err := s.service.Create(ctx, params)
+
+	s.ErrorIs(err, dbErr)
+	s.Nil(res)
+}
+
+// --- Tests for AddMembers ---
+
+func (s *GuardianServiceSuite) TestAddMembers_Success() {
+	ctx := context.Background()
+	group := &models.GuardianGroup{ID: 1}
+	updated := &models.GuardianGroup{ID: 1, Members: []models.GroupMember{{GroupID: 1, UserID: "u3", Status: models.MemberPending}}}
+
+	s.mockGuardianRepo.On("GetByID", ctx, int64(1)).Return(group, nil).Once()
+	s.mockGuardianRepo.On("AddMembers", ctx, int64(1), []string{"u3"}).Return(nil)
+	s.mockGuardianRepo.On("GetByID", ctx, int64(1)).Return(updated, nil).Once()
+
+	res, err := s.service.AddMembers(ctx, 1, []string{"u3"})
+
+	s.NoError(err)
+	s.Equal(updated, res)
+}
+
+func (s *GuardianServiceSuite) TestAddMembers_GroupNotFound() {
+	ctx := context.Background()
+
+	s.mockGuardianRepo.On("GetByID", ctx, int64(9)).Return(nil, errs.ErrGuardianGroupNotFound)
+
+	res, err := s.service.AddMembers(ctx, 9, []string{"u1"})
+
+	s.ErrorIs(err, errs.ErrGuardianGroupNotFound)
+	s.Nil(res)
+	s.mockGuardianRepo.AssertNotCalled(s.T(), "AddMembers")
+}
+
+// --- Tests for SetConsent ---
+
+func (s *GuardianServiceSuite) TestSetConsent_Consent() {
+	ctx := context.Background()
+	expected := &models.GroupMember{GroupID: 1, UserID: "u1", Status: models.MemberConsented}
+
+	s.mockGuardianRepo.On("SetConsent", ctx, int64(1), "u1", true).Return(expected, nil)
+
+	res, err := s.service.SetConsent(ctx, 1, "u1", true)
+
+	s.NoError(err)
+	s.Equal(expected, res)
+}
+
+func (s *GuardianServiceSuite) TestSetConsent_Revoke() {
+	ctx := context.Background()
+	expected := &models.GroupMember{GroupID: 1, UserID: "u1", Status: models.MemberRevoked}
+
+	s.mockGuardianRepo.On("SetConsent", ctx, int64(1), "u1", false).Return(expected, nil)
+
+	res, err := s.service.SetConsent(ctx, 1, "u1", false)
+
+	s.NoError(err)
+	s.Equal(models.MemberRevoked, res.Status)
+}
+
+func (s *GuardianServiceSuite) TestSetConsent_NotMember() {
+	ctx := context.Background()
+
+	s.mockGuardianRepo.On("SetConsent", ctx, int64(1), "stranger", true).Return(nil, errs.ErrGroupMemberNotFound)
+
+	res, err := s.service.SetConsent(ctx, 1, "stranger", true)
+
+	s.ErrorIs(err, errs.ErrGroupMemberNotFound)
+	s.Nil(res)
+}
+
+// --- Tests for Delete ---
+
+func (s *GuardianServiceSuite) TestDelete_NotFound() {
+	ctx := context.Background()
+
+	s.mockGuardianRepo.On("Delete", ctx, int64(5)).Return(errs.ErrGuardianGroupNotFound)
+
+	err := s.service.Delete(ctx, 5)
+
+	s.ErrorIs(err, errs.ErrGuardianGroupNotFound)
+}
@@ -15,29 +15,38 @@ import (
 type IncidentRepo interface {
 	Create(ctx context.Context, params *models.CreateIncidentParams) (*models.Incident, error)
 	GetByID(ctx context.Context, id int64) (*models.Incident, error)
-	Update(ctx context.Context, params *models.UpdateIncidentParams) (*models.Incident, error)
-	Deactivate(ctx context.Context, id int64) error
+	Update(ctx context.Context, params *models.UpdateIncidentParams) (updated, previous *models.Incident, err error)
+	Deactivate(ctx context.Context, id int64) (*models.Incident, error)
 	List(ctx context.Context, limit, offset int) ([]models.Incident, error)
-	GetStats(ctx context.Context, window time.Duration) ([]models.Stats, error)
+	GetStats(ctx context.Context, window time.Duration, countPossiblyInside bool) ([]models.Stats, error)
 }
 
 type CacheRepo interface {
 	InvalidateActiveIncidents(ctx context.Context) error
 }
 
+type QueueProducer interface {
+	EnqueueIncidentEvent(ctx context.Context, event string, incident, previous *models.Incident) error
+	EnqueueRetroactiveAlert(ctx context.Context, incidentID int64, since time.Time) error
+	EnqueuePlaceAlertScan(ctx context.Context, incidentID int64) error
+	EnqueueEscalationCancel(ctx context.Context, incidentID int64) error
+}
+
 type Service struct {
 	log       *slog.Logger
 	cfg       config.AppConfig
 	incRepo   IncidentRepo
 	cacheRepo CacheRepo
+	queue     QueueProducer
 }
 
-func New(log *slog.Logger, cfg config.AppConfig, incRepo IncidentRepo, cacheRepo CacheRepo) *Service {
+func New(log *slog.Logger, cfg config.AppConfig, incRepo IncidentRepo, cacheRepo CacheRepo, queue QueueProducer) *Service {
 	return &Service{
 		log:       log,
 		cfg:       cfg,
 		incRepo:   incRepo,
 		cacheRepo: cacheRepo,
+		queue:     queue,
 	}
 }
 
@@ -72,6 +81,12 @@ func (s *Service) Create(ctx context.Context, params *models.CreateIncidentParam
 		log.Warn("failed to invalidate cache", logattr.Err(err))
 	}
 
+	s.publish(ctx, log, models.EventIncidentCreated, created, nil)
+	s.alertPlaces(ctx, log, created)
+	if params.AlertRecentUsers {
+		s.alertRecentUsers(ctx, log, created)
+	}
+
 	return created, nil
 }
 
@@ -81,7 +96,8 @@ func (s *Service) Update(ctx context.Context, params *models.UpdateIncidentParam
 		slog.Int64("id", params.ID),
 	)
 
-	updated, err := s.incRepo.Update(ctx, params)
+	// Subscribers get the state before the update along with the new one.
+	updated, previous, err := s.incRepo.Update(ctx, params)
 	if err != nil {
 		if !errors.Is(err, errs.ErrIncidentNotFound) && !errors.Is(err, errs.ErrIncidentExists) {
 			log.Error("failed to update incident", logattr.Err(err))
@@ -93,6 +109,12 @@ func (s *Service) Update(ctx context.Context, params *models.UpdateIncidentParam
 		log.Warn("failed to invalidate cache", logattr.Err(err))
 	}
 
+	s.publish(ctx, log, models.EventIncidentUpdated, updated, previous)
+	s.alertPlaces(ctx, log, updated)
+	if params.AlertRecentUsers {
+		s.alertRecentUsers(ctx, log, updated)
+	}
+
 	return updated, nil
 }
 
@@ -102,7 +124,7 @@ func (s *Service) Deactivate(ctx context.Context, id int64) error {
 		slog.Int64("id", id),
 	)
 
-	err := s.incRepo.Deactivate(ctx, id)
+	deactivated, err := s.incRepo.Deactivate(ctx, id)
 	if err != nil {
 		if !errors.Is(err, errs.ErrIncidentNotFound) {
 			log.Error("failed to deactivate incident", logattr.Err(err))
@@ -114,9 +136,49 @@ func (s *Service) Deactivate(ctx context.Context, id int64) error {
 		log.Warn("failed to invalidate cache", logattr.Err(err))
 	}
 
+	// Deactivating an inactive incident changes nothing, subscribers have already been told.
+	if deactivated != nil {
+		s.publish(ctx, log, models.EventIncidentDeactivated, deactivated, nil)
+		// Follow-ups left behind find the incident inactive and do nothing, so a failure is only logged.
+		if err := s.queue.EnqueueEscalationCancel(ctx, id); err != nil {
+			log.Error("failed to enqueue escalation cancellation", logattr.Err(err))
+		}
+	}
+
 	return nil
 }
 
+// publish enqueues an incident event for webhook subscribers. The change is already stored,
+// so a failure is only logged.
+func (s *Service) publish(ctx context.Context, log *slog.Logger, event string, incident, previous *models.Incident) {
+	if err := s.queue.EnqueueIncidentEvent(ctx, event, incident, previous); err != nil {
+		log.Error("failed to enqueue incident event", slog.String("event", event), logattr.Err(err))
+	}
+}
+
+// alertRecentUsers queues alerts for the users whose last check within the look-back window is in the zone
+// of the active incident. Like publish, a failure is only logged.
+func (s *Service) alertRecentUsers(ctx context.Context, log *slog.Logger, incident *models.Incident) {
+	if !incident.IsActive {
+		return
+	}
+	since := time.Now().Add(-s.cfg.RetroactiveAlertWindow)
+	if err := s.queue.EnqueueRetroactiveAlert(ctx, incident.ID, since); err != nil {
+		log.Error("failed to enqueue retroactive alerts", logattr.Err(err))
+	}
+}
+
+// alertPlaces queues alerts for the saved places the zone of the active incident reaches into.
+// Like publish, a failure is only logged.
+func (s *Service) alertPlaces(ctx context.Context, log *slog.Logger, incident *models.Incident) {
+	if !incident.IsActive {
+		return
+	}
+	if err := s.queue.EnqueuePlaceAlertScan(ctx, incident.ID); err != nil {
+		log.Error("failed to enqueue place alerts", logattr.Err(err))
+	}
+}
+
 func (s *Service) List(ctx context.Context, limit, offset int) ([]models.Incident, error) {
 	list, err := s.incRepo.List(ctx, limit, offset)
 	if err != nil {
@@ -128,7 +190,7 @@ func (s *Service) List(ctx context.Context, limit, offset int) ([]models.Inciden
 }
 
 func (s *Service) GetStats(ctx context.Context) ([]models.Stats, error) {
-	stats, err := s.incRepo.GetStats(ctx, s.cfg.StatsTimeWindow)
+	stats, err := s.incRepo.GetStats(ctx, s.cfg.StatsTimeWindow, s.cfg.StatsCountPossiblyInside)
 	if err != nil {
 		s.log.Error("failed to get unique users stats", logattr.Err(err))
 		return nil, err
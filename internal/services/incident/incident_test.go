@@ -19,15 +19,18 @@ type IncidentServiceSuite struct {
 	suite.Suite
 	mockInc   *MockIncidentRepo
 	mockCache *MockCacheRepo
+	mockQueue *MockQueueProducer
 	service   *Service
 }
 
 func (s *IncidentServiceSuite) SetupTest() {
 	s.mockInc = NewMockIncidentRepo(s.T())
 	s.mockCache = NewMockCacheRepo(s.T())
+	s.mockQueue = NewMockQueueProducer(s.T())
 
 	cfg := config.AppConfig{
-		StatsTimeWindow: 15 * time.Minute,
+		StatsTimeWindow:        15 * time.Minute,
+		RetroactiveAlertWindow: 10 * time.Minute,
 	}
 
 	s.service = New(
@@ -35,6 +38,7 @@ func (s *IncidentServiceSuite) SetupTest() {
 		cfg,
 		s.mockInc,
 		s.mockCache,
+		s.mockQueue,
 	)
 }
 
@@ -77,6 +81,8 @@ func (s *IncidentServiceSuite) TestCreate_Success() {
 	s.mockInc.On("Create", mock.Anything, params).Return(created, nil)
 
 	s.mockCache.On("InvalidateActiveIncidents", mock.Anything).Return(nil)
+	s.mockQueue.On("EnqueueIncidentEvent", mock.Anything, models.EventIncidentCreated, created, (*models.Incident)(nil)).Return(nil)
+	s.mockQueue.On("EnqueuePlaceAlertScan", mock.Anything, int64(1)).Return(nil)
 
 	res, err := s.service.Create(ctx, params)
 
@@ -84,6 +90,61 @@ func (s *IncidentServiceSuite) TestCreate_Success() {
 	s.Equal(created, res)
 }
 
+func (s *IncidentServiceSuite) TestCreate_PlaceAlertEnqueueError() {
+	ctx := context.Background()
+	params := &models.CreateIncidentParams{Latitude: 10, Longitude: 10, Radius: 100}
+	created := &models.Incident{ID: 1, Latitude: 10, Longitude: 10, Radius: 100, IsActive: true}
+
+	s.mockInc.On("Create", mock.Anything, params).Return(created, nil)
+	s.mockCache.On("InvalidateActiveIncidents", mock.Anything).Return(nil)
+	s.mockQueue.On("EnqueueIncidentEvent", mock.Anything, models.EventIncidentCreated, created, (*models.Incident)(nil)).Return(nil)
+	s.mockQueue.On("EnqueuePlaceAlertScan", mock.Anything, int64(1)).Return(errors.New("redis down"))
+
+	res, err := s.service.Create(ctx, params)
+
+	// The incident is stored, so the failure doesn't fail the request.
+	s.NoError(err)
+	s.Equal(created, res)
+}
+
+func (s *IncidentServiceSuite) TestCreate_AlertRecentUsers() {
+	ctx := context.Background()
+	params := &models.CreateIncidentParams{Latitude: 10, Longitude: 10, Radius: 100, AlertRecentUsers: true}
+	created := &models.Incident{ID: 1, Latitude: 10, Longitude: 10, Radius: 100, IsActive: true}
+
+	s.mockInc.On("Create", mock.Anything, params).Return(created, nil)
+	s.mockCache.On("InvalidateActiveIncidents", mock.Anything).Return(nil)
+	s.mockQueue.On("EnqueueIncidentEvent", mock.Anything, models.EventIncidentCreated, created, (*models.Incident)(nil)).Return(nil)
+	s.mockQueue.On("EnqueuePlaceAlertScan", mock.Anything, int64(1)).Return(nil)
+	s.mockQueue.On("EnqueueRetroactiveAlert", mock.Anything, int64(1), mock.MatchedBy(func(since time.Time) bool {
+		ago := time.Since(since)
+		return ago >= 10*time.Minute && ago < 11*time.Minute
+	})).Return(nil)
+
+	res, err := s.service.Create(ctx, params)
+
+	s.NoError(err)
+	s.Equal(created, res)
+}
+
+func (s *IncidentServiceSuite) TestCreate_AlertRecentUsersEnqueueError() {
+	ctx := context.Background()
+	params := &models.CreateIncidentParams{Latitude: 10, Longitude: 10, Radius: 100, AlertRecentUsers: true}
+	created := &models.Incident{ID: 1, Latitude: 10, Longitude: 10, Radius: 100, IsActive: true}
+
+	s.mockInc.On("Create", mock.Anything, params).Return(created, nil)
+	s.mockCache.On("InvalidateActiveIncidents", mock.Anything).Return(nil)
+	s.mockQueue.On("EnqueueIncidentEvent", mock.Anything, models.EventIncidentCreated, created, (*models.Incident)(nil)).Return(nil)
+	s.mockQueue.On("EnqueuePlaceAlertScan", mock.Anything, int64(1)).Return(nil)
+	s.mockQueue.On("EnqueueRetroactiveAlert", mock.Anything, int64(1), mock.Anything).Return(errors.New("redis down"))
+
+	res, err := s.service.Create(ctx, params)
+
+	// The incident is stored, so the failure doesn't fail the request.
+	s.NoError(err)
+	s.Equal(created, res)
+}
+
 func (s *IncidentServiceSuite) TestCreate_RepoError() {
 	ctx := context.Background()
 	params := &models.CreateIncidentParams{Latitude: 10, Longitude: 10}
@@ -103,6 +164,22 @@ func (s *IncidentServiceSuite) TestCreate_CacheInvalidationError() {
 
 	s.mockInc.On("Create", mock.Anything, params).Return(created, nil)
 	s.mockCache.On("InvalidateActiveIncidents", mock.Anything).Return(errors.New("redis down"))
+	s.mockQueue.On("EnqueueIncidentEvent", mock.Anything, models.EventIncidentCreated, created, (*models.Incident)(nil)).Return(nil)
+
+	res, err := s.service.Create(ctx, params)
+
+	s.NoError(err)
+	s.Equal(created, res)
+}
+
+func (s *IncidentServiceSuite) TestCreate_EnqueueEventError() {
+	ctx := context.Background()
+	params := &models.CreateIncidentParams{Latitude: 10}
+	created := &models.Incident{ID: 1}
+
+	s.mockInc.On("Create", mock.Anything, params).Return(created, nil)
+	s.mockCache.On("InvalidateActiveIncidents", mock.Anything).Return(nil)
+	s.mockQueue.On("EnqueueIncidentEvent", mock.Anything, models.EventIncidentCreated, created, (*models.Incident)(nil)).Return(errors.New("redis down"))
 
 	res, err := s.service.Create(ctx, params)
 
@@ -114,11 +191,13 @@ func (s *IncidentServiceSuite) TestCreate_CacheInvalidationError() {
 
 func (s *IncidentServiceSuite) TestUpdate_Success() {
 	ctx := context.Background()
-	params := &models.UpdateIncidentParams{ID: 1}
-	updated := &models.Incident{ID: 1}
+	params := &models.UpdateIncidentParams{ID: 1, Radius: 200}
+	previous := &models.Incident{ID: 1, Radius: 100}
+	updated := &models.Incident{ID: 1, Radius: 200}
 
-	s.mockInc.On("Update", mock.Anything, params).Return(updated, nil)
+	s.mockInc.On("Update", mock.Anything, params).Return(updated, previous, nil)
 	s.mockCache.On("InvalidateActiveIncidents", mock.Anything).Return(nil)
+	s.mockQueue.On("EnqueueIncidentEvent", mock.Anything, models.EventIncidentUpdated, updated, previous).Return(nil)
 
 	res, err := s.service.Update(ctx, params)
 
@@ -126,11 +205,47 @@ func (s *IncidentServiceSuite) TestUpdate_Success() {
 	s.Equal(updated, res)
 }
 
+func (s *IncidentServiceSuite) TestUpdate_AlertRecentUsers() {
+	ctx := context.Background()
+	params := &models.UpdateIncidentParams{ID: 1, Radius: 200, AlertRecentUsers: true}
+	previous := &models.Incident{ID: 1, Radius: 100, IsActive: true}
+	updated := &models.Incident{ID: 1, Radius: 200, IsActive: true}
+
+	s.mockInc.On("Update", mock.Anything, params).Return(updated, previous, nil)
+	s.mockCache.On("InvalidateActiveIncidents", mock.Anything).Return(nil)
+	s.mockQueue.On("EnqueueIncidentEvent", mock.Anything, models.EventIncidentUpdated, updated, previous).Return(nil)
+	s.mockQueue.On("EnqueuePlaceAlertScan", mock.Anything, int64(1)).Return(nil)
+	s.mockQueue.On("EnqueueRetroactiveAlert", mock.Anything, int64(1), mock.Anything).Return(nil)
+
+	res, err := s.service.Update(ctx, params)
+
+	s.NoError(err)
+	s.Equal(updated, res)
+}
+
+func (s *IncidentServiceSuite) TestUpdate_AlertRecentUsersInactive() {
+	ctx := context.Background()
+	params := &models.UpdateIncidentParams{ID: 1, Radius: 200, AlertRecentUsers: true}
+	previous := &models.Incident{ID: 1, Radius: 100}
+	updated := &models.Incident{ID: 1, Radius: 200}
+
+	s.mockInc.On("Update", mock.Anything, params).Return(updated, previous, nil)
+	s.mockCache.On("InvalidateActiveIncidents", mock.Anything).Return(nil)
+	s.mockQueue.On("EnqueueIncidentEvent", mock.Anything, models.EventIncidentUpdated, updated, previous).Return(nil)
+
+	res, err := s.service.Update(ctx, params)
+
+	s.NoError(err)
+	s.Equal(updated, res)
+	s.mockQueue.AssertNotCalled(s.T(), "EnqueueRetroactiveAlert", mock.Anything, mock.Anything, mock.Anything)
+	s.mockQueue.AssertNotCalled(s.T(), "EnqueuePlaceAlertScan", mock.Anything, mock.Anything)
+}
+
 func (s *IncidentServiceSuite) TestUpdate_NotFound() {
 	ctx := context.Background()
 	params := &models.UpdateIncidentParams{ID: 1}
 
-	s.mockInc.On("Update", mock.Anything, params).Return(nil, errs.ErrIncidentNotFound)
+	s.mockInc.On("Update", mock.Anything, params).Return(nil, nil, errs.ErrIncidentNotFound)
 
 	res, err := s.service.Update(ctx, params)
 
@@ -138,23 +253,66 @@ func (s *IncidentServiceSuite) TestUpdate_NotFound() {
 	s.Nil(res)
 }
 
+func (s *IncidentServiceSuite) TestUpdate_Conflict() {
+	ctx := context.Background()
+	params := &models.UpdateIncidentParams{ID: 1}
+
+	s.mockInc.On("Update", mock.Anything, params).Return(nil, nil, errs.ErrIncidentExists)
+
+	res, err := s.service.Update(ctx, params)
+
+	s.ErrorIs(err, errs.ErrIncidentExists)
+	s.Nil(res)
+}
+
 // --- Tests for Deactivate ---
 
 func (s *IncidentServiceSuite) TestDeactivate_Success() {
 	ctx := context.Background()
 
-	s.mockInc.On("Deactivate", mock.Anything, int64(1)).Return(nil)
+	deactivated := &models.Incident{ID: 1, IsActive: false}
+
+	s.mockInc.On("Deactivate", mock.Anything, int64(1)).Return(deactivated, nil)
+	s.mockCache.On("InvalidateActiveIncidents", mock.Anything).Return(nil)
+	s.mockQueue.On("EnqueueIncidentEvent", mock.Anything, models.EventIncidentDeactivated, deactivated, (*models.Incident)(nil)).Return(nil)
+	s.mockQueue.On("EnqueueEscalationCancel", mock.Anything, int64(1)).Return(nil)
+
+	err := s.service.Deactivate(ctx, 1)
+
+	s.NoError(err)
+}
+
+func (s *IncidentServiceSuite) TestDeactivate_EscalationCancelError() {
+	ctx := context.Background()
+	deactivated := &models.Incident{ID: 1}
+
+	s.mockInc.On("Deactivate", mock.Anything, int64(1)).Return(deactivated, nil)
+	s.mockCache.On("InvalidateActiveIncidents", mock.Anything).Return(nil)
+	s.mockQueue.On("EnqueueIncidentEvent", mock.Anything, models.EventIncidentDeactivated, deactivated, (*models.Incident)(nil)).Return(nil)
+	s.mockQueue.On("EnqueueEscalationCancel", mock.Anything, int64(1)).Return(errors.New("redis down"))
+
+	err := s.service.Deactivate(ctx, 1)
+
+	s.NoError(err)
+}
+
+func (s *IncidentServiceSuite) TestDeactivate_AlreadyInactive() {
+	ctx := context.Background()
+
+	s.mockInc.On("Deactivate", mock.Anything, int64(1)).Return(nil, nil)
 	s.mockCache.On("InvalidateActiveIncidents", mock.Anything).Return(nil)
 
 	err := s.service.Deactivate(ctx, 1)
 
 	s.NoError(err)
+	s.mockQueue.AssertNotCalled(s.T(), "EnqueueIncidentEvent", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	s.mockQueue.AssertNotCalled(s.T(), "EnqueueEscalationCancel", mock.Anything, mock.Anything)
 }
 
 func (s *IncidentServiceSuite) TestDeactivate_NotFound() {
 	ctx := context.Background()
 
-	s.mockInc.On("Deactivate", mock.Anything, int64(1)).Return(errs.ErrIncidentNotFound)
+	s.mockInc.On("Deactivate", mock.Anything, int64(1)).Return(nil, errs.ErrIncidentNotFound)
 
 	err := s.service.Deactivate(ctx, 1)
 
@@ -168,7 +326,7 @@ func (s *IncidentServiceSuite) TestGetStats_Success() {
 	window := 15 * time.Minute
 	expected := []models.Stats{{IncidentID: 1, UserCount: 100}}
 
-	s.mockInc.On("GetStats", mock.Anything, window).Return(expected, nil)
+	s.mockInc.On("GetStats", mock.Anything, window, false).Return(expected, nil)
 
 	res, err := s.service.GetStats(ctx)
 
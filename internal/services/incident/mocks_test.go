@@ -108,20 +108,31 @@ func (_c *MockIncidentRepo_Create_Call) RunAndReturn(run func(ctx context.Contex
 }
 
 // Deactivate provides a mock function for the type MockIncidentRepo
-func (_mock *MockIncidentRepo) Deactivate(ctx context.Context, id int64) error {
+func (_mock *MockIncidentRepo) Deactivate(ctx context.Context, id int64) (*models.Incident, error) {
 	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Deactivate")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+	var r0 *models.Incident
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) (*models.Incident, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) *models.Incident); ok {
 		r0 = returnFunc(ctx, id)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Incident)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
 // MockIncidentRepo_Deactivate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Deactivate'
@@ -154,12 +165,12 @@ func (_c *MockIncidentRepo_Deactivate_Call) Run(run func(ctx context.Context, id
 	return _c
 }
 
-func (_c *MockIncidentRepo_Deactivate_Call) Return(err error) *MockIncidentRepo_Deactivate_Call {
-	_c.Call.Return(err)
+func (_c *MockIncidentRepo_Deactivate_Call) Return(incident *models.Incident, err error) *MockIncidentRepo_Deactivate_Call {
+	_c.Call.Return(incident, err)
 	return _c
 }
 
-func (_c *MockIncidentRepo_Deactivate_Call) RunAndReturn(run func(ctx context.Context, id int64) error) *MockIncidentRepo_Deactivate_Call {
+func (_c *MockIncidentRepo_Deactivate_Call) RunAndReturn(run func(ctx context.Context, id int64) (*models.Incident, error)) *MockIncidentRepo_Deactivate_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -233,8 +244,8 @@ func (_c *MockIncidentRepo_GetByID_Call) RunAndReturn(run func(ctx context.Conte
 }
 
 // GetStats provides a mock function for the type MockIncidentRepo
-func (_mock *MockIncidentRepo) GetStats(ctx context.Context, window time.Duration) ([]models.Stats, error) {
-	ret := _mock.Called(ctx, window)
+func (_mock *MockIncidentRepo) GetStats(ctx context.Context, window time.Duration, countPossiblyInside bool) ([]models.Stats, error) {
+	ret := _mock.Called(ctx, window, countPossiblyInside)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetStats")
@@ -242,18 +253,18 @@ func (_mock *MockIncidentRepo) GetStats(ctx context.Context, window time.Duratio
 
 	var r0 []models.Stats
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Duration) ([]models.Stats, error)); ok {
-		return returnFunc(ctx, window)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Duration, bool) ([]models.Stats, error)); ok {
+		return returnFunc(ctx, window, countPossiblyInside)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Duration) []models.Stats); ok {
-		r0 = returnFunc(ctx, window)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Duration, bool) []models.Stats); ok {
+		r0 = returnFunc(ctx, window, countPossiblyInside)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]models.Stats)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Duration) error); ok {
-		r1 = returnFunc(ctx, window)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Duration, bool) error); ok {
+		r1 = returnFunc(ctx, window, countPossiblyInside)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -268,11 +279,12 @@ type MockIncidentRepo_GetStats_Call struct {
 // GetStats is a helper method to define mock.On call
 //   - ctx context.Context
 //   - window time.Duration
-func (_e *MockIncidentRepo_Expecter) GetStats(ctx interface{}, window interface{}) *MockIncidentRepo_GetStats_Call {
-	return &MockIncidentRepo_GetStats_Call{Call: _e.mock.On("GetStats", ctx, window)}
+//   - countPossiblyInside bool
+func (_e *MockIncidentRepo_Expecter) GetStats(ctx interface{}, window interface{}, countPossiblyInside interface{}) *MockIncidentRepo_GetStats_Call {
+	return &MockIncidentRepo_GetStats_Call{Call: _e.mock.On("GetStats", ctx, window, countPossiblyInside)}
 }
 
-func (_c *MockIncidentRepo_GetStats_Call) Run(run func(ctx context.Context, window time.Duration)) *MockIncidentRepo_GetStats_Call {
+func (_c *MockIncidentRepo_GetStats_Call) Run(run func(ctx context.Context, window time.Duration, countPossiblyInside bool)) *MockIncidentRepo_GetStats_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -282,9 +294,14 @@ func (_c *MockIncidentRepo_GetStats_Call) Run(run func(ctx context.Context, wind
 		if args[1] != nil {
 			arg1 = args[1].(time.Duration)
 		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
@@ -295,7 +312,7 @@ func (_c *MockIncidentRepo_GetStats_Call) Return(statss []models.Stats, err erro
 	return _c
 }
 
-func (_c *MockIncidentRepo_GetStats_Call) RunAndReturn(run func(ctx context.Context, window time.Duration) ([]models.Stats, error)) *MockIncidentRepo_GetStats_Call {
+func (_c *MockIncidentRepo_GetStats_Call) RunAndReturn(run func(ctx context.Context, window time.Duration, countPossiblyInside bool) ([]models.Stats, error)) *MockIncidentRepo_GetStats_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -375,7 +392,7 @@ func (_c *MockIncidentRepo_List_Call) RunAndReturn(run func(ctx context.Context,
 }
 
 // Update provides a mock function for the type MockIncidentRepo
-func (_mock *MockIncidentRepo) Update(ctx context.Context, params *models.UpdateIncidentParams) (*models.Incident, error) {
+func (_mock *MockIncidentRepo) Update(ctx context.Context, params *models.UpdateIncidentParams) (*models.Incident, *models.Incident, error) {
 	ret := _mock.Called(ctx, params)
 
 	if len(ret) == 0 {
@@ -383,8 +400,9 @@ func (_mock *MockIncidentRepo) Update(ctx context.Context, params *models.Update
 	}
 
 	var r0 *models.Incident
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.UpdateIncidentParams) (*models.Incident, error)); ok {
+	var r1 *models.Incident
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.UpdateIncidentParams) (*models.Incident, *models.Incident, error)); ok {
 		return returnFunc(ctx, params)
 	}
 	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.UpdateIncidentParams) *models.Incident); ok {
@@ -394,12 +412,19 @@ func (_mock *MockIncidentRepo) Update(ctx context.Context, params *models.Update
 			r0 = ret.Get(0).(*models.Incident)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *models.UpdateIncidentParams) error); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *models.UpdateIncidentParams) *models.Incident); ok {
 		r1 = returnFunc(ctx, params)
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*models.Incident)
+		}
 	}
-	return r0, r1
+	if returnFunc, ok := ret.Get(2).(func(context.Context, *models.UpdateIncidentParams) error); ok {
+		r2 = returnFunc(ctx, params)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
 }
 
 // MockIncidentRepo_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
@@ -432,12 +457,12 @@ func (_c *MockIncidentRepo_Update_Call) Run(run func(ctx context.Context, params
 	return _c
 }
 
-func (_c *MockIncidentRepo_Update_Call) Return(incident *models.Incident, err error) *MockIncidentRepo_Update_Call {
-	_c.Call.Return(incident, err)
+func (_c *MockIncidentRepo_Update_Call) Return(updated *models.Incident, previous *models.Incident, err error) *MockIncidentRepo_Update_Call {
+	_c.Call.Return(updated, previous, err)
 	return _c
 }
 
-func (_c *MockIncidentRepo_Update_Call) RunAndReturn(run func(ctx context.Context, params *models.UpdateIncidentParams) (*models.Incident, error)) *MockIncidentRepo_Update_Call {
+func (_c *MockIncidentRepo_Update_Call) RunAndReturn(run func(ctx context.Context, params *models.UpdateIncidentParams) (*models.Incident, *models.Incident, error)) *MockIncidentRepo_Update_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -519,3 +544,276 @@ func (_c *MockCacheRepo_InvalidateActiveIncidents_Call) RunAndReturn(run func(ct
 	_c.Call.Return(run)
 	return _c
 }
+
+// NewMockQueueProducer creates a new instance of MockQueueProducer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockQueueProducer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockQueueProducer {
+	mock := &MockQueueProducer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockQueueProducer is an autogenerated mock type for the QueueProducer type
+type MockQueueProducer struct {
+	mock.Mock
+}
+
+type MockQueueProducer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockQueueProducer) EXPECT() *MockQueueProducer_Expecter {
+	return &MockQueueProducer_Expecter{mock: &_m.Mock}
+}
+
+// EnqueueEscalationCancel provides a mock function for the type MockQueueProducer
+func (_mock *MockQueueProducer) EnqueueEscalationCancel(ctx context.Context, incidentID int64) error {
+	ret := _mock.Called(ctx, incidentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnqueueEscalationCancel")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = returnFunc(ctx, incidentID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQueueProducer_EnqueueEscalationCancel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnqueueEscalationCancel'
+type MockQueueProducer_EnqueueEscalationCancel_Call struct {
+	*mock.Call
+}
+
+// EnqueueEscalationCancel is a helper method to define mock.On call
+//   - ctx context.Context
+//   - incidentID int64
+func (_e *MockQueueProducer_Expecter) EnqueueEscalationCancel(ctx interface{}, incidentID interface{}) *MockQueueProducer_EnqueueEscalationCancel_Call {
+	return &MockQueueProducer_EnqueueEscalationCancel_Call{Call: _e.mock.On("EnqueueEscalationCancel", ctx, incidentID)}
+}
+
+func (_c *MockQueueProducer_EnqueueEscalationCancel_Call) Run(run func(ctx context.Context, incidentID int64)) *MockQueueProducer_EnqueueEscalationCancel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQueueProducer_EnqueueEscalationCancel_Call) Return(err error) *MockQueueProducer_EnqueueEscalationCancel_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQueueProducer_EnqueueEscalationCancel_Call) RunAndReturn(run func(ctx context.Context, incidentID int64) error) *MockQueueProducer_EnqueueEscalationCancel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnqueueIncidentEvent provides a mock function for the type MockQueueProducer
+func (_mock *MockQueueProducer) EnqueueIncidentEvent(ctx context.Context, event string, incident *models.Incident, previous *models.Incident) error {
+	ret := _mock.Called(ctx, event, incident, previous)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnqueueIncidentEvent")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *models.Incident, *models.Incident) error); ok {
+		r0 = returnFunc(ctx, event, incident, previous)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQueueProducer_EnqueueIncidentEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnqueueIncidentEvent'
+type MockQueueProducer_EnqueueIncidentEvent_Call struct {
+	*mock.Call
+}
+
+// EnqueueIncidentEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - event string
+//   - incident *models.Incident
+//   - previous *models.Incident
+func (_e *MockQueueProducer_Expecter) EnqueueIncidentEvent(ctx interface{}, event interface{}, incident interface{}, previous interface{}) *MockQueueProducer_EnqueueIncidentEvent_Call {
+	return &MockQueueProducer_EnqueueIncidentEvent_Call{Call: _e.mock.On("EnqueueIncidentEvent", ctx, event, incident, previous)}
+}
+
+func (_c *MockQueueProducer_EnqueueIncidentEvent_Call) Run(run func(ctx context.Context, event string, incident *models.Incident, previous *models.Incident)) *MockQueueProducer_EnqueueIncidentEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *models.Incident
+		if args[2] != nil {
+			arg2 = args[2].(*models.Incident)
+		}
+		var arg3 *models.Incident
+		if args[3] != nil {
+			arg3 = args[3].(*models.Incident)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQueueProducer_EnqueueIncidentEvent_Call) Return(err error) *MockQueueProducer_EnqueueIncidentEvent_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQueueProducer_EnqueueIncidentEvent_Call) RunAndReturn(run func(ctx context.Context, event string, incident *models.Incident, previous *models.Incident) error) *MockQueueProducer_EnqueueIncidentEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnqueuePlaceAlertScan provides a mock function for the type MockQueueProducer
+func (_mock *MockQueueProducer) EnqueuePlaceAlertScan(ctx context.Context, incidentID int64) error {
+	ret := _mock.Called(ctx, incidentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnqueuePlaceAlertScan")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = returnFunc(ctx, incidentID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQueueProducer_EnqueuePlaceAlertScan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnqueuePlaceAlertScan'
+type MockQueueProducer_EnqueuePlaceAlertScan_Call struct {
+	*mock.Call
+}
+
+// EnqueuePlaceAlertScan is a helper method to define mock.On call
+//   - ctx context.Context
+//   - incidentID int64
+func (_e *MockQueueProducer_Expecter) EnqueuePlaceAlertScan(ctx interface{}, incidentID interface{}) *MockQueueProducer_EnqueuePlaceAlertScan_Call {
+	return &MockQueueProducer_EnqueuePlaceAlertScan_Call{Call: _e.mock.On("EnqueuePlaceAlertScan", ctx, incidentID)}
+}
+
+func (_c *MockQueueProducer_EnqueuePlaceAlertScan_Call) Run(run func(ctx context.Context, incidentID int64)) *MockQueueProducer_EnqueuePlaceAlertScan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQueueProducer_EnqueuePlaceAlertScan_Call) Return(err error) *MockQueueProducer_EnqueuePlaceAlertScan_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQueueProducer_EnqueuePlaceAlertScan_Call) RunAndReturn(run func(ctx context.Context, incidentID int64) error) *MockQueueProducer_EnqueuePlaceAlertScan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnqueueRetroactiveAlert provides a mock function for the type MockQueueProducer
+func (_mock *MockQueueProducer) EnqueueRetroactiveAlert(ctx context.Context, incidentID int64, since time.Time) error {
+	ret := _mock.Called(ctx, incidentID, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnqueueRetroactiveAlert")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, time.Time) error); ok {
+		r0 = returnFunc(ctx, incidentID, since)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQueueProducer_EnqueueRetroactiveAlert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnqueueRetroactiveAlert'
+type MockQueueProducer_EnqueueRetroactiveAlert_Call struct {
+	*mock.Call
+}
+
+// EnqueueRetroactiveAlert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - incidentID int64
+//   - since time.Time
+func (_e *MockQueueProducer_Expecter) EnqueueRetroactiveAlert(ctx interface{}, incidentID interface{}, since interface{}) *MockQueueProducer_EnqueueRetroactiveAlert_Call {
+	return &MockQueueProducer_EnqueueRetroactiveAlert_Call{Call: _e.mock.On("EnqueueRetroactiveAlert", ctx, incidentID, since)}
+}
+
+func (_c *MockQueueProducer_EnqueueRetroactiveAlert_Call) Run(run func(ctx context.Context, incidentID int64, since time.Time)) *MockQueueProducer_EnqueueRetroactiveAlert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQueueProducer_EnqueueRetroactiveAlert_Call) Return(err error) *MockQueueProducer_EnqueueRetroactiveAlert_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQueueProducer_EnqueueRetroactiveAlert_Call) RunAndReturn(run func(ctx context.Context, incidentID int64, since time.Time) error) *MockQueueProducer_EnqueueRetroactiveAlert_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -5,6 +5,7 @@ import (
 	"log/slog"
 	"time"
 
+	"github.com/ocenb/geo-alerts/internal/config"
 	"github.com/ocenb/geo-alerts/internal/domain/errs"
 	"github.com/ocenb/geo-alerts/internal/domain/models"
 	"github.com/ocenb/geo-alerts/internal/logger/logattr"
@@ -13,6 +14,7 @@ import (
 
 type LocationRepo interface {
 	SaveCheckLog(ctx context.Context, check *models.CheckLocationResult) error
+	RecordAlerts(ctx context.Context, userID string, incidentIDs []int64) error
 }
 
 type IncidentRepo interface {
@@ -22,28 +24,51 @@ type IncidentRepo interface {
 type CacheRepo interface {
 	GetActiveIncidents(ctx context.Context) ([]models.IncidentShort, error)
 	SetActiveIncidents(ctx context.Context, incidents []models.IncidentShort) error
+	SwapLastLocation(ctx context.Context, userID string, loc *models.UserLocation) (*models.UserLocation, error)
+}
+
+type PlaceRepo interface {
+	ClaimAffected(ctx context.Context, userID string, incidentID int64) ([]models.PlaceMatch, error)
+	ReleaseAlerts(ctx context.Context, matches []models.PlaceMatch) error
+}
+
+type DwellRepo interface {
+	TrackDwells(ctx context.Context, userID string, latitude, longitude float64, incidentIDs []int64, at time.Time) (entered, exited []models.ZoneDwell, err error)
+	ListPolicies(ctx context.Context) ([]models.EscalationPolicy, error)
 }
 
 type QueueProducer interface {
-	EnqueueDangerAlert(ctx context.Context, userID string, latitude, longitude float64) error
+	EnqueueDangerAlert(ctx context.Context, userID string, latitude, longitude float64, dangers []models.IncidentShort) error
+	EnqueueProximityAlert(ctx context.Context, userID string, latitude, longitude float64, nearby []models.NearbyIncident) error
+	EnqueuePredictedAlert(ctx context.Context, userID string, latitude, longitude float64, predicted []models.PredictedIncident) error
+	EnqueueCrossingAlert(ctx context.Context, userID string, latitude, longitude float64, crossed []models.IncidentShort) error
+	EnqueuePlaceAlerts(ctx context.Context, matches []models.PlaceMatch) ([]models.PlaceMatch, error)
+	ScheduleEscalations(ctx context.Context, dwell *models.ZoneDwell, policy *models.EscalationPolicy) error
+	CancelEscalations(ctx context.Context, dwells []models.ZoneDwell) error
 }
 
 type Service struct {
 	log             *slog.Logger
+	cfg             config.LocationConfig
 	asyncJobTimeout time.Duration
 	locationRepo    LocationRepo
 	incRepo         IncidentRepo
 	cacheRepo       CacheRepo
+	placeRepo       PlaceRepo
+	dwellRepo       DwellRepo
 	queue           QueueProducer
 }
 
-func New(log *slog.Logger, asyncJobTimeout time.Duration, locationRepo LocationRepo, incRepo IncidentRepo, cacheRepo CacheRepo, queue QueueProducer) *Service {
+func New(log *slog.Logger, cfg config.LocationConfig, asyncJobTimeout time.Duration, locationRepo LocationRepo, incRepo IncidentRepo, cacheRepo CacheRepo, placeRepo PlaceRepo, dwellRepo DwellRepo, queue QueueProducer) *Service {
 	return &Service{
 		log:             log,
+		cfg:             cfg,
 		asyncJobTimeout: asyncJobTimeout,
 		locationRepo:    locationRepo,
 		incRepo:         incRepo,
 		cacheRepo:       cacheRepo,
+		placeRepo:       placeRepo,
+		dwellRepo:       dwellRepo,
 		queue:           queue,
 	}
 }
@@ -62,28 +87,165 @@ func (s *Service) Check(ctx context.Context, params *models.CheckLocationParams)
 		return nil, err
 	}
 
+	accuracy := 0.0
+	if params.Accuracy != nil {
+		accuracy = *params.Accuracy
+	}
+
 	foundDangers := make([]models.IncidentShort, 0)
+	matches := make([]models.ZoneMatch, 0)
+	nearby := make([]models.NearbyIncident, 0)
 	for _, inc := range incidents {
-		dist := geo.Distance(params.Latitude, params.Longitude, inc.Latitude, inc.Longitude)
-		if dist <= float64(inc.Radius) {
+		switch incidentCircle(inc).Classify(params.Latitude, params.Longitude, accuracy) {
+		case geo.Inside:
+			matches = append(matches, models.ZoneMatch{IncidentShort: inc, Match: models.MatchInside})
 			foundDangers = append(foundDangers, inc)
+			continue
+		case geo.PartiallyInside:
+			matches = append(matches, models.ZoneMatch{IncidentShort: inc, Match: models.MatchPossiblyInside})
+			if s.cfg.PossiblyInside {
+				foundDangers = append(foundDangers, inc)
+			}
+			continue
 		}
+
+		dist := geo.Distance(params.Latitude, params.Longitude, inc.Latitude, inc.Longitude)
+		toEdge := dist - float64(inc.Radius)
+		if toEdge <= float64(s.approachBuffer(inc)) {
+			nearby = append(nearby, models.NearbyIncident{IncidentShort: inc, DistanceToEdge: toEdge})
+		}
+	}
+
+	now := time.Now()
+	// A timestamp in the future comes from a skewed clock, the position is taken as recorded now.
+	recordedAt := now
+	if params.Timestamp != nil && params.Timestamp.Before(now) {
+		recordedAt = *params.Timestamp
 	}
 
 	result := &models.CheckLocationResult{
 		UserID:    params.UserID,
 		Latitude:  params.Latitude,
 		Longitude: params.Longitude,
+		Accuracy:  params.Accuracy,
 		HasDanger: len(foundDangers) > 0,
 		Dangers:   foundDangers,
-		CreatedAt: time.Now(),
+		Matches:   matches,
+		Nearby:    nearby,
+		Predicted: s.predictEntries(params, incidents, now),
+		Crossed:   make([]models.IncidentShort, 0),
+		CreatedAt: now,
+	}
+
+	prev, err := s.cacheRepo.SwapLastLocation(ctx, params.UserID, &models.UserLocation{
+		Latitude:   params.Latitude,
+		Longitude:  params.Longitude,
+		RecordedAt: recordedAt,
+	})
+	switch {
+	case err == nil:
+		if !prev.RecordedAt.After(recordedAt) {
+			result.Crossed = crossedIncidents(prev, params, incidents)
+		}
+	case err != errs.ErrCacheMiss:
+		log.Warn("failed to swap last known location", logattr.Err(err))
+	}
+
+	if result.HasDanger {
+		result.Escape = escapeRoute(params.Latitude, params.Longitude, incidents)
 	}
 
-	go s.processPostCheck(result, log)
+	go s.processPostCheck(result, len(incidents) > 0, log)
 
 	return result, nil
 }
 
+func incidentCircle(inc models.IncidentShort) geo.Circle {
+	return geo.Circle{Latitude: inc.Latitude, Longitude: inc.Longitude, Radius: float64(inc.Radius)}
+}
+
+// escapeRoute finds the way out of all active zones, since leaving one zone may lead into another.
+func escapeRoute(lat, lon float64, incidents []models.IncidentShort) *models.EscapeRoute {
+	circles := make([]geo.Circle, 0, len(incidents))
+	for _, inc := range incidents {
+		circles = append(circles, incidentCircle(inc))
+	}
+
+	bearing, dist, ok := geo.NearestExit(lat, lon, circles)
+	if !ok {
+		return nil
+	}
+
+	exitLat, exitLon := geo.Destination(lat, lon, bearing, dist)
+	return &models.EscapeRoute{
+		Bearing:   bearing,
+		Distance:  dist,
+		Latitude:  exitLat,
+		Longitude: exitLon,
+	}
+}
+
+// crossedIncidents returns the zones the user passed straight through between the previous and the current check,
+// i.e. the segment between the points intersects a zone while neither point is inside it.
+func crossedIncidents(prev *models.UserLocation, params *models.CheckLocationParams, incidents []models.IncidentShort) []models.IncidentShort {
+	crossed := make([]models.IncidentShort, 0)
+	for _, inc := range incidents {
+		circle := incidentCircle(inc)
+		if circle.Contains(prev.Latitude, prev.Longitude) || circle.Contains(params.Latitude, params.Longitude) {
+			continue
+		}
+		if circle.IntersectsSegment(prev.Latitude, prev.Longitude, params.Latitude, params.Longitude) {
+			crossed = append(crossed, inc)
+		}
+	}
+	return crossed
+}
+
+// predictEntries projects the user's path along the reported heading over the prediction horizon
+// and returns the zones the path enters, with time to entry counted from now.
+func (s *Service) predictEntries(params *models.CheckLocationParams, incidents []models.IncidentShort, now time.Time) []models.PredictedIncident {
+	predicted := make([]models.PredictedIncident, 0)
+	if params.Speed == nil || params.Heading == nil || *params.Speed <= 0 || s.cfg.PredictionHorizon <= 0 {
+		return predicted
+	}
+	speed, heading := *params.Speed, *params.Heading
+
+	// The position may have been recorded a while ago, the user has kept moving since then.
+	elapsed := 0.0
+	if params.Timestamp != nil {
+		elapsed = max(now.Sub(*params.Timestamp).Seconds(), 0)
+	}
+	horizon := s.cfg.PredictionHorizon.Seconds()
+	if elapsed >= horizon {
+		return predicted
+	}
+
+	// The horizon runs from now, so the path from the recorded position covers the time elapsed too.
+	pathLength := speed * (horizon + elapsed)
+	for _, inc := range incidents {
+		dist, ok := geo.PathEntry(params.Latitude, params.Longitude, heading, pathLength, incidentCircle(inc))
+		if !ok {
+			continue
+		}
+
+		predicted = append(predicted, models.PredictedIncident{
+			IncidentShort:   inc,
+			DistanceToEntry: dist,
+			TimeToEntry:     max(dist/speed-elapsed, 0),
+		})
+	}
+
+	return predicted
+}
+
+// approachBuffer returns the per-incident buffer if set, otherwise the global default.
+func (s *Service) approachBuffer(inc models.IncidentShort) int {
+	if inc.ApproachBuffer != nil {
+		return *inc.ApproachBuffer
+	}
+	return s.cfg.ProximityBuffer
+}
+
 func (s *Service) getActiveIncidents(ctx context.Context) ([]models.IncidentShort, error) {
 	incidents, err := s.cacheRepo.GetActiveIncidents(ctx)
 	if err == nil {
@@ -108,7 +270,7 @@ func (s *Service) getActiveIncidents(ctx context.Context) ([]models.IncidentShor
 	return incidents, nil
 }
 
-func (s *Service) processPostCheck(check *models.CheckLocationResult, log *slog.Logger) {
+func (s *Service) processPostCheck(check *models.CheckLocationResult, hasIncidents bool, log *slog.Logger) {
 	ctx, cancel := context.WithTimeout(context.Background(), s.asyncJobTimeout)
 	defer cancel()
 
@@ -116,9 +278,111 @@ func (s *Service) processPostCheck(check *models.CheckLocationResult, log *slog.
 		log.Error("failed to save log for user", logattr.Err(err))
 	}
 
+	if hasIncidents {
+		s.alertPlaces(ctx, log, check.UserID)
+		s.trackDwells(ctx, log, check)
+	}
+
 	if check.HasDanger {
-		if err := s.queue.EnqueueDangerAlert(ctx, check.UserID, check.Latitude, check.Longitude); err != nil {
+		if err := s.queue.EnqueueDangerAlert(ctx, check.UserID, check.Latitude, check.Longitude, check.Dangers); err != nil {
 			log.Error("failed to enqueue webhook for user", logattr.Err(err))
+			return
+		}
+		// Keeps retroactive alerts about these incidents from reaching the user again.
+		ids := make([]int64, 0, len(check.Dangers))
+		for _, inc := range check.Dangers {
+			ids = append(ids, inc.ID)
+		}
+		if err := s.locationRepo.RecordAlerts(ctx, check.UserID, ids); err != nil {
+			log.Warn("failed to record alerts for user", logattr.Err(err))
+		}
+		return
+	}
+
+	if len(check.Predicted) > 0 {
+		if err := s.queue.EnqueuePredictedAlert(ctx, check.UserID, check.Latitude, check.Longitude, check.Predicted); err != nil {
+			log.Error("failed to enqueue predicted danger webhook for user", logattr.Err(err))
+		}
+	}
+
+	if s.cfg.CrossingAlerts && len(check.Crossed) > 0 {
+		if err := s.queue.EnqueueCrossingAlert(ctx, check.UserID, check.Latitude, check.Longitude, check.Crossed); err != nil {
+			log.Error("failed to enqueue path crossing webhook for user", logattr.Err(err))
+		}
+	}
+
+	if s.cfg.ProximityAlerts && len(check.Nearby) > 0 {
+		if err := s.queue.EnqueueProximityAlert(ctx, check.UserID, check.Latitude, check.Longitude, check.Nearby); err != nil {
+			log.Error("failed to enqueue proximity webhook for user", logattr.Err(err))
+		}
+	}
+}
+
+// alertPlaces catches up on the user's saved places the active zones reach into, e.g. places saved
+// after the incident was reported. Places alerted about an incident before are left out.
+func (s *Service) alertPlaces(ctx context.Context, log *slog.Logger, userID string) {
+	matches, err := s.placeRepo.ClaimAffected(ctx, userID, 0)
+	if err != nil {
+		log.Error("failed to find saved places in zones", logattr.Err(err))
+		return
+	}
+	if len(matches) == 0 {
+		return
+	}
+
+	failed, err := s.queue.EnqueuePlaceAlerts(ctx, matches)
+	if err != nil {
+		log.Error("failed to enqueue place alerts for user", logattr.Err(err))
+		// The next check claims them again.
+		if relErr := s.placeRepo.ReleaseAlerts(ctx, failed); relErr != nil {
+			log.Error("failed to release unsent place alerts", logattr.Err(relErr))
+		}
+	}
+}
+
+// trackDwells keeps track of how long the user stays in the zones found by consecutive checks. Entering a zone
+// schedules the follow-ups of the escalation policy for the severity of the incident, leaving it cancels them.
+func (s *Service) trackDwells(ctx context.Context, log *slog.Logger, check *models.CheckLocationResult) {
+	ids := make([]int64, 0, len(check.Dangers))
+	severities := make(map[int64]int, len(check.Dangers))
+	for _, inc := range check.Dangers {
+		ids = append(ids, inc.ID)
+		severities[inc.ID] = inc.Severity
+	}
+
+	entered, exited, err := s.dwellRepo.TrackDwells(ctx, check.UserID, check.Latitude, check.Longitude, ids, check.CreatedAt)
+	if err != nil {
+		log.Error("failed to track zone dwells", logattr.Err(err))
+		return
+	}
+
+	if len(exited) > 0 {
+		// Follow-ups left behind find the user gone and do nothing.
+		if err := s.queue.CancelEscalations(ctx, exited); err != nil {
+			log.Warn("failed to cancel escalations for user", logattr.Err(err))
+		}
+	}
+	if len(entered) == 0 {
+		return
+	}
+
+	policies, err := s.dwellRepo.ListPolicies(ctx)
+	if err != nil {
+		log.Error("failed to list escalation policies", logattr.Err(err))
+		return
+	}
+	bySeverity := make(map[int]*models.EscalationPolicy, len(policies))
+	for i := range policies {
+		bySeverity[policies[i].Severity] = &policies[i]
+	}
+
+	for i := range entered {
+		policy, ok := bySeverity[severities[entered[i].IncidentID]]
+		if !ok {
+			continue
+		}
+		if err := s.queue.ScheduleEscalations(ctx, &entered[i], policy); err != nil {
+			log.Error("failed to schedule escalations for user", slog.Int64("incident_id", entered[i].IncidentID), logattr.Err(err))
 		}
 	}
 }
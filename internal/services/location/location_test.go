@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/ocenb/geo-alerts/internal/config"
 	"github.com/ocenb/geo-alerts/internal/domain/errs"
 	"github.com/ocenb/geo-alerts/internal/domain/models"
 	"github.com/ocenb/geo-alerts/internal/logger"
@@ -19,6 +20,8 @@ type LocationServiceSuite struct {
 	mockCache *MockCacheRepo
 	mockInc   *MockIncidentRepo
 	mockLoc   *MockLocationRepo
+	mockPlace *MockPlaceRepo
+	mockDwell *MockDwellRepo
 	mockQueue *MockQueueProducer
 	service   *Service
 }
@@ -27,14 +30,24 @@ func (s *LocationServiceSuite) SetupTest() {
 	s.mockCache = NewMockCacheRepo(s.T())
 	s.mockInc = NewMockIncidentRepo(s.T())
 	s.mockLoc = NewMockLocationRepo(s.T())
+	s.mockPlace = NewMockPlaceRepo(s.T())
+	s.mockDwell = NewMockDwellRepo(s.T())
 	s.mockQueue = NewMockQueueProducer(s.T())
 
+	// Checks run in the background look up saved places whenever there are active incidents.
+	s.mockPlace.On("ClaimAffected", mock.Anything, mock.Anything, int64(0)).Return([]models.PlaceMatch{}, nil).Maybe()
+	s.mockDwell.On("TrackDwells", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return([]models.ZoneDwell{}, []models.ZoneDwell{}, nil).Maybe()
+
 	s.service = New(
 		logger.NewDiscard(),
+		config.LocationConfig{ProximityBuffer: 500, ProximityAlerts: true, PredictionHorizon: 5 * time.Minute},
 		time.Second,
 		s.mockLoc,
 		s.mockInc,
 		s.mockCache,
+		s.mockPlace,
+		s.mockDwell,
 		s.mockQueue,
 	)
 }
@@ -50,9 +63,12 @@ func (s *LocationServiceSuite) TestCheck_CacheHit_Danger() {
 	s.mockCache.On("GetActiveIncidents", mock.Anything).
 		Return([]models.IncidentShort{incident}, nil)
 
+	s.mockCache.On("SwapLastLocation", mock.Anything, "u1", mock.Anything).Return(nil, errs.ErrCacheMiss)
+
 	// Async calls
 	s.mockLoc.On("SaveCheckLog", mock.Anything, mock.Anything).Return(nil).Maybe()
-	s.mockQueue.On("EnqueueDangerAlert", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	s.mockQueue.On("EnqueueDangerAlert", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	s.mockLoc.On("RecordAlerts", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
 
 	res, err := s.service.Check(ctx, &models.CheckLocationParams{
 		UserID: "u1", Latitude: 10.0, Longitude: 10.0,
@@ -61,6 +77,8 @@ func (s *LocationServiceSuite) TestCheck_CacheHit_Danger() {
 	s.NoError(err)
 	s.True(res.HasDanger)
 	s.Len(res.Dangers, 1)
+	s.Require().NotNil(res.Escape)
+	s.InDelta(1000, res.Escape.Distance, 5)
 }
 
 func (s *LocationServiceSuite) TestCheck_CacheMiss_DBSuccess() {
@@ -73,9 +91,12 @@ func (s *LocationServiceSuite) TestCheck_CacheMiss_DBSuccess() {
 
 	s.mockCache.On("SetActiveIncidents", mock.Anything, mock.Anything).Return(nil)
 
+	s.mockCache.On("SwapLastLocation", mock.Anything, "u1", mock.Anything).Return(nil, errs.ErrCacheMiss)
+
 	// Async calls
 	s.mockLoc.On("SaveCheckLog", mock.Anything, mock.Anything).Return(nil).Maybe()
-	s.mockQueue.On("EnqueueDangerAlert", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	s.mockQueue.On("EnqueueDangerAlert", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	s.mockLoc.On("RecordAlerts", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
 
 	res, err := s.service.Check(ctx, &models.CheckLocationParams{UserID: "u1", Latitude: 10.0, Longitude: 10.0})
 
@@ -97,11 +118,86 @@ func (s *LocationServiceSuite) TestCheck_DBError() {
 
 func (s *LocationServiceSuite) TestProcessPostCheck_Danger() {
 	s.mockLoc.On("SaveCheckLog", mock.Anything, mock.Anything).Return(nil).Once()
-	s.mockQueue.On("EnqueueDangerAlert", mock.Anything, "u1", 10.0, 10.0).Return(nil).Once()
+	dangers := []models.IncidentShort{{ID: 1, Latitude: 10.0, Longitude: 10.0, Radius: 100, Severity: 3}}
+	s.mockQueue.On("EnqueueDangerAlert", mock.Anything, "u1", 10.0, 10.0, dangers).Return(nil).Once()
+	s.mockLoc.On("RecordAlerts", mock.Anything, "u1", []int64{1}).Return(nil).Once()
+
+	check := &models.CheckLocationResult{UserID: "u1", Latitude: 10.0, Longitude: 10.0, HasDanger: true, Dangers: dangers}
+
+	s.service.processPostCheck(check, false, logger.NewDiscard())
+}
+
+func (s *LocationServiceSuite) TestProcessPostCheck_SavedPlaces() {
+	matches := []models.PlaceMatch{
+		{Place: models.SavedPlace{ID: 1, UserID: "u1", Name: "Home"}, Incident: models.IncidentShort{ID: 1}},
+		{Place: models.SavedPlace{ID: 2, UserID: "u1", Name: "Work"}, Incident: models.IncidentShort{ID: 1}},
+	}
+	s.mockLoc.On("SaveCheckLog", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockPlace.ExpectedCalls = nil
+	s.mockPlace.On("ClaimAffected", mock.Anything, "u1", int64(0)).Return(matches, nil).Once()
+	s.mockQueue.On("EnqueuePlaceAlerts", mock.Anything, matches).Return(matches[1:], errors.New("redis down")).Once()
+	s.mockPlace.On("ReleaseAlerts", mock.Anything, matches[1:]).Return(nil).Once()
+
+	check := &models.CheckLocationResult{UserID: "u1", Latitude: 10.0, Longitude: 10.0}
+
+	s.service.processPostCheck(check, true, logger.NewDiscard())
+}
+
+func (s *LocationServiceSuite) TestProcessPostCheck_NoIncidentsSkipsPlaces() {
+	s.mockLoc.On("SaveCheckLog", mock.Anything, mock.Anything).Return(nil).Once()
+
+	check := &models.CheckLocationResult{UserID: "u1", Latitude: 10.0, Longitude: 10.0}
+
+	s.service.processPostCheck(check, false, logger.NewDiscard())
+
+	s.mockPlace.AssertNotCalled(s.T(), "ClaimAffected", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *LocationServiceSuite) TestProcessPostCheck_EscalationsOnEntry() {
+	now := time.Now()
+	dangers := []models.IncidentShort{{ID: 1, Severity: 5}, {ID: 2, Severity: 2}}
+	entered := []models.ZoneDwell{{UserID: "u1", IncidentID: 1, EnteredAt: now}, {UserID: "u1", IncidentID: 2, EnteredAt: now}}
+	policy := models.EscalationPolicy{Severity: 5, RealertAfter: 600, EscalateAfter: 1800}
+
+	s.mockLoc.On("SaveCheckLog", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockQueue.On("EnqueueDangerAlert", mock.Anything, "u1", 10.0, 10.0, dangers).Return(nil).Once()
+	s.mockLoc.On("RecordAlerts", mock.Anything, "u1", []int64{1, 2}).Return(nil).Once()
+	s.mockDwell.ExpectedCalls = nil
+	s.mockDwell.On("TrackDwells", mock.Anything, "u1", 10.0, 10.0, []int64{1, 2}, now).Return(entered, []models.ZoneDwell{}, nil).Once()
+	s.mockDwell.On("ListPolicies", mock.Anything).Return([]models.EscalationPolicy{policy}, nil).Once()
+	// Only the incident of severity 5 has a policy.
+	s.mockQueue.On("ScheduleEscalations", mock.Anything, &entered[0], &policy).Return(nil).Once()
+
+	check := &models.CheckLocationResult{UserID: "u1", Latitude: 10.0, Longitude: 10.0, HasDanger: true, Dangers: dangers, CreatedAt: now}
+
+	s.service.processPostCheck(check, true, logger.NewDiscard())
+}
+
+func (s *LocationServiceSuite) TestProcessPostCheck_EscalationsCancelledOnExit() {
+	exited := []models.ZoneDwell{{UserID: "u1", IncidentID: 1}}
+
+	s.mockLoc.On("SaveCheckLog", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockDwell.ExpectedCalls = nil
+	s.mockDwell.On("TrackDwells", mock.Anything, "u1", 10.0, 10.0, []int64{}, mock.Anything).Return([]models.ZoneDwell{}, exited, nil).Once()
+	s.mockQueue.On("CancelEscalations", mock.Anything, exited).Return(nil).Once()
+
+	check := &models.CheckLocationResult{UserID: "u1", Latitude: 10.0, Longitude: 10.0}
 
-	check := &models.CheckLocationResult{UserID: "u1", Latitude: 10.0, Longitude: 10.0, HasDanger: true}
+	s.service.processPostCheck(check, true, logger.NewDiscard())
 
-	s.service.processPostCheck(check, logger.NewDiscard())
+	s.mockDwell.AssertNotCalled(s.T(), "ListPolicies", mock.Anything)
+}
+
+func (s *LocationServiceSuite) TestProcessPostCheck_DangerEnqueueError() {
+	s.mockLoc.On("SaveCheckLog", mock.Anything, mock.Anything).Return(nil).Once()
+	dangers := []models.IncidentShort{{ID: 1, Latitude: 10.0, Longitude: 10.0, Radius: 100, Severity: 3}}
+	s.mockQueue.On("EnqueueDangerAlert", mock.Anything, "u1", 10.0, 10.0, dangers).Return(errors.New("redis down")).Once()
+
+	check := &models.CheckLocationResult{UserID: "u1", Latitude: 10.0, Longitude: 10.0, HasDanger: true, Dangers: dangers}
+
+	s.service.processPostCheck(check, false, logger.NewDiscard())
+
+	s.mockLoc.AssertNotCalled(s.T(), "RecordAlerts", mock.Anything, mock.Anything, mock.Anything)
 }
 
 func (s *LocationServiceSuite) TestProcessPostCheck_Safe() {
@@ -109,5 +205,316 @@ func (s *LocationServiceSuite) TestProcessPostCheck_Safe() {
 
 	check := &models.CheckLocationResult{UserID: "u1", HasDanger: false}
 
-	s.service.processPostCheck(check, logger.NewDiscard())
+	s.service.processPostCheck(check, false, logger.NewDiscard())
+}
+
+func (s *LocationServiceSuite) TestCheck_Nearby() {
+	ctx := context.Background()
+	// ~1.1 km north of the user, radius 1000 -> edge is ~110 m away
+	incident := models.IncidentShort{ID: 1, Latitude: 10.01, Longitude: 10.0, Radius: 1000}
+
+	s.mockCache.On("GetActiveIncidents", mock.Anything).
+		Return([]models.IncidentShort{incident}, nil)
+
+	s.mockCache.On("SwapLastLocation", mock.Anything, "u1", mock.Anything).Return(nil, errs.ErrCacheMiss)
+
+	// Async calls
+	s.mockLoc.On("SaveCheckLog", mock.Anything, mock.Anything).Return(nil).Maybe()
+	s.mockQueue.On("EnqueueProximityAlert", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	res, err := s.service.Check(ctx, &models.CheckLocationParams{
+		UserID: "u1", Latitude: 10.0, Longitude: 10.0,
+	})
+
+	s.NoError(err)
+	s.False(res.HasDanger)
+	s.Require().Len(res.Nearby, 1)
+	s.InDelta(112, res.Nearby[0].DistanceToEdge, 5)
+}
+
+func (s *LocationServiceSuite) TestCheck_Nearby_IncidentBufferOverride() {
+	ctx := context.Background()
+	buffer := 50
+	incident := models.IncidentShort{ID: 1, Latitude: 10.01, Longitude: 10.0, Radius: 1000, ApproachBuffer: &buffer}
+
+	s.mockCache.On("GetActiveIncidents", mock.Anything).
+		Return([]models.IncidentShort{incident}, nil)
+
+	s.mockCache.On("SwapLastLocation", mock.Anything, "u1", mock.Anything).Return(nil, errs.ErrCacheMiss)
+
+	// Async calls
+	s.mockLoc.On("SaveCheckLog", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	res, err := s.service.Check(ctx, &models.CheckLocationParams{
+		UserID: "u1", Latitude: 10.0, Longitude: 10.0,
+	})
+
+	s.NoError(err)
+	s.False(res.HasDanger)
+	s.Empty(res.Nearby)
+}
+
+func (s *LocationServiceSuite) TestProcessPostCheck_Nearby() {
+	nearby := []models.NearbyIncident{{IncidentShort: models.IncidentShort{ID: 1}, DistanceToEdge: 100}}
+
+	s.mockLoc.On("SaveCheckLog", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockQueue.On("EnqueueProximityAlert", mock.Anything, "u1", 10.0, 10.0, nearby).Return(nil).Once()
+
+	check := &models.CheckLocationResult{UserID: "u1", Latitude: 10.0, Longitude: 10.0, Nearby: nearby}
+
+	s.service.processPostCheck(check, false, logger.NewDiscard())
+}
+
+func (s *LocationServiceSuite) TestProcessPostCheck_Nearby_AlertsDisabled() {
+	s.service.cfg.ProximityAlerts = false
+	nearby := []models.NearbyIncident{{IncidentShort: models.IncidentShort{ID: 1}, DistanceToEdge: 100}}
+
+	s.mockLoc.On("SaveCheckLog", mock.Anything, mock.Anything).Return(nil).Once()
+
+	check := &models.CheckLocationResult{UserID: "u1", Latitude: 10.0, Longitude: 10.0, Nearby: nearby}
+
+	s.service.processPostCheck(check, false, logger.NewDiscard())
+}
+
+func (s *LocationServiceSuite) TestCheck_Escape_OverlappingZones() {
+	ctx := context.Background()
+	incidents := []models.IncidentShort{
+		{ID: 1, Latitude: 0, Longitude: 0, Radius: 1000},
+		{ID: 2, Latitude: 0, Longitude: 0.015, Radius: 1000},
+	}
+
+	s.mockCache.On("GetActiveIncidents", mock.Anything).Return(incidents, nil)
+
+	s.mockCache.On("SwapLastLocation", mock.Anything, "u1", mock.Anything).Return(nil, errs.ErrCacheMiss)
+
+	// Async calls
+	s.mockLoc.On("SaveCheckLog", mock.Anything, mock.Anything).Return(nil).Maybe()
+	s.mockQueue.On("EnqueueDangerAlert", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	s.mockLoc.On("RecordAlerts", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	res, err := s.service.Check(ctx, &models.CheckLocationParams{
+		UserID: "u1", Latitude: 0, Longitude: 0.005,
+	})
+
+	s.NoError(err)
+	s.Len(res.Dangers, 1)
+	s.Require().NotNil(res.Escape)
+	// East is blocked by the second zone, so the way out is longer than the 444 m to the first zone's edge.
+	s.InDelta(619, res.Escape.Distance, 5)
+}
+
+func (s *LocationServiceSuite) TestCheck_Predicted() {
+	ctx := context.Background()
+	// ~2.2 km east of the user, radius 500 -> path enters after ~1724 m
+	incident := models.IncidentShort{ID: 1, Latitude: 0, Longitude: 0.02, Radius: 500}
+	speed, heading := 20.0, 90.0
+	recorded := time.Now().Add(-10 * time.Second)
+
+	s.mockCache.On("GetActiveIncidents", mock.Anything).
+		Return([]models.IncidentShort{incident}, nil)
+
+	s.mockCache.On("SwapLastLocation", mock.Anything, "u1", mock.Anything).Return(nil, errs.ErrCacheMiss)
+
+	// Async calls
+	s.mockLoc.On("SaveCheckLog", mock.Anything, mock.Anything).Return(nil).Maybe()
+	s.mockQueue.On("EnqueuePredictedAlert", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	res, err := s.service.Check(ctx, &models.CheckLocationParams{
+		UserID: "u1", Latitude: 0, Longitude: 0,
+		Speed: &speed, Heading: &heading, Timestamp: &recorded,
+	})
+
+	s.NoError(err)
+	s.False(res.HasDanger)
+	s.Require().Len(res.Predicted, 1)
+	s.InDelta(1724, res.Predicted[0].DistanceToEntry, 3)
+	s.InDelta(1724.0/20-10, res.Predicted[0].TimeToEntry, 1)
+}
+
+func (s *LocationServiceSuite) TestCheck_Predicted_LateCheck() {
+	ctx := context.Background()
+	incident := models.IncidentShort{ID: 1, Latitude: 0, Longitude: 0.02, Radius: 500}
+	// 1500 m within the horizon from the recorded position, but the user has kept moving for another minute.
+	speed, heading := 5.0, 90.0
+	recorded := time.Now().Add(-time.Minute)
+
+	s.mockCache.On("GetActiveIncidents", mock.Anything).
+		Return([]models.IncidentShort{incident}, nil)
+
+	s.mockCache.On("SwapLastLocation", mock.Anything, "u1", mock.Anything).Return(nil, errs.ErrCacheMiss)
+
+	// Async calls
+	s.mockLoc.On("SaveCheckLog", mock.Anything, mock.Anything).Return(nil).Maybe()
+	s.mockQueue.On("EnqueuePredictedAlert", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	res, err := s.service.Check(ctx, &models.CheckLocationParams{
+		UserID: "u1", Latitude: 0, Longitude: 0,
+		Speed: &speed, Heading: &heading, Timestamp: &recorded,
+	})
+
+	s.NoError(err)
+	s.Require().Len(res.Predicted, 1)
+	s.InDelta(1724, res.Predicted[0].DistanceToEntry, 3)
+	s.InDelta(1724.0/5-60, res.Predicted[0].TimeToEntry, 1)
+}
+
+func (s *LocationServiceSuite) TestCheck_FutureTimestamp() {
+	ctx := context.Background()
+	future := time.Now().Add(time.Hour)
+
+	s.mockCache.On("GetActiveIncidents", mock.Anything).Return([]models.IncidentShort{}, nil)
+	s.mockCache.On("SwapLastLocation", mock.Anything, "u1", mock.MatchedBy(func(loc *models.UserLocation) bool {
+		return !loc.RecordedAt.After(time.Now())
+	})).Return(nil, errs.ErrCacheMiss).Once()
+
+	// Async calls
+	s.mockLoc.On("SaveCheckLog", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	_, err := s.service.Check(ctx, &models.CheckLocationParams{
+		UserID: "u1", Latitude: 0, Longitude: 0, Timestamp: &future,
+	})
+
+	s.NoError(err)
+}
+
+func (s *LocationServiceSuite) TestCheck_Predicted_BeyondHorizon() {
+	ctx := context.Background()
+	incident := models.IncidentShort{ID: 1, Latitude: 0, Longitude: 0.02, Radius: 500}
+	speed, heading := 1.0, 90.0 // 300 m in 5 minutes
+
+	s.mockCache.On("GetActiveIncidents", mock.Anything).
+		Return([]models.IncidentShort{incident}, nil)
+
+	s.mockCache.On("SwapLastLocation", mock.Anything, "u1", mock.Anything).Return(nil, errs.ErrCacheMiss)
+
+	// Async calls
+	s.mockLoc.On("SaveCheckLog", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	res, err := s.service.Check(ctx, &models.CheckLocationParams{
+		UserID: "u1", Latitude: 0, Longitude: 0,
+		Speed: &speed, Heading: &heading,
+	})
+
+	s.NoError(err)
+	s.Empty(res.Predicted)
+}
+
+func (s *LocationServiceSuite) TestProcessPostCheck_Predicted() {
+	predicted := []models.PredictedIncident{{IncidentShort: models.IncidentShort{ID: 1}, DistanceToEntry: 1000, TimeToEntry: 50}}
+
+	s.mockLoc.On("SaveCheckLog", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockQueue.On("EnqueuePredictedAlert", mock.Anything, "u1", 10.0, 10.0, predicted).Return(nil).Once()
+
+	check := &models.CheckLocationResult{UserID: "u1", Latitude: 10.0, Longitude: 10.0, Predicted: predicted}
+
+	s.service.processPostCheck(check, false, logger.NewDiscard())
+}
+
+func (s *LocationServiceSuite) TestCheck_Crossed() {
+	ctx := context.Background()
+	// Small zone between the previous (west) and the current (east) points
+	incident := models.IncidentShort{ID: 1, Latitude: 0, Longitude: 0.01, Radius: 200}
+	prev := &models.UserLocation{Latitude: 0, Longitude: 0, RecordedAt: time.Now().Add(-time.Minute)}
+
+	s.mockCache.On("GetActiveIncidents", mock.Anything).
+		Return([]models.IncidentShort{incident}, nil)
+	s.mockCache.On("SwapLastLocation", mock.Anything, "u1", mock.Anything).Return(prev, nil)
+
+	// Async calls
+	s.mockLoc.On("SaveCheckLog", mock.Anything, mock.Anything).Return(nil).Maybe()
+	s.mockQueue.On("EnqueueCrossingAlert", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	res, err := s.service.Check(ctx, &models.CheckLocationParams{
+		UserID: "u1", Latitude: 0, Longitude: 0.02,
+	})
+
+	s.NoError(err)
+	s.False(res.HasDanger)
+	s.Require().Len(res.Crossed, 1)
+	s.Equal(int64(1), res.Crossed[0].ID)
+}
+
+func (s *LocationServiceSuite) TestCheck_Crossed_PreviousInside() {
+	ctx := context.Background()
+	incident := models.IncidentShort{ID: 1, Latitude: 0, Longitude: 0.01, Radius: 200}
+	prev := &models.UserLocation{Latitude: 0, Longitude: 0.01, RecordedAt: time.Now().Add(-time.Minute)}
+
+	s.mockCache.On("GetActiveIncidents", mock.Anything).
+		Return([]models.IncidentShort{incident}, nil)
+	s.mockCache.On("SwapLastLocation", mock.Anything, "u1", mock.Anything).Return(prev, nil)
+
+	// Async calls
+	s.mockLoc.On("SaveCheckLog", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	res, err := s.service.Check(ctx, &models.CheckLocationParams{
+		UserID: "u1", Latitude: 0, Longitude: 0.02,
+	})
+
+	s.NoError(err)
+	s.Empty(res.Crossed)
+}
+
+func (s *LocationServiceSuite) TestProcessPostCheck_Crossed() {
+	s.service.cfg.CrossingAlerts = true
+	crossed := []models.IncidentShort{{ID: 1}}
+
+	s.mockLoc.On("SaveCheckLog", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockQueue.On("EnqueueCrossingAlert", mock.Anything, "u1", 10.0, 10.0, crossed).Return(nil).Once()
+
+	check := &models.CheckLocationResult{UserID: "u1", Latitude: 10.0, Longitude: 10.0, Crossed: crossed}
+
+	s.service.processPostCheck(check, false, logger.NewDiscard())
+}
+
+func (s *LocationServiceSuite) TestCheck_Accuracy_PossiblyInside() {
+	ctx := context.Background()
+	// ~556 m from the center, the uncertainty circle crosses the edge
+	incident := models.IncidentShort{ID: 1, Latitude: 0, Longitude: 0, Radius: 1000}
+	accuracy := 500.0
+
+	s.mockCache.On("GetActiveIncidents", mock.Anything).
+		Return([]models.IncidentShort{incident}, nil)
+	s.mockCache.On("SwapLastLocation", mock.Anything, "u1", mock.Anything).Return(nil, errs.ErrCacheMiss)
+
+	// Async calls
+	s.mockLoc.On("SaveCheckLog", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	res, err := s.service.Check(ctx, &models.CheckLocationParams{
+		UserID: "u1", Latitude: 0, Longitude: 0.005, Accuracy: &accuracy,
+	})
+
+	s.NoError(err)
+	s.False(res.HasDanger)
+	s.Empty(res.Dangers)
+	s.Require().Len(res.Matches, 1)
+	s.Equal(models.MatchPossiblyInside, res.Matches[0].Match)
+	s.Empty(res.Nearby)
+}
+
+func (s *LocationServiceSuite) TestCheck_Accuracy_PossiblyInsideCounts() {
+	s.service.cfg.PossiblyInside = true
+	ctx := context.Background()
+	incident := models.IncidentShort{ID: 1, Latitude: 0, Longitude: 0, Radius: 1000}
+	accuracy := 200.0
+
+	s.mockCache.On("GetActiveIncidents", mock.Anything).
+		Return([]models.IncidentShort{incident}, nil)
+	s.mockCache.On("SwapLastLocation", mock.Anything, "u1", mock.Anything).Return(nil, errs.ErrCacheMiss)
+
+	// Async calls
+	s.mockLoc.On("SaveCheckLog", mock.Anything, mock.Anything).Return(nil).Maybe()
+	s.mockQueue.On("EnqueueDangerAlert", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	s.mockLoc.On("RecordAlerts", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	// Just outside the zone, but within the GPS accuracy
+	res, err := s.service.Check(ctx, &models.CheckLocationParams{
+		UserID: "u1", Latitude: 0, Longitude: 0.01, Accuracy: &accuracy,
+	})
+
+	s.NoError(err)
+	s.True(res.HasDanger)
+	s.Len(res.Dangers, 1)
+	s.Require().Len(res.Matches, 1)
+	s.Equal(models.MatchPossiblyInside, res.Matches[0].Match)
+	s.Nil(res.Escape)
 }
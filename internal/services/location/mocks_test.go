@@ -6,6 +6,7 @@ package location
 
 import (
 	"context"
+	"time"
 
 	"github.com/ocenb/geo-alerts/internal/domain/models"
 	mock "github.com/stretchr/testify/mock"
@@ -38,6 +39,69 @@ func (_m *MockLocationRepo) EXPECT() *MockLocationRepo_Expecter {
 	return &MockLocationRepo_Expecter{mock: &_m.Mock}
 }
 
+// RecordAlerts provides a mock function for the type MockLocationRepo
+func (_mock *MockLocationRepo) RecordAlerts(ctx context.Context, userID string, incidentIDs []int64) error {
+	ret := _mock.Called(ctx, userID, incidentIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordAlerts")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []int64) error); ok {
+		r0 = returnFunc(ctx, userID, incidentIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockLocationRepo_RecordAlerts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordAlerts'
+type MockLocationRepo_RecordAlerts_Call struct {
+	*mock.Call
+}
+
+// RecordAlerts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - incidentIDs []int64
+func (_e *MockLocationRepo_Expecter) RecordAlerts(ctx interface{}, userID interface{}, incidentIDs interface{}) *MockLocationRepo_RecordAlerts_Call {
+	return &MockLocationRepo_RecordAlerts_Call{Call: _e.mock.On("RecordAlerts", ctx, userID, incidentIDs)}
+}
+
+func (_c *MockLocationRepo_RecordAlerts_Call) Run(run func(ctx context.Context, userID string, incidentIDs []int64)) *MockLocationRepo_RecordAlerts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []int64
+		if args[2] != nil {
+			arg2 = args[2].([]int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLocationRepo_RecordAlerts_Call) Return(err error) *MockLocationRepo_RecordAlerts_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockLocationRepo_RecordAlerts_Call) RunAndReturn(run func(ctx context.Context, userID string, incidentIDs []int64) error) *MockLocationRepo_RecordAlerts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SaveCheckLog provides a mock function for the type MockLocationRepo
 func (_mock *MockLocationRepo) SaveCheckLog(ctx context.Context, check *models.CheckLocationResult) error {
 	ret := _mock.Called(ctx, check)
@@ -330,13 +394,87 @@ func (_c *MockCacheRepo_SetActiveIncidents_Call) RunAndReturn(run func(ctx conte
 	return _c
 }
 
-// NewMockQueueProducer creates a new instance of MockQueueProducer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// SwapLastLocation provides a mock function for the type MockCacheRepo
+func (_mock *MockCacheRepo) SwapLastLocation(ctx context.Context, userID string, loc *models.UserLocation) (*models.UserLocation, error) {
+	ret := _mock.Called(ctx, userID, loc)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SwapLastLocation")
+	}
+
+	var r0 *models.UserLocation
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *models.UserLocation) (*models.UserLocation, error)); ok {
+		return returnFunc(ctx, userID, loc)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *models.UserLocation) *models.UserLocation); ok {
+		r0 = returnFunc(ctx, userID, loc)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserLocation)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *models.UserLocation) error); ok {
+		r1 = returnFunc(ctx, userID, loc)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCacheRepo_SwapLastLocation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SwapLastLocation'
+type MockCacheRepo_SwapLastLocation_Call struct {
+	*mock.Call
+}
+
+// SwapLastLocation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - loc *models.UserLocation
+func (_e *MockCacheRepo_Expecter) SwapLastLocation(ctx interface{}, userID interface{}, loc interface{}) *MockCacheRepo_SwapLastLocation_Call {
+	return &MockCacheRepo_SwapLastLocation_Call{Call: _e.mock.On("SwapLastLocation", ctx, userID, loc)}
+}
+
+func (_c *MockCacheRepo_SwapLastLocation_Call) Run(run func(ctx context.Context, userID string, loc *models.UserLocation)) *MockCacheRepo_SwapLastLocation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *models.UserLocation
+		if args[2] != nil {
+			arg2 = args[2].(*models.UserLocation)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCacheRepo_SwapLastLocation_Call) Return(userLocation *models.UserLocation, err error) *MockCacheRepo_SwapLastLocation_Call {
+	_c.Call.Return(userLocation, err)
+	return _c
+}
+
+func (_c *MockCacheRepo_SwapLastLocation_Call) RunAndReturn(run func(ctx context.Context, userID string, loc *models.UserLocation) (*models.UserLocation, error)) *MockCacheRepo_SwapLastLocation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockPlaceRepo creates a new instance of MockPlaceRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
-func NewMockQueueProducer(t interface {
+func NewMockPlaceRepo(t interface {
 	mock.TestingT
 	Cleanup(func())
-}) *MockQueueProducer {
-	mock := &MockQueueProducer{}
+}) *MockPlaceRepo {
+	mock := &MockPlaceRepo{}
 	mock.Mock.Test(t)
 
 	t.Cleanup(func() { mock.AssertExpectations(t) })
@@ -344,51 +482,61 @@ func NewMockQueueProducer(t interface {
 	return mock
 }
 
-// MockQueueProducer is an autogenerated mock type for the QueueProducer type
-type MockQueueProducer struct {
+// MockPlaceRepo is an autogenerated mock type for the PlaceRepo type
+type MockPlaceRepo struct {
 	mock.Mock
 }
 
-type MockQueueProducer_Expecter struct {
+type MockPlaceRepo_Expecter struct {
 	mock *mock.Mock
 }
 
-func (_m *MockQueueProducer) EXPECT() *MockQueueProducer_Expecter {
-	return &MockQueueProducer_Expecter{mock: &_m.Mock}
+func (_m *MockPlaceRepo) EXPECT() *MockPlaceRepo_Expecter {
+	return &MockPlaceRepo_Expecter{mock: &_m.Mock}
 }
 
-// EnqueueDangerAlert provides a mock function for the type MockQueueProducer
-func (_mock *MockQueueProducer) EnqueueDangerAlert(ctx context.Context, userID string, latitude float64, longitude float64) error {
-	ret := _mock.Called(ctx, userID, latitude, longitude)
+// ClaimAffected provides a mock function for the type MockPlaceRepo
+func (_mock *MockPlaceRepo) ClaimAffected(ctx context.Context, userID string, incidentID int64) ([]models.PlaceMatch, error) {
+	ret := _mock.Called(ctx, userID, incidentID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for EnqueueDangerAlert")
+		panic("no return value specified for ClaimAffected")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, float64, float64) error); ok {
-		r0 = returnFunc(ctx, userID, latitude, longitude)
+	var r0 []models.PlaceMatch
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64) ([]models.PlaceMatch, error)); ok {
+		return returnFunc(ctx, userID, incidentID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64) []models.PlaceMatch); ok {
+		r0 = returnFunc(ctx, userID, incidentID)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.PlaceMatch)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = returnFunc(ctx, userID, incidentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockQueueProducer_EnqueueDangerAlert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnqueueDangerAlert'
-type MockQueueProducer_EnqueueDangerAlert_Call struct {
+// MockPlaceRepo_ClaimAffected_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimAffected'
+type MockPlaceRepo_ClaimAffected_Call struct {
 	*mock.Call
 }
 
-// EnqueueDangerAlert is a helper method to define mock.On call
+// ClaimAffected is a helper method to define mock.On call
 //   - ctx context.Context
 //   - userID string
-//   - latitude float64
-//   - longitude float64
-func (_e *MockQueueProducer_Expecter) EnqueueDangerAlert(ctx interface{}, userID interface{}, latitude interface{}, longitude interface{}) *MockQueueProducer_EnqueueDangerAlert_Call {
-	return &MockQueueProducer_EnqueueDangerAlert_Call{Call: _e.mock.On("EnqueueDangerAlert", ctx, userID, latitude, longitude)}
+//   - incidentID int64
+func (_e *MockPlaceRepo_Expecter) ClaimAffected(ctx interface{}, userID interface{}, incidentID interface{}) *MockPlaceRepo_ClaimAffected_Call {
+	return &MockPlaceRepo_ClaimAffected_Call{Call: _e.mock.On("ClaimAffected", ctx, userID, incidentID)}
 }
 
-func (_c *MockQueueProducer_EnqueueDangerAlert_Call) Run(run func(ctx context.Context, userID string, latitude float64, longitude float64)) *MockQueueProducer_EnqueueDangerAlert_Call {
+func (_c *MockPlaceRepo_ClaimAffected_Call) Run(run func(ctx context.Context, userID string, incidentID int64)) *MockPlaceRepo_ClaimAffected_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -398,30 +546,786 @@ func (_c *MockQueueProducer_EnqueueDangerAlert_Call) Run(run func(ctx context.Co
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 float64
+		var arg2 int64
 		if args[2] != nil {
-			arg2 = args[2].(float64)
-		}
-		var arg3 float64
-		if args[3] != nil {
-			arg3 = args[3].(float64)
+			arg2 = args[2].(int64)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
-			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockQueueProducer_EnqueueDangerAlert_Call) Return(err error) *MockQueueProducer_EnqueueDangerAlert_Call {
+func (_c *MockPlaceRepo_ClaimAffected_Call) Return(placeMatchs []models.PlaceMatch, err error) *MockPlaceRepo_ClaimAffected_Call {
+	_c.Call.Return(placeMatchs, err)
+	return _c
+}
+
+func (_c *MockPlaceRepo_ClaimAffected_Call) RunAndReturn(run func(ctx context.Context, userID string, incidentID int64) ([]models.PlaceMatch, error)) *MockPlaceRepo_ClaimAffected_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReleaseAlerts provides a mock function for the type MockPlaceRepo
+func (_mock *MockPlaceRepo) ReleaseAlerts(ctx context.Context, matches []models.PlaceMatch) error {
+	ret := _mock.Called(ctx, matches)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseAlerts")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []models.PlaceMatch) error); ok {
+		r0 = returnFunc(ctx, matches)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockPlaceRepo_ReleaseAlerts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseAlerts'
+type MockPlaceRepo_ReleaseAlerts_Call struct {
+	*mock.Call
+}
+
+// ReleaseAlerts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - matches []models.PlaceMatch
+func (_e *MockPlaceRepo_Expecter) ReleaseAlerts(ctx interface{}, matches interface{}) *MockPlaceRepo_ReleaseAlerts_Call {
+	return &MockPlaceRepo_ReleaseAlerts_Call{Call: _e.mock.On("ReleaseAlerts", ctx, matches)}
+}
+
+func (_c *MockPlaceRepo_ReleaseAlerts_Call) Run(run func(ctx context.Context, matches []models.PlaceMatch)) *MockPlaceRepo_ReleaseAlerts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []models.PlaceMatch
+		if args[1] != nil {
+			arg1 = args[1].([]models.PlaceMatch)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPlaceRepo_ReleaseAlerts_Call) Return(err error) *MockPlaceRepo_ReleaseAlerts_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockPlaceRepo_ReleaseAlerts_Call) RunAndReturn(run func(ctx context.Context, matches []models.PlaceMatch) error) *MockPlaceRepo_ReleaseAlerts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockDwellRepo creates a new instance of MockDwellRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDwellRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDwellRepo {
+	mock := &MockDwellRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockDwellRepo is an autogenerated mock type for the DwellRepo type
+type MockDwellRepo struct {
+	mock.Mock
+}
+
+type MockDwellRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDwellRepo) EXPECT() *MockDwellRepo_Expecter {
+	return &MockDwellRepo_Expecter{mock: &_m.Mock}
+}
+
+// ListPolicies provides a mock function for the type MockDwellRepo
+func (_mock *MockDwellRepo) ListPolicies(ctx context.Context) ([]models.EscalationPolicy, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPolicies")
+	}
+
+	var r0 []models.EscalationPolicy
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]models.EscalationPolicy, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []models.EscalationPolicy); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.EscalationPolicy)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDwellRepo_ListPolicies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPolicies'
+type MockDwellRepo_ListPolicies_Call struct {
+	*mock.Call
+}
+
+// ListPolicies is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockDwellRepo_Expecter) ListPolicies(ctx interface{}) *MockDwellRepo_ListPolicies_Call {
+	return &MockDwellRepo_ListPolicies_Call{Call: _e.mock.On("ListPolicies", ctx)}
+}
+
+func (_c *MockDwellRepo_ListPolicies_Call) Run(run func(ctx context.Context)) *MockDwellRepo_ListPolicies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDwellRepo_ListPolicies_Call) Return(escalationPolicys []models.EscalationPolicy, err error) *MockDwellRepo_ListPolicies_Call {
+	_c.Call.Return(escalationPolicys, err)
+	return _c
+}
+
+func (_c *MockDwellRepo_ListPolicies_Call) RunAndReturn(run func(ctx context.Context) ([]models.EscalationPolicy, error)) *MockDwellRepo_ListPolicies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TrackDwells provides a mock function for the type MockDwellRepo
+func (_mock *MockDwellRepo) TrackDwells(ctx context.Context, userID string, latitude float64, longitude float64, incidentIDs []int64, at time.Time) ([]models.ZoneDwell, []models.ZoneDwell, error) {
+	ret := _mock.Called(ctx, userID, latitude, longitude, incidentIDs, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TrackDwells")
+	}
+
+	var r0 []models.ZoneDwell
+	var r1 []models.ZoneDwell
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, float64, float64, []int64, time.Time) ([]models.ZoneDwell, []models.ZoneDwell, error)); ok {
+		return returnFunc(ctx, userID, latitude, longitude, incidentIDs, at)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, float64, float64, []int64, time.Time) []models.ZoneDwell); ok {
+		r0 = returnFunc(ctx, userID, latitude, longitude, incidentIDs, at)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ZoneDwell)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, float64, float64, []int64, time.Time) []models.ZoneDwell); ok {
+		r1 = returnFunc(ctx, userID, latitude, longitude, incidentIDs, at)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]models.ZoneDwell)
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, float64, float64, []int64, time.Time) error); ok {
+		r2 = returnFunc(ctx, userID, latitude, longitude, incidentIDs, at)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockDwellRepo_TrackDwells_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TrackDwells'
+type MockDwellRepo_TrackDwells_Call struct {
+	*mock.Call
+}
+
+// TrackDwells is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - latitude float64
+//   - longitude float64
+//   - incidentIDs []int64
+//   - at time.Time
+func (_e *MockDwellRepo_Expecter) TrackDwells(ctx interface{}, userID interface{}, latitude interface{}, longitude interface{}, incidentIDs interface{}, at interface{}) *MockDwellRepo_TrackDwells_Call {
+	return &MockDwellRepo_TrackDwells_Call{Call: _e.mock.On("TrackDwells", ctx, userID, latitude, longitude, incidentIDs, at)}
+}
+
+func (_c *MockDwellRepo_TrackDwells_Call) Run(run func(ctx context.Context, userID string, latitude float64, longitude float64, incidentIDs []int64, at time.Time)) *MockDwellRepo_TrackDwells_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 float64
+		if args[2] != nil {
+			arg2 = args[2].(float64)
+		}
+		var arg3 float64
+		if args[3] != nil {
+			arg3 = args[3].(float64)
+		}
+		var arg4 []int64
+		if args[4] != nil {
+			arg4 = args[4].([]int64)
+		}
+		var arg5 time.Time
+		if args[5] != nil {
+			arg5 = args[5].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDwellRepo_TrackDwells_Call) Return(entered []models.ZoneDwell, exited []models.ZoneDwell, err error) *MockDwellRepo_TrackDwells_Call {
+	_c.Call.Return(entered, exited, err)
+	return _c
+}
+
+func (_c *MockDwellRepo_TrackDwells_Call) RunAndReturn(run func(ctx context.Context, userID string, latitude float64, longitude float64, incidentIDs []int64, at time.Time) ([]models.ZoneDwell, []models.ZoneDwell, error)) *MockDwellRepo_TrackDwells_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockQueueProducer creates a new instance of MockQueueProducer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockQueueProducer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockQueueProducer {
+	mock := &MockQueueProducer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockQueueProducer is an autogenerated mock type for the QueueProducer type
+type MockQueueProducer struct {
+	mock.Mock
+}
+
+type MockQueueProducer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockQueueProducer) EXPECT() *MockQueueProducer_Expecter {
+	return &MockQueueProducer_Expecter{mock: &_m.Mock}
+}
+
+// CancelEscalations provides a mock function for the type MockQueueProducer
+func (_mock *MockQueueProducer) CancelEscalations(ctx context.Context, dwells []models.ZoneDwell) error {
+	ret := _mock.Called(ctx, dwells)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelEscalations")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []models.ZoneDwell) error); ok {
+		r0 = returnFunc(ctx, dwells)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQueueProducer_CancelEscalations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelEscalations'
+type MockQueueProducer_CancelEscalations_Call struct {
+	*mock.Call
+}
+
+// CancelEscalations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - dwells []models.ZoneDwell
+func (_e *MockQueueProducer_Expecter) CancelEscalations(ctx interface{}, dwells interface{}) *MockQueueProducer_CancelEscalations_Call {
+	return &MockQueueProducer_CancelEscalations_Call{Call: _e.mock.On("CancelEscalations", ctx, dwells)}
+}
+
+func (_c *MockQueueProducer_CancelEscalations_Call) Run(run func(ctx context.Context, dwells []models.ZoneDwell)) *MockQueueProducer_CancelEscalations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []models.ZoneDwell
+		if args[1] != nil {
+			arg1 = args[1].([]models.ZoneDwell)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQueueProducer_CancelEscalations_Call) Return(err error) *MockQueueProducer_CancelEscalations_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQueueProducer_CancelEscalations_Call) RunAndReturn(run func(ctx context.Context, dwells []models.ZoneDwell) error) *MockQueueProducer_CancelEscalations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnqueueCrossingAlert provides a mock function for the type MockQueueProducer
+func (_mock *MockQueueProducer) EnqueueCrossingAlert(ctx context.Context, userID string, latitude float64, longitude float64, crossed []models.IncidentShort) error {
+	ret := _mock.Called(ctx, userID, latitude, longitude, crossed)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnqueueCrossingAlert")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, float64, float64, []models.IncidentShort) error); ok {
+		r0 = returnFunc(ctx, userID, latitude, longitude, crossed)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQueueProducer_EnqueueCrossingAlert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnqueueCrossingAlert'
+type MockQueueProducer_EnqueueCrossingAlert_Call struct {
+	*mock.Call
+}
+
+// EnqueueCrossingAlert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - latitude float64
+//   - longitude float64
+//   - crossed []models.IncidentShort
+func (_e *MockQueueProducer_Expecter) EnqueueCrossingAlert(ctx interface{}, userID interface{}, latitude interface{}, longitude interface{}, crossed interface{}) *MockQueueProducer_EnqueueCrossingAlert_Call {
+	return &MockQueueProducer_EnqueueCrossingAlert_Call{Call: _e.mock.On("EnqueueCrossingAlert", ctx, userID, latitude, longitude, crossed)}
+}
+
+func (_c *MockQueueProducer_EnqueueCrossingAlert_Call) Run(run func(ctx context.Context, userID string, latitude float64, longitude float64, crossed []models.IncidentShort)) *MockQueueProducer_EnqueueCrossingAlert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 float64
+		if args[2] != nil {
+			arg2 = args[2].(float64)
+		}
+		var arg3 float64
+		if args[3] != nil {
+			arg3 = args[3].(float64)
+		}
+		var arg4 []models.IncidentShort
+		if args[4] != nil {
+			arg4 = args[4].([]models.IncidentShort)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQueueProducer_EnqueueCrossingAlert_Call) Return(err error) *MockQueueProducer_EnqueueCrossingAlert_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQueueProducer_EnqueueCrossingAlert_Call) RunAndReturn(run func(ctx context.Context, userID string, latitude float64, longitude float64, crossed []models.IncidentShort) error) *MockQueueProducer_EnqueueCrossingAlert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnqueueDangerAlert provides a mock function for the type MockQueueProducer
+func (_mock *MockQueueProducer) EnqueueDangerAlert(ctx context.Context, userID string, latitude float64, longitude float64, dangers []models.IncidentShort) error {
+	ret := _mock.Called(ctx, userID, latitude, longitude, dangers)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnqueueDangerAlert")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, float64, float64, []models.IncidentShort) error); ok {
+		r0 = returnFunc(ctx, userID, latitude, longitude, dangers)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQueueProducer_EnqueueDangerAlert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnqueueDangerAlert'
+type MockQueueProducer_EnqueueDangerAlert_Call struct {
+	*mock.Call
+}
+
+// EnqueueDangerAlert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - latitude float64
+//   - longitude float64
+//   - dangers []models.IncidentShort
+func (_e *MockQueueProducer_Expecter) EnqueueDangerAlert(ctx interface{}, userID interface{}, latitude interface{}, longitude interface{}, dangers interface{}) *MockQueueProducer_EnqueueDangerAlert_Call {
+	return &MockQueueProducer_EnqueueDangerAlert_Call{Call: _e.mock.On("EnqueueDangerAlert", ctx, userID, latitude, longitude, dangers)}
+}
+
+func (_c *MockQueueProducer_EnqueueDangerAlert_Call) Run(run func(ctx context.Context, userID string, latitude float64, longitude float64, dangers []models.IncidentShort)) *MockQueueProducer_EnqueueDangerAlert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 float64
+		if args[2] != nil {
+			arg2 = args[2].(float64)
+		}
+		var arg3 float64
+		if args[3] != nil {
+			arg3 = args[3].(float64)
+		}
+		var arg4 []models.IncidentShort
+		if args[4] != nil {
+			arg4 = args[4].([]models.IncidentShort)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQueueProducer_EnqueueDangerAlert_Call) Return(err error) *MockQueueProducer_EnqueueDangerAlert_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQueueProducer_EnqueueDangerAlert_Call) RunAndReturn(run func(ctx context.Context, userID string, latitude float64, longitude float64, dangers []models.IncidentShort) error) *MockQueueProducer_EnqueueDangerAlert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnqueuePlaceAlerts provides a mock function for the type MockQueueProducer
+func (_mock *MockQueueProducer) EnqueuePlaceAlerts(ctx context.Context, matches []models.PlaceMatch) ([]models.PlaceMatch, error) {
+	ret := _mock.Called(ctx, matches)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnqueuePlaceAlerts")
+	}
+
+	var r0 []models.PlaceMatch
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []models.PlaceMatch) ([]models.PlaceMatch, error)); ok {
+		return returnFunc(ctx, matches)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []models.PlaceMatch) []models.PlaceMatch); ok {
+		r0 = returnFunc(ctx, matches)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.PlaceMatch)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []models.PlaceMatch) error); ok {
+		r1 = returnFunc(ctx, matches)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQueueProducer_EnqueuePlaceAlerts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnqueuePlaceAlerts'
+type MockQueueProducer_EnqueuePlaceAlerts_Call struct {
+	*mock.Call
+}
+
+// EnqueuePlaceAlerts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - matches []models.PlaceMatch
+func (_e *MockQueueProducer_Expecter) EnqueuePlaceAlerts(ctx interface{}, matches interface{}) *MockQueueProducer_EnqueuePlaceAlerts_Call {
+	return &MockQueueProducer_EnqueuePlaceAlerts_Call{Call: _e.mock.On("EnqueuePlaceAlerts", ctx, matches)}
+}
+
+func (_c *MockQueueProducer_EnqueuePlaceAlerts_Call) Run(run func(ctx context.Context, matches []models.PlaceMatch)) *MockQueueProducer_EnqueuePlaceAlerts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []models.PlaceMatch
+		if args[1] != nil {
+			arg1 = args[1].([]models.PlaceMatch)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQueueProducer_EnqueuePlaceAlerts_Call) Return(placeMatchs []models.PlaceMatch, err error) *MockQueueProducer_EnqueuePlaceAlerts_Call {
+	_c.Call.Return(placeMatchs, err)
+	return _c
+}
+
+func (_c *MockQueueProducer_EnqueuePlaceAlerts_Call) RunAndReturn(run func(ctx context.Context, matches []models.PlaceMatch) ([]models.PlaceMatch, error)) *MockQueueProducer_EnqueuePlaceAlerts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnqueuePredictedAlert provides a mock function for the type MockQueueProducer
+func (_mock *MockQueueProducer) EnqueuePredictedAlert(ctx context.Context, userID string, latitude float64, longitude float64, predicted []models.PredictedIncident) error {
+	ret := _mock.Called(ctx, userID, latitude, longitude, predicted)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnqueuePredictedAlert")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, float64, float64, []models.PredictedIncident) error); ok {
+		r0 = returnFunc(ctx, userID, latitude, longitude, predicted)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQueueProducer_EnqueuePredictedAlert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnqueuePredictedAlert'
+type MockQueueProducer_EnqueuePredictedAlert_Call struct {
+	*mock.Call
+}
+
+// EnqueuePredictedAlert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - latitude float64
+//   - longitude float64
+//   - predicted []models.PredictedIncident
+func (_e *MockQueueProducer_Expecter) EnqueuePredictedAlert(ctx interface{}, userID interface{}, latitude interface{}, longitude interface{}, predicted interface{}) *MockQueueProducer_EnqueuePredictedAlert_Call {
+	return &MockQueueProducer_EnqueuePredictedAlert_Call{Call: _e.mock.On("EnqueuePredictedAlert", ctx, userID, latitude, longitude, predicted)}
+}
+
+func (_c *MockQueueProducer_EnqueuePredictedAlert_Call) Run(run func(ctx context.Context, userID string, latitude float64, longitude float64, predicted []models.PredictedIncident)) *MockQueueProducer_EnqueuePredictedAlert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 float64
+		if args[2] != nil {
+			arg2 = args[2].(float64)
+		}
+		var arg3 float64
+		if args[3] != nil {
+			arg3 = args[3].(float64)
+		}
+		var arg4 []models.PredictedIncident
+		if args[4] != nil {
+			arg4 = args[4].([]models.PredictedIncident)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQueueProducer_EnqueuePredictedAlert_Call) Return(err error) *MockQueueProducer_EnqueuePredictedAlert_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQueueProducer_EnqueuePredictedAlert_Call) RunAndReturn(run func(ctx context.Context, userID string, latitude float64, longitude float64, predicted []models.PredictedIncident) error) *MockQueueProducer_EnqueuePredictedAlert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnqueueProximityAlert provides a mock function for the type MockQueueProducer
+func (_mock *MockQueueProducer) EnqueueProximityAlert(ctx context.Context, userID string, latitude float64, longitude float64, nearby []models.NearbyIncident) error {
+	ret := _mock.Called(ctx, userID, latitude, longitude, nearby)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnqueueProximityAlert")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, float64, float64, []models.NearbyIncident) error); ok {
+		r0 = returnFunc(ctx, userID, latitude, longitude, nearby)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQueueProducer_EnqueueProximityAlert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnqueueProximityAlert'
+type MockQueueProducer_EnqueueProximityAlert_Call struct {
+	*mock.Call
+}
+
+// EnqueueProximityAlert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - latitude float64
+//   - longitude float64
+//   - nearby []models.NearbyIncident
+func (_e *MockQueueProducer_Expecter) EnqueueProximityAlert(ctx interface{}, userID interface{}, latitude interface{}, longitude interface{}, nearby interface{}) *MockQueueProducer_EnqueueProximityAlert_Call {
+	return &MockQueueProducer_EnqueueProximityAlert_Call{Call: _e.mock.On("EnqueueProximityAlert", ctx, userID, latitude, longitude, nearby)}
+}
+
+func (_c *MockQueueProducer_EnqueueProximityAlert_Call) Run(run func(ctx context.Context, userID string, latitude float64, longitude float64, nearby []models.NearbyIncident)) *MockQueueProducer_EnqueueProximityAlert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 float64
+		if args[2] != nil {
+			arg2 = args[2].(float64)
+		}
+		var arg3 float64
+		if args[3] != nil {
+			arg3 = args[3].(float64)
+		}
+		var arg4 []models.NearbyIncident
+		if args[4] != nil {
+			arg4 = args[4].([]models.NearbyIncident)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQueueProducer_EnqueueProximityAlert_Call) Return(err error) *MockQueueProducer_EnqueueProximityAlert_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQueueProducer_EnqueueProximityAlert_Call) RunAndReturn(run func(ctx context.Context, userID string, latitude float64, longitude float64, nearby []models.NearbyIncident) error) *MockQueueProducer_EnqueueProximityAlert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ScheduleEscalations provides a mock function for the type MockQueueProducer
+func (_mock *MockQueueProducer) ScheduleEscalations(ctx context.Context, dwell *models.ZoneDwell, policy *models.EscalationPolicy) error {
+	ret := _mock.Called(ctx, dwell, policy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ScheduleEscalations")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.ZoneDwell, *models.EscalationPolicy) error); ok {
+		r0 = returnFunc(ctx, dwell, policy)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQueueProducer_ScheduleEscalations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ScheduleEscalations'
+type MockQueueProducer_ScheduleEscalations_Call struct {
+	*mock.Call
+}
+
+// ScheduleEscalations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - dwell *models.ZoneDwell
+//   - policy *models.EscalationPolicy
+func (_e *MockQueueProducer_Expecter) ScheduleEscalations(ctx interface{}, dwell interface{}, policy interface{}) *MockQueueProducer_ScheduleEscalations_Call {
+	return &MockQueueProducer_ScheduleEscalations_Call{Call: _e.mock.On("ScheduleEscalations", ctx, dwell, policy)}
+}
+
+func (_c *MockQueueProducer_ScheduleEscalations_Call) Run(run func(ctx context.Context, dwell *models.ZoneDwell, policy *models.EscalationPolicy)) *MockQueueProducer_ScheduleEscalations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *models.ZoneDwell
+		if args[1] != nil {
+			arg1 = args[1].(*models.ZoneDwell)
+		}
+		var arg2 *models.EscalationPolicy
+		if args[2] != nil {
+			arg2 = args[2].(*models.EscalationPolicy)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQueueProducer_ScheduleEscalations_Call) Return(err error) *MockQueueProducer_ScheduleEscalations_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockQueueProducer_EnqueueDangerAlert_Call) RunAndReturn(run func(ctx context.Context, userID string, latitude float64, longitude float64) error) *MockQueueProducer_EnqueueDangerAlert_Call {
+func (_c *MockQueueProducer_ScheduleEscalations_Call) RunAndReturn(run func(ctx context.Context, dwell *models.ZoneDwell, policy *models.EscalationPolicy) error) *MockQueueProducer_ScheduleEscalations_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,115 @@
+package place
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/ocenb/geo-alerts/internal/domain/errs"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/logger/logattr"
+)
+
+type PlaceRepo interface {
+	Create(ctx context.Context, params *models.SavePlaceParams) (*models.SavedPlace, error)
+	Update(ctx context.Context, params *models.SavePlaceParams) (*models.SavedPlace, error)
+	Delete(ctx context.Context, userID string, id int64) error
+	ListByUser(ctx context.Context, userID string) ([]models.SavedPlace, error)
+	ClaimAffected(ctx context.Context, userID string, incidentID int64) ([]models.PlaceMatch, error)
+	ReleaseAlerts(ctx context.Context, matches []models.PlaceMatch) error
+}
+
+type QueueProducer interface {
+	EnqueuePlaceAlerts(ctx context.Context, matches []models.PlaceMatch) ([]models.PlaceMatch, error)
+}
+
+type Service struct {
+	log       *slog.Logger
+	placeRepo PlaceRepo
+	queue     QueueProducer
+}
+
+func New(log *slog.Logger, placeRepo PlaceRepo, queue QueueProducer) *Service {
+	return &Service{
+		log:       log,
+		placeRepo: placeRepo,
+		queue:     queue,
+	}
+}
+
+// Create saves the place. If an active zone already reaches into it, the owner is alerted right away.
+func (s *Service) Create(ctx context.Context, params *models.SavePlaceParams) (*models.SavedPlace, error) {
+	log := s.log.With(logattr.Op("PlaceService.Create"), slog.String("user_id", params.UserID))
+
+	place, err := s.placeRepo.Create(ctx, params)
+	if err != nil {
+		if !errors.Is(err, errs.ErrPlaceExists) {
+			log.Error("failed to create saved place", logattr.Err(err))
+		}
+		return nil, err
+	}
+
+	s.alertPlaces(ctx, log, params.UserID)
+
+	log.Info("saved place created", slog.Int64("place_id", place.ID))
+	return place, nil
+}
+
+// Update moves or resizes the place. The owner hears again about the zones reaching into it at its new extent.
+func (s *Service) Update(ctx context.Context, params *models.SavePlaceParams) (*models.SavedPlace, error) {
+	log := s.log.With(logattr.Op("PlaceService.Update"), slog.String("user_id", params.UserID), slog.Int64("place_id", params.ID))
+
+	place, err := s.placeRepo.Update(ctx, params)
+	if err != nil {
+		if !errors.Is(err, errs.ErrPlaceNotFound) && !errors.Is(err, errs.ErrPlaceExists) {
+			log.Error("failed to update saved place", logattr.Err(err))
+		}
+		return nil, err
+	}
+
+	s.alertPlaces(ctx, log, params.UserID)
+
+	return place, nil
+}
+
+func (s *Service) Delete(ctx context.Context, userID string, id int64) error {
+	if err := s.placeRepo.Delete(ctx, userID, id); err != nil {
+		if !errors.Is(err, errs.ErrPlaceNotFound) {
+			s.log.Error("failed to delete saved place", logattr.Op("PlaceService.Delete"), slog.Int64("place_id", id), logattr.Err(err))
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *Service) List(ctx context.Context, userID string) ([]models.SavedPlace, error) {
+	places, err := s.placeRepo.ListByUser(ctx, userID)
+	if err != nil {
+		s.log.Error("failed to list saved places", logattr.Op("PlaceService.List"), logattr.Err(err))
+		return nil, err
+	}
+
+	return places, nil
+}
+
+// alertPlaces queues alerts for the user's places the active zones reach into. The place is already stored,
+// so a failure is only logged, the unsent alerts are released for the next location check.
+func (s *Service) alertPlaces(ctx context.Context, log *slog.Logger, userID string) {
+	matches, err := s.placeRepo.ClaimAffected(ctx, userID, 0)
+	if err != nil {
+		log.Error("failed to find zones reaching saved places", logattr.Err(err))
+		return
+	}
+	if len(matches) == 0 {
+		return
+	}
+
+	failed, err := s.queue.EnqueuePlaceAlerts(ctx, matches)
+	if err != nil {
+		log.Error("failed to enqueue place alerts", logattr.Err(err))
+		if relErr := s.placeRepo.ReleaseAlerts(ctx, failed); relErr != nil {
+			log.Error("failed to release unsent place alerts", logattr.Err(relErr))
+		}
+	}
+}
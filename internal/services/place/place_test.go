@@ -0,0 +1,149 @@
+package place
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ocenb/geo-alerts/internal/domain/errs"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/logger"
+)
+
+type PlaceServiceSuite struct {
+	suite.Suite
+	mockPlaceRepo *MockPlaceRepo
+	mockQueue     *MockQueueProducer
+	service       *Service
+}
+
+func (s *PlaceServiceSuite) SetupTest() {
+	s.mockPlaceRepo = NewMockPlaceRepo(s.T())
+	s.mockQueue = NewMockQueueProducer(s.T())
+	s.service = New(logger.NewDiscard(), s.mockPlaceRepo, s.mockQueue)
+}
+
+func TestPlaceServiceSuite(t *testing.T) {
+	suite.Run(t, new(PlaceServiceSuite))
+}
+
+// --- Tests for Create ---
+
+func (s *PlaceServiceSuite) TestCreate_Success() {
+	ctx := context.Background()
+	params := &models.SavePlaceParams{UserID: "u1", Name: "home", Latitude: 10, Longitude: 10, Radius: 200}
+	expected := &models.SavedPlace{ID: 1, UserID: "u1", Name: "home", Latitude: 10, Longitude: 10, Radius: 200}
+
+	s.mockPlaceRepo.On("Create", ctx, params).Return(expected, nil)
+	s.mockPlaceRepo.On("ClaimAffected", ctx, "u1", int64(0)).Return([]models.PlaceMatch{}, nil)
+
+	res, err := s.service.Create(ctx, params)
+
+	s.NoError(err)
+	s.Equal(expected, res)
+	s.mockQueue.AssertNotCalled(s.T(), "EnqueuePlaceAlerts", mock.Anything, mock.Anything)
+}
+
+func (s *PlaceServiceSuite) TestCreate_InsideActiveZone() {
+	ctx := context.Background()
+	params := &models.SavePlaceParams{UserID: "u1", Name: "home", Latitude: 10, Longitude: 10, Radius: 200}
+	expected := &models.SavedPlace{ID: 1, UserID: "u1", Name: "home", Latitude: 10, Longitude: 10, Radius: 200}
+	matches := []models.PlaceMatch{{Place: *expected, Incident: models.IncidentShort{ID: 7}}}
+
+	s.mockPlaceRepo.On("Create", ctx, params).Return(expected, nil)
+	s.mockPlaceRepo.On("ClaimAffected", ctx, "u1", int64(0)).Return(matches, nil)
+	s.mockQueue.On("EnqueuePlaceAlerts", ctx, matches).Return(nil, nil)
+
+	res, err := s.service.Create(ctx, params)
+
+	s.NoError(err)
+	s.Equal(expected, res)
+}
+
+func (s *PlaceServiceSuite) TestCreate_AlertEnqueueError() {
+	ctx := context.Background()
+	params := &models.SavePlaceParams{UserID: "u1", Name: "home", Latitude: 10, Longitude: 10, Radius: 200}
+	expected := &models.SavedPlace{ID: 1, UserID: "u1", Name: "home", Latitude: 10, Longitude: 10, Radius: 200}
+	matches := []models.PlaceMatch{{Place: *expected, Incident: models.IncidentShort{ID: 7}}}
+
+	s.mockPlaceRepo.On("Create", ctx, params).Return(expected, nil)
+	s.mockPlaceRepo.On("ClaimAffected", ctx, "u1", int64(0)).Return(matches, nil)
+	s.mockQueue.On("EnqueuePlaceAlerts", ctx, matches).Return(matches, errors.New("redis down"))
+	s.mockPlaceRepo.On("ReleaseAlerts", ctx, matches).Return(nil)
+
+	res, err := s.service.Create(ctx, params)
+
+	// The place is stored, so the failure doesn't fail the request.
+	s.NoError(err)
+	s.Equal(expected, res)
+}
+
+func (s *PlaceServiceSuite) TestCreate_Exists() {
+	ctx := context.Background()
+	params := &models.SavePlaceParams{UserID: "u1", Name: "home"}
+
+	s.mockPlaceRepo.On("Create", ctx, params).Return(nil, errs.ErrPlaceExists)
+
+	res, err := s.service.Create(ctx, params)
+
+	s.ErrorIs(err, errs.ErrPlaceExists)
+	s.Nil(res)
+	s.mockPlaceRepo.AssertNotCalled(s.T(), "ClaimAffected", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// --- Tests for Update ---
+
+func (s *PlaceServiceSuite) TestUpdate_Success() {
+	ctx := context.Background()
+	params := &models.SavePlaceParams{ID: 1, UserID: "u1", Name: "work", Latitude: 20, Longitude: 20, Radius: 500}
+	expected := &models.SavedPlace{ID: 1, UserID: "u1", Name: "work", Latitude: 20, Longitude: 20, Radius: 500}
+
+	s.mockPlaceRepo.On("Update", ctx, params).Return(expected, nil)
+	s.mockPlaceRepo.On("ClaimAffected", ctx, "u1", int64(0)).Return([]models.PlaceMatch{}, nil)
+
+	res, err := s.service.Update(ctx, params)
+
+	s.NoError(err)
+	s.Equal(expected, res)
+}
+
+func (s *PlaceServiceSuite) TestUpdate_NotFound() {
+	ctx := context.Background()
+	params := &models.SavePlaceParams{ID: 1, UserID: "u1", Name: "work"}
+
+	s.mockPlaceRepo.On("Update", ctx, params).Return(nil, errs.ErrPlaceNotFound)
+
+	res, err := s.service.Update(ctx, params)
+
+	s.ErrorIs(err, errs.ErrPlaceNotFound)
+	s.Nil(res)
+}
+
+// --- Tests for Delete ---
+
+func (s *PlaceServiceSuite) TestDelete_NotFound() {
+	ctx := context.Background()
+
+	s.mockPlaceRepo.On("Delete", ctx, "u1", int64(1)).Return(errs.ErrPlaceNotFound)
+
+	err := s.service.Delete(ctx, "u1", 1)
+
+	s.ErrorIs(err, errs.ErrPlaceNotFound)
+}
+
+// --- Tests for List ---
+
+func (s *PlaceServiceSuite) TestList_Success() {
+	ctx := context.Background()
+	expected := []models.SavedPlace{{ID: 1, UserID: "u1", Name: "home"}, {ID: 2, UserID: "u1", Name: "work"}}
+
+	s.mockPlaceRepo.On("ListByUser", ctx, "u1").Return(expected, nil)
+
+	res, err := s.service.List(ctx, "u1")
+
+	s.NoError(err)
+	s.Equal(expected, res)
+}
@@ -0,0 +1,58 @@
+package preferences
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/ocenb/geo-alerts/internal/domain/errs"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/logger/logattr"
+)
+
+type PreferencesRepo interface {
+	Get(ctx context.Context, userID string) (*models.UserPreferences, error)
+	Upsert(ctx context.Context, params *models.UpdatePreferencesParams) (*models.UserPreferences, error)
+}
+
+type Service struct {
+	log       *slog.Logger
+	prefsRepo PreferencesRepo
+}
+
+func New(log *slog.Logger, prefsRepo PreferencesRepo) *Service {
+	return &Service{
+		log:       log,
+		prefsRepo: prefsRepo,
+	}
+}
+
+// Get returns the preferences of the user, or the defaults if they never set any.
+func (s *Service) Get(ctx context.Context, userID string) (*models.UserPreferences, error) {
+	prefs, err := s.prefsRepo.Get(ctx, userID)
+	if err != nil {
+		if errors.Is(err, errs.ErrPreferencesNotFound) {
+			return models.DefaultPreferences(userID), nil
+		}
+		s.log.Error("failed to get preferences", logattr.Op("PreferencesService.Get"), logattr.Err(err))
+		return nil, err
+	}
+
+	return prefs, nil
+}
+
+// Update replaces the preferences of the user. They apply from the next location check on.
+func (s *Service) Update(ctx context.Context, params *models.UpdatePreferencesParams) (*models.UserPreferences, error) {
+	if params.Timezone == "" {
+		params.Timezone = "UTC"
+	}
+
+	prefs, err := s.prefsRepo.Upsert(ctx, params)
+	if err != nil {
+		s.log.Error("failed to update preferences", logattr.Op("PreferencesService.Update"), logattr.Err(err))
+		return nil, err
+	}
+
+	s.log.Info("preferences updated", slog.Bool("opted_out", prefs.OptedOut), slog.Int("min_severity", prefs.MinSeverity))
+	return prefs, nil
+}
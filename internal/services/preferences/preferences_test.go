@@ -0,0 +1,83 @@
+package preferences
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ocenb/geo-alerts/internal/domain/errs"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/logger"
+)
+
+type PreferencesServiceSuite struct {
+	suite.Suite
+	mockPrefsRepo *MockPreferencesRepo
+	service       *Service
+}
+
+func (s *PreferencesServiceSuite) SetupTest() {
+	s.mockPrefsRepo = NewMockPreferencesRepo(s.T())
+	s.service = New(logger.NewDiscard(), s.mockPrefsRepo)
+}
+
+func TestPreferencesServiceSuite(t *testing.T) {
+	suite.Run(t, new(PreferencesServiceSuite))
+}
+
+// --- Tests for Get ---
+
+func (s *PreferencesServiceSuite) TestGet_Saved() {
+	ctx := context.Background()
+	expected := &models.UserPreferences{UserID: "u1", MinSeverity: 3, Timezone: "Europe/Moscow"}
+
+	s.mockPrefsRepo.On("Get", ctx, "u1").Return(expected, nil)
+
+	res, err := s.service.Get(ctx, "u1")
+
+	s.NoError(err)
+	s.Equal(expected, res)
+}
+
+func (s *PreferencesServiceSuite) TestGet_Defaults() {
+	ctx := context.Background()
+
+	s.mockPrefsRepo.On("Get", ctx, "u1").Return(nil, errs.ErrPreferencesNotFound)
+
+	res, err := s.service.Get(ctx, "u1")
+
+	s.NoError(err)
+	s.Equal(models.DefaultPreferences("u1"), res)
+}
+
+func (s *PreferencesServiceSuite) TestGet_RepoError() {
+	ctx := context.Background()
+	dbErr := errors.New("db error")
+
+	s.mockPrefsRepo.On("Get", ctx, "u1").Return(nil, dbErr)
+
+	res, err := s.service.Get(ctx, "u1")
+
+	s.ErrorIs(err, dbErr)
+	s.Nil(res)
+}
+
+// --- Tests for Update ---
+
+func (s *PreferencesServiceSuite) TestUpdate_DefaultTimezone() {
+	ctx := context.Background()
+	params := &models.UpdatePreferencesParams{UserID: "u1", QuietHours: &models.QuietHours{Start: "22:00", End: "07:00"}}
+	expected := &models.UserPreferences{UserID: "u1", QuietHours: params.QuietHours, Timezone: "UTC"}
+
+	s.mockPrefsRepo.On("Upsert", ctx, mock.MatchedBy(func(p *models.UpdatePreferencesParams) bool {
+		return p.Timezone == "UTC"
+	})).Return(expected, nil)
+
+	res, err := s.service.Update(ctx, params)
+
+	s.NoError(err)
+	s.Equal(expected, res)
+}
@@ -0,0 +1,102 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/ocenb/geo-alerts/internal/domain/errs"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/logger/logattr"
+)
+
+type Inspector interface {
+	Queues() ([]models.QueueInfo, error)
+	ListTasks(queue, state string, filter models.QueueTaskFilter, limit, offset int) ([]models.QueueTask, error)
+	RunTask(queue, id string) error
+	DeleteTask(queue, id string) error
+	ReplayArchived(queue string, filter models.QueueTaskFilter) (int, error)
+}
+
+type Service struct {
+	log       *slog.Logger
+	inspector Inspector
+}
+
+func New(log *slog.Logger, inspector Inspector) *Service {
+	return &Service{
+		log:       log,
+		inspector: inspector,
+	}
+}
+
+func (s *Service) Queues(ctx context.Context) ([]models.QueueInfo, error) {
+	queues, err := s.inspector.Queues()
+	if err != nil {
+		s.log.Error("failed to list queues", logattr.Op("QueueService.Queues"), logattr.Err(err))
+		return nil, err
+	}
+
+	return queues, nil
+}
+
+func (s *Service) ListTasks(ctx context.Context, queue, state string, filter models.QueueTaskFilter, limit, offset int) ([]models.QueueTask, error) {
+	tasks, err := s.inspector.ListTasks(queue, state, filter, limit, offset)
+	if err != nil {
+		if !errors.Is(err, errs.ErrQueueNotFound) {
+			s.log.Error("failed to list tasks", logattr.Op("QueueService.ListTasks"), slog.String("queue", queue), slog.String("state", state), logattr.Err(err))
+		}
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+func (s *Service) RunTask(ctx context.Context, queue, id string) error {
+	log := s.log.With(logattr.Op("QueueService.RunTask"), slog.String("queue", queue), slog.String("task_id", id))
+
+	if err := s.inspector.RunTask(queue, id); err != nil {
+		if !isClientErr(err) {
+			log.Error("failed to run task", logattr.Err(err))
+		}
+		return err
+	}
+
+	log.Info("task moved to pending")
+	return nil
+}
+
+func (s *Service) DeleteTask(ctx context.Context, queue, id string) error {
+	log := s.log.With(logattr.Op("QueueService.DeleteTask"), slog.String("queue", queue), slog.String("task_id", id))
+
+	if err := s.inspector.DeleteTask(queue, id); err != nil {
+		if !isClientErr(err) {
+			log.Error("failed to delete task", logattr.Err(err))
+		}
+		return err
+	}
+
+	log.Info("task deleted")
+	return nil
+}
+
+// ReplayArchived moves the archived tasks that match the filter back to pending.
+// On failure the tasks replayed so far stay replayed.
+func (s *Service) ReplayArchived(ctx context.Context, queue string, filter models.QueueTaskFilter) (*models.ReplayResult, error) {
+	log := s.log.With(logattr.Op("QueueService.ReplayArchived"), slog.String("queue", queue))
+
+	replayed, err := s.inspector.ReplayArchived(queue, filter)
+	if err != nil {
+		if !errors.Is(err, errs.ErrQueueNotFound) {
+			log.Error("failed to replay archived tasks", slog.Int("replayed", replayed), logattr.Err(err))
+		}
+		return nil, err
+	}
+
+	log.Info("archived tasks replayed", slog.Int("replayed", replayed))
+	return &models.ReplayResult{Replayed: replayed}, nil
+}
+
+func isClientErr(err error) bool {
+	return errors.Is(err, errs.ErrQueueNotFound) || errors.Is(err, errs.ErrTaskNotFound) || errors.Is(err, errs.ErrTaskState)
+}
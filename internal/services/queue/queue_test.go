@@ -0,0 +1,133 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ocenb/geo-alerts/internal/domain/errs"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/logger"
+)
+
+type QueueServiceSuite struct {
+	suite.Suite
+	mockInspector *MockInspector
+	service       *Service
+}
+
+func (s *QueueServiceSuite) SetupTest() {
+	s.mockInspector = NewMockInspector(s.T())
+
+	s.service = New(
+		logger.NewDiscard(),
+		s.mockInspector,
+	)
+}
+
+func TestQueueServiceSuite(t *testing.T) {
+	suite.Run(t, new(QueueServiceSuite))
+}
+
+// --- Tests for Queues ---
+
+func (s *QueueServiceSuite) TestQueues_Success() {
+	ctx := context.Background()
+	expected := []models.QueueInfo{{Queue: "default", Archived: 3}}
+
+	s.mockInspector.On("Queues").Return(expected, nil)
+
+	res, err := s.service.Queues(ctx)
+
+	s.NoError(err)
+	s.Equal(expected, res)
+}
+
+// --- Tests for ListTasks ---
+
+func (s *QueueServiceSuite) TestListTasks_Success() {
+	ctx := context.Background()
+	from := time.Now().Add(-time.Hour)
+	filter := models.QueueTaskFilter{Error: "status: 500", From: &from}
+	expected := []models.QueueTask{{ID: "t1", State: models.TaskStateArchived}}
+
+	s.mockInspector.On("ListTasks", "default", models.TaskStateArchived, filter, 10, 0).Return(expected, nil)
+
+	res, err := s.service.ListTasks(ctx, "default", models.TaskStateArchived, filter, 10, 0)
+
+	s.NoError(err)
+	s.Equal(expected, res)
+}
+
+func (s *QueueServiceSuite) TestListTasks_QueueNotFound() {
+	ctx := context.Background()
+
+	s.mockInspector.On("ListTasks", "missing", models.TaskStatePending, models.QueueTaskFilter{}, 10, 0).Return(nil, errs.ErrQueueNotFound)
+
+	res, err := s.service.ListTasks(ctx, "missing", models.TaskStatePending, models.QueueTaskFilter{}, 10, 0)
+
+	s.ErrorIs(err, errs.ErrQueueNotFound)
+	s.Nil(res)
+}
+
+// --- Tests for RunTask ---
+
+func (s *QueueServiceSuite) TestRunTask_Success() {
+	ctx := context.Background()
+
+	s.mockInspector.On("RunTask", "default", "t1").Return(nil)
+
+	err := s.service.RunTask(ctx, "default", "t1")
+
+	s.NoError(err)
+}
+
+func (s *QueueServiceSuite) TestRunTask_AlreadyPending() {
+	ctx := context.Background()
+
+	s.mockInspector.On("RunTask", "default", "t1").Return(errs.ErrTaskState)
+
+	err := s.service.RunTask(ctx, "default", "t1")
+
+	s.ErrorIs(err, errs.ErrTaskState)
+}
+
+// --- Tests for DeleteTask ---
+
+func (s *QueueServiceSuite) TestDeleteTask_NotFound() {
+	ctx := context.Background()
+
+	s.mockInspector.On("DeleteTask", "default", "t1").Return(errs.ErrTaskNotFound)
+
+	err := s.service.DeleteTask(ctx, "default", "t1")
+
+	s.ErrorIs(err, errs.ErrTaskNotFound)
+}
+
+// --- Tests for ReplayArchived ---
+
+func (s *QueueServiceSuite) TestReplayArchived_Success() {
+	ctx := context.Background()
+	filter := models.QueueTaskFilter{Error: "timeout"}
+
+	s.mockInspector.On("ReplayArchived", "default", filter).Return(4, nil)
+
+	res, err := s.service.ReplayArchived(ctx, "default", filter)
+
+	s.NoError(err)
+	s.Equal(&models.ReplayResult{Replayed: 4}, res)
+}
+
+func (s *QueueServiceSuite) TestReplayArchived_Error() {
+	ctx := context.Background()
+
+	s.mockInspector.On("ReplayArchived", "default", models.QueueTaskFilter{}).Return(1, errors.New("redis down"))
+
+	res, err := s.service.ReplayArchived(ctx, "default", models.QueueTaskFilter{})
+
+	s.Error(err)
+	s.Nil(res)
+}
@@ -0,0 +1,150 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/ocenb/geo-alerts/internal/domain/errs"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/logger/logattr"
+)
+
+type SubscriptionRepo interface {
+	Create(ctx context.Context, params *models.CreateWebhookSubscriptionParams) (*models.WebhookSubscription, error)
+	GetByID(ctx context.Context, id int64) (*models.WebhookSubscription, error)
+	Update(ctx context.Context, params *models.UpdateWebhookSubscriptionParams) (*models.WebhookSubscription, error)
+	Delete(ctx context.Context, id int64) error
+	List(ctx context.Context, limit, offset int) ([]models.WebhookSubscription, error)
+}
+
+type DeliveryRepo interface {
+	GetDeliveryByID(ctx context.Context, id int64) (*models.WebhookDelivery, error)
+	ListDeliveries(ctx context.Context, params *models.ListWebhookDeliveriesParams) ([]models.WebhookDelivery, error)
+}
+
+type QueueProducer interface {
+	EnqueueRedelivery(ctx context.Context, d *models.WebhookDelivery) (string, error)
+}
+
+type OutboundStatsProvider interface {
+	OutboundStats() *models.OutboundStats
+}
+
+type Service struct {
+	log                 *slog.Logger
+	secretRotationGrace time.Duration
+	subRepo             SubscriptionRepo
+	deliveryRepo        DeliveryRepo
+	queue               QueueProducer
+	outbound            OutboundStatsProvider
+}
+
+func New(log *slog.Logger, secretRotationGrace time.Duration, subRepo SubscriptionRepo, deliveryRepo DeliveryRepo, queue QueueProducer, outbound OutboundStatsProvider) *Service {
+	return &Service{
+		log:                 log,
+		secretRotationGrace: secretRotationGrace,
+		subRepo:             subRepo,
+		deliveryRepo:        deliveryRepo,
+		queue:               queue,
+		outbound:            outbound,
+	}
+}
+
+func (s *Service) Create(ctx context.Context, params *models.CreateWebhookSubscriptionParams) (*models.WebhookSubscription, error) {
+	created, err := s.subRepo.Create(ctx, params)
+	if err != nil {
+		s.log.Error("failed to create webhook subscription", logattr.Op("WebhookService.Create"), slog.String("url", params.URL), logattr.Err(err))
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (s *Service) GetByID(ctx context.Context, id int64) (*models.WebhookSubscription, error) {
+	sub, err := s.subRepo.GetByID(ctx, id)
+	if err != nil {
+		if !errors.Is(err, errs.ErrSubscriptionNotFound) {
+			s.log.Error("failed to get webhook subscription", logattr.Op("WebhookService.GetByID"), slog.Int64("id", id), logattr.Err(err))
+		}
+		return nil, err
+	}
+
+	return sub, nil
+}
+
+// Update changes a subscription. A new secret doesn't invalidate the old one at once,
+// deliveries are signed with both for the rotation grace period.
+func (s *Service) Update(ctx context.Context, params *models.UpdateWebhookSubscriptionParams) (*models.WebhookSubscription, error) {
+	params.SecretRotationGrace = s.secretRotationGrace
+
+	updated, err := s.subRepo.Update(ctx, params)
+	if err != nil {
+		if !errors.Is(err, errs.ErrSubscriptionNotFound) {
+			s.log.Error("failed to update webhook subscription", logattr.Op("WebhookService.Update"), slog.Int64("id", params.ID), logattr.Err(err))
+		}
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+func (s *Service) Delete(ctx context.Context, id int64) error {
+	if err := s.subRepo.Delete(ctx, id); err != nil {
+		if !errors.Is(err, errs.ErrSubscriptionNotFound) {
+			s.log.Error("failed to delete webhook subscription", logattr.Op("WebhookService.Delete"), slog.Int64("id", id), logattr.Err(err))
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *Service) List(ctx context.Context, limit, offset int) ([]models.WebhookSubscription, error) {
+	list, err := s.subRepo.List(ctx, limit, offset)
+	if err != nil {
+		s.log.Error("failed to list webhook subscriptions", logattr.Op("WebhookService.List"), logattr.Err(err))
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (s *Service) ListDeliveries(ctx context.Context, params *models.ListWebhookDeliveriesParams) ([]models.WebhookDelivery, error) {
+	list, err := s.deliveryRepo.ListDeliveries(ctx, params)
+	if err != nil {
+		s.log.Error("failed to list webhook deliveries", logattr.Op("WebhookService.ListDeliveries"), logattr.Err(err))
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// Redeliver resends the payload of a logged delivery to the same subscriber.
+func (s *Service) Redeliver(ctx context.Context, id int64) (*models.Redelivery, error) {
+	log := s.log.With(logattr.Op("WebhookService.Redeliver"), slog.Int64("delivery_id", id))
+
+	delivery, err := s.deliveryRepo.GetDeliveryByID(ctx, id)
+	if err != nil {
+		if !errors.Is(err, errs.ErrDeliveryNotFound) {
+			log.Error("failed to get webhook delivery", logattr.Err(err))
+		}
+		return nil, err
+	}
+
+	taskID, err := s.queue.EnqueueRedelivery(ctx, delivery)
+	if err != nil {
+		log.Error("failed to enqueue redelivery", logattr.Err(err))
+		return nil, err
+	}
+
+	log.Info("webhook redelivery enqueued", slog.String("task_id", taskID))
+
+	return &models.Redelivery{DeliveryID: id, TaskID: taskID}, nil
+}
+
+// OutboundStats returns circuit breaker and rate limiter state of the webhook worker in this instance.
+func (s *Service) OutboundStats(ctx context.Context) *models.OutboundStats {
+	return s.outbound.OutboundStats()
+}
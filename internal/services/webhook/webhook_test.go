@@ -0,0 +1,177 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ocenb/geo-alerts/internal/domain/errs"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/logger"
+)
+
+type WebhookServiceSuite struct {
+	suite.Suite
+	mockSub      *MockSubscriptionRepo
+	mockDelivery *MockDeliveryRepo
+	mockQueue    *MockQueueProducer
+	mockOutbound *MockOutboundStatsProvider
+	service      *Service
+}
+
+func (s *WebhookServiceSuite) SetupTest() {
+	s.mockSub = NewMockSubscriptionRepo(s.T())
+	s.mockDelivery = NewMockDeliveryRepo(s.T())
+	s.mockQueue = NewMockQueueProducer(s.T())
+	s.mockOutbound = NewMockOutboundStatsProvider(s.T())
+
+	s.service = New(
+		logger.NewDiscard(),
+		24*time.Hour,
+		s.mockSub,
+		s.mockDelivery,
+		s.mockQueue,
+		s.mockOutbound,
+	)
+}
+
+func TestWebhookServiceSuite(t *testing.T) {
+	suite.Run(t, new(WebhookServiceSuite))
+}
+
+// --- Tests for Create ---
+
+func (s *WebhookServiceSuite) TestCreate_Success() {
+	ctx := context.Background()
+	params := &models.CreateWebhookSubscriptionParams{URL: "http://example.com", Secret: "s3cr3t", IsEnabled: true}
+	created := &models.WebhookSubscription{ID: 1, URL: "http://example.com", IsEnabled: true}
+
+	s.mockSub.On("Create", mock.Anything, params).Return(created, nil)
+
+	res, err := s.service.Create(ctx, params)
+
+	s.NoError(err)
+	s.Equal(created, res)
+}
+
+func (s *WebhookServiceSuite) TestCreate_RepoError() {
+	ctx := context.Background()
+	params := &models.CreateWebhookSubscriptionParams{URL: "http://example.com"}
+
+	s.mockSub.On("Create", mock.Anything, params).Return(nil, errors.New("db error"))
+
+	res, err := s.service.Create(ctx, params)
+
+	s.Error(err)
+	s.Nil(res)
+}
+
+// --- Tests for GetByID ---
+
+func (s *WebhookServiceSuite) TestGetByID_NotFound() {
+	ctx := context.Background()
+
+	s.mockSub.On("GetByID", mock.Anything, int64(1)).Return(nil, errs.ErrSubscriptionNotFound)
+
+	res, err := s.service.GetByID(ctx, 1)
+
+	s.ErrorIs(err, errs.ErrSubscriptionNotFound)
+	s.Nil(res)
+}
+
+// --- Tests for Update ---
+
+func (s *WebhookServiceSuite) TestUpdate_Success() {
+	ctx := context.Background()
+	params := &models.UpdateWebhookSubscriptionParams{ID: 1, URL: "http://example.com", Events: []string{models.EventDanger}}
+	updated := &models.WebhookSubscription{ID: 1, URL: "http://example.com", Events: []string{models.EventDanger}}
+
+	s.mockSub.On("Update", mock.Anything, params).Return(updated, nil)
+
+	res, err := s.service.Update(ctx, params)
+
+	s.NoError(err)
+	s.Equal(updated, res)
+}
+
+func (s *WebhookServiceSuite) TestUpdate_SecretRotationGrace() {
+	ctx := context.Background()
+	secret := "new-secret-0123456789"
+	params := &models.UpdateWebhookSubscriptionParams{ID: 1, URL: "http://example.com", Secret: &secret}
+
+	s.mockSub.On("Update", mock.Anything, mock.MatchedBy(func(p *models.UpdateWebhookSubscriptionParams) bool {
+		return p.SecretRotationGrace == 24*time.Hour
+	})).Return(&models.WebhookSubscription{ID: 1}, nil)
+
+	_, err := s.service.Update(ctx, params)
+
+	s.NoError(err)
+}
+
+// --- Tests for Delete ---
+
+func (s *WebhookServiceSuite) TestDelete_NotFound() {
+	ctx := context.Background()
+
+	s.mockSub.On("Delete", mock.Anything, int64(1)).Return(errs.ErrSubscriptionNotFound)
+
+	err := s.service.Delete(ctx, 1)
+
+	s.ErrorIs(err, errs.ErrSubscriptionNotFound)
+}
+
+// --- Tests for Redeliver ---
+
+func (s *WebhookServiceSuite) TestRedeliver_Success() {
+	ctx := context.Background()
+	delivery := &models.WebhookDelivery{ID: 7, TaskType: "webhook:danger", SubscriptionID: 2}
+
+	s.mockDelivery.On("GetDeliveryByID", mock.Anything, int64(7)).Return(delivery, nil)
+	s.mockQueue.On("EnqueueRedelivery", mock.Anything, delivery).Return("task-1", nil)
+
+	res, err := s.service.Redeliver(ctx, 7)
+
+	s.NoError(err)
+	s.Equal(&models.Redelivery{DeliveryID: 7, TaskID: "task-1"}, res)
+}
+
+func (s *WebhookServiceSuite) TestRedeliver_NotFound() {
+	ctx := context.Background()
+
+	s.mockDelivery.On("GetDeliveryByID", mock.Anything, int64(7)).Return(nil, errs.ErrDeliveryNotFound)
+
+	res, err := s.service.Redeliver(ctx, 7)
+
+	s.ErrorIs(err, errs.ErrDeliveryNotFound)
+	s.Nil(res)
+}
+
+func (s *WebhookServiceSuite) TestRedeliver_QueueError() {
+	ctx := context.Background()
+	delivery := &models.WebhookDelivery{ID: 7}
+
+	s.mockDelivery.On("GetDeliveryByID", mock.Anything, int64(7)).Return(delivery, nil)
+	s.mockQueue.On("EnqueueRedelivery", mock.Anything, delivery).Return("", errors.New("redis down"))
+
+	res, err := s.service.Redeliver(ctx, 7)
+
+	s.Error(err)
+	s.Nil(res)
+}
+
+// --- Tests for OutboundStats ---
+
+func (s *WebhookServiceSuite) TestOutboundStats() {
+	stats := &models.OutboundStats{
+		Breakers: []models.BreakerStats{{Destination: "example.com", State: models.BreakerOpen, Failures: 5}},
+		Limiters: []models.LimiterStats{{SubscriptionID: 1, RPS: 2, Burst: 5, Tokens: 3, Allowed: 10, Throttled: 1}},
+	}
+
+	s.mockOutbound.On("OutboundStats").Return(stats)
+
+	s.Equal(stats, s.service.OutboundStats(context.Background()))
+}
@@ -0,0 +1,142 @@
+package geo
+
+import "math"
+
+// Circle is a zone on Earth with a radius in meters.
+type Circle struct {
+	Latitude  float64
+	Longitude float64
+	Radius    float64
+}
+
+func (c Circle) Contains(lat, lon float64) bool {
+	return Distance(lat, lon, c.Latitude, c.Longitude) <= c.Radius
+}
+
+// Containment describes how an uncertain position overlaps a circle.
+type Containment int
+
+const (
+	Outside Containment = iota
+	PartiallyInside
+	Inside
+)
+
+// Classifies a position with the given horizontal accuracy in meters:
+// Inside if the whole uncertainty circle is in the circle, Outside if they do not overlap.
+func (c Circle) Classify(lat, lon, accuracy float64) Containment {
+	dist := Distance(lat, lon, c.Latitude, c.Longitude)
+	switch {
+	case dist+accuracy <= c.Radius:
+		return Inside
+	case dist-accuracy <= c.Radius:
+		return PartiallyInside
+	default:
+		return Outside
+	}
+}
+
+const (
+	exitCoarseStep = 1.0  // degrees
+	exitFineStep   = 0.05 // degrees
+	exitPrecision  = 0.1  // meters
+	exitMargin     = 1.0  // meters past the boundary, so the exit point is strictly outside
+	exitMaxHops    = 64
+)
+
+// Finds the nearest point outside the union of circles for a point inside it.
+// Returns bearing in degrees and distance in meters to that point.
+// ok is false if the point is not inside any circle.
+func NearestExit(lat, lon float64, circles []Circle) (bearing, distance float64, ok bool) {
+	if !insideAny(lat, lon, circles) {
+		return 0, 0, false
+	}
+
+	bestBearing, bestDist := 0.0, math.Inf(1)
+	for b := 0.0; b < 360; b += exitCoarseStep {
+		if d := exitAlong(lat, lon, b, circles); d < bestDist {
+			bestBearing, bestDist = b, d
+		}
+	}
+
+	from := bestBearing - exitCoarseStep
+	for b := from; b <= bestBearing+exitCoarseStep; b += exitFineStep {
+		nb := math.Mod(b+360, 360)
+		if d := exitAlong(lat, lon, nb, circles); d < bestDist {
+			bestBearing, bestDist = nb, d
+		}
+	}
+
+	return bestBearing, bestDist, true
+}
+
+// Walks along the bearing hopping over every circle on the way
+// and returns the distance to the first point outside all of them.
+func exitAlong(lat, lon, bearing float64, circles []Circle) float64 {
+	d := 0.0
+	for range exitMaxHops {
+		pLat, pLon := Destination(lat, lon, bearing, d)
+		inside := -1
+		for i, c := range circles {
+			if c.Contains(pLat, pLon) {
+				inside = i
+				break
+			}
+		}
+		if inside < 0 {
+			return d
+		}
+
+		// Inside a circle the covered part of a path is a single segment no longer than its diameter,
+		// so the boundary can be found by bisection.
+		c := circles[inside]
+		lo, hi := d, d+2*c.Radius+exitMargin
+		for hi-lo > exitPrecision {
+			mid := (lo + hi) / 2
+			mLat, mLon := Destination(lat, lon, bearing, mid)
+			if c.Contains(mLat, mLon) {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		d = hi + exitMargin
+	}
+	return math.Inf(1)
+}
+
+func insideAny(lat, lon float64, circles []Circle) bool {
+	for _, c := range circles {
+		if c.Contains(lat, lon) {
+			return true
+		}
+	}
+	return false
+}
+
+// Checks if the great-circle segment between two points passes through the circle.
+func (c Circle) IntersectsSegment(lat1, lon1, lat2, lon2 float64) bool {
+	return SegmentDistance(lat1, lon1, lat2, lon2, c.Latitude, c.Longitude) <= c.Radius
+}
+
+// Finds the distance in meters along the path from (lat, lon) with the given bearing
+// at which the path enters the circle. ok is false if the path starts inside the circle
+// or does not reach it within length meters.
+func PathEntry(lat, lon, bearing, length float64, c Circle) (distance float64, ok bool) {
+	if c.Contains(lat, lon) {
+		return 0, false
+	}
+
+	xt, at := CrossTrack(lat, lon, bearing, c.Latitude, c.Longitude)
+	if math.Abs(xt) > c.Radius {
+		return 0, false
+	}
+
+	halfChord := math.Acos(clamp(math.Cos(c.Radius/earthRadius)/math.Cos(xt/earthRadius), -1, 1)) * earthRadius
+	entry := at - halfChord
+	if entry < 0 || entry > length {
+		return 0, false
+	}
+
+	return entry, true
+}
@@ -0,0 +1,183 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNearestExit(t *testing.T) {
+	tests := []struct {
+		name        string
+		lat, lon    float64
+		circles     []Circle
+		wantOK      bool
+		wantBearing float64
+		wantDist    float64
+		bearingEps  float64
+		distEps     float64
+	}{
+		{
+			name: "Outside all circles",
+			lat:  0, lon: 0,
+			circles: []Circle{{Latitude: 1, Longitude: 1, Radius: 1000}},
+			wantOK:  false,
+		},
+		{
+			name: "Off-center in single circle",
+			lat:  0, lon: 0.005, // ~556 m east of the center
+			circles: []Circle{{Latitude: 0, Longitude: 0, Radius: 1000}},
+			wantOK:  true, wantBearing: 90, wantDist: 444,
+			bearingEps: 1, distEps: 3,
+		},
+		{
+			name: "Overlapping circles block the shortest way",
+			lat:  0, lon: 0.005,
+			circles: []Circle{
+				{Latitude: 0, Longitude: 0, Radius: 1000},
+				{Latitude: 0, Longitude: 0.015, Radius: 1000}, // covers the way east
+			},
+			// The nearest exit is where the circles intersect (north or south), not 444 m east.
+			wantOK: true, wantDist: 619,
+			distEps: 3,
+		},
+		{
+			name: "Exit through the overlapped circle",
+			lat:  0, lon: 0.0095,
+			circles: []Circle{
+				{Latitude: 0, Longitude: 0, Radius: 1100},
+				{Latitude: 0, Longitude: 0.018, Radius: 1100},
+			},
+			// Both centers are ~1000 m away, the lens is thin north and south.
+			wantOK: true, wantDist: 460,
+			distEps: 5,
+		},
+		{
+			name: "Circle across antimeridian",
+			lat:  0, lon: 179.998, // ~222 m west of the center
+			circles: []Circle{{Latitude: 0, Longitude: -180, Radius: 1000}},
+			wantOK:  true, wantBearing: 270, wantDist: 778,
+			bearingEps: 1, distEps: 3,
+		},
+		{
+			name: "Overlapping circles across antimeridian",
+			lat:  0, lon: -179.998, // ~222 m east of the first center
+			circles: []Circle{
+				{Latitude: 0, Longitude: 180, Radius: 1000},
+				{Latitude: 0, Longitude: 179.985, Radius: 1000}, // covers the way west
+			},
+			wantOK: true, wantBearing: 90, wantDist: 778,
+			bearingEps: 1, distEps: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bearing, dist, ok := NearestExit(tt.lat, tt.lon, tt.circles)
+			if ok != tt.wantOK {
+				t.Fatalf("NearestExit() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if math.Abs(dist-tt.wantDist) > tt.distEps {
+				t.Errorf("NearestExit() distance = %v, want %v (+/- %v)", dist, tt.wantDist, tt.distEps)
+			}
+			if tt.bearingEps > 0 && math.Abs(bearing-tt.wantBearing) > tt.bearingEps {
+				t.Errorf("NearestExit() bearing = %v, want %v (+/- %v)", bearing, tt.wantBearing, tt.bearingEps)
+			}
+
+			exitLat, exitLon := Destination(tt.lat, tt.lon, bearing, dist)
+			if insideAny(exitLat, exitLon, tt.circles) {
+				t.Errorf("exit point (%v, %v) is inside a circle", exitLat, exitLon)
+			}
+		})
+	}
+}
+
+func TestPathEntry(t *testing.T) {
+	tests := []struct {
+		name     string
+		lat, lon float64
+		bearing  float64
+		length   float64
+		circle   Circle
+		wantOK   bool
+		wantDist float64
+	}{
+		{
+			name: "Heading straight at the circle",
+			lat:  0, lon: 0, bearing: 90, length: 5000,
+			circle: Circle{Latitude: 0, Longitude: 0.02, Radius: 500},
+			wantOK: true, wantDist: 1724,
+		},
+		{
+			name: "Heading away from the circle",
+			lat:  0, lon: 0, bearing: 270, length: 5000,
+			circle: Circle{Latitude: 0, Longitude: 0.02, Radius: 500},
+			wantOK: false,
+		},
+		{
+			name: "Passing by the circle",
+			lat:  0, lon: 0, bearing: 90, length: 5000,
+			circle: Circle{Latitude: 0.01, Longitude: 0.02, Radius: 500},
+			wantOK: false,
+		},
+		{
+			name: "Circle beyond the path length",
+			lat:  0, lon: 0, bearing: 90, length: 1000,
+			circle: Circle{Latitude: 0, Longitude: 0.02, Radius: 500},
+			wantOK: false,
+		},
+		{
+			name: "Starting inside the circle",
+			lat:  0, lon: 0.02, bearing: 90, length: 5000,
+			circle: Circle{Latitude: 0, Longitude: 0.02, Radius: 500},
+			wantOK: false,
+		},
+		{
+			name: "Grazing entry across antimeridian",
+			lat:  0, lon: 179.99, bearing: 90, length: 5000,
+			circle: Circle{Latitude: 0.003, Longitude: -179.99, Radius: 500},
+			wantOK: true, wantDist: 1851,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dist, ok := PathEntry(tt.lat, tt.lon, tt.bearing, tt.length, tt.circle)
+			if ok != tt.wantOK {
+				t.Fatalf("PathEntry() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && math.Abs(dist-tt.wantDist) > 3 {
+				t.Errorf("PathEntry() distance = %v, want %v", dist, tt.wantDist)
+			}
+		})
+	}
+}
+
+func TestClassify(t *testing.T) {
+	circle := Circle{Latitude: 0, Longitude: 0, Radius: 1000}
+
+	tests := []struct {
+		name     string
+		lat, lon float64
+		accuracy float64
+		want     Containment
+	}{
+		{name: "Exact point inside", lat: 0, lon: 0.005, accuracy: 0, want: Inside},
+		{name: "Exact point outside", lat: 0, lon: 0.01, accuracy: 0, want: Outside},
+		{name: "Uncertainty within the circle", lat: 0, lon: 0.005, accuracy: 400, want: Inside},
+		{name: "Point inside, uncertainty crosses the edge", lat: 0, lon: 0.005, accuracy: 500, want: PartiallyInside},
+		{name: "Point outside, uncertainty crosses the edge", lat: 0, lon: 0.01, accuracy: 200, want: PartiallyInside},
+		{name: "Uncertainty covers the whole circle", lat: 0, lon: 0, accuracy: 5000, want: PartiallyInside},
+		{name: "Uncertainty does not reach the circle", lat: 0, lon: 0.02, accuracy: 500, want: Outside},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := circle.Classify(tt.lat, tt.lon, tt.accuracy); got != tt.want {
+				t.Errorf("Classify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
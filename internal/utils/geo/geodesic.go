@@ -0,0 +1,88 @@
+package geo
+
+import "math"
+
+func toRad(deg float64) float64 {
+	return deg * (math.Pi / 180.0)
+}
+
+func toDeg(rad float64) float64 {
+	return rad * (180.0 / math.Pi)
+}
+
+// Normalizes longitude to the [-180, 180) range.
+func normalizeLon(lon float64) float64 {
+	lon = math.Mod(lon+180, 360)
+	if lon < 0 {
+		lon += 360
+	}
+	return lon - 180
+}
+
+// Calculates initial bearing in degrees [0, 360) from the first point to the second one.
+func Bearing(lat1, lon1, lat2, lon2 float64) float64 {
+	lat1Rad := toRad(lat1)
+	lat2Rad := toRad(lat2)
+	dLon := toRad(lon2 - lon1)
+
+	y := math.Sin(dLon) * math.Cos(lat2Rad)
+	x := math.Cos(lat1Rad)*math.Sin(lat2Rad) - math.Sin(lat1Rad)*math.Cos(lat2Rad)*math.Cos(dLon)
+
+	return math.Mod(toDeg(math.Atan2(y, x))+360, 360)
+}
+
+// Calculates the point reached by travelling the given distance in meters
+// from the start point along the given initial bearing in degrees.
+func Destination(lat, lon, bearing, distance float64) (float64, float64) {
+	latRad := toRad(lat)
+	lonRad := toRad(lon)
+	bearingRad := toRad(bearing)
+	angular := distance / earthRadius
+
+	destLat := math.Asin(math.Sin(latRad)*math.Cos(angular) +
+		math.Cos(latRad)*math.Sin(angular)*math.Cos(bearingRad))
+	destLon := lonRad + math.Atan2(
+		math.Sin(bearingRad)*math.Sin(angular)*math.Cos(latRad),
+		math.Cos(angular)-math.Sin(latRad)*math.Sin(destLat),
+	)
+
+	return toDeg(destLat), normalizeLon(toDeg(destLon))
+}
+
+// Calculates cross-track and along-track distances in meters of a point relative to
+// the great-circle path starting at (lat, lon) with the given bearing.
+// Cross-track is positive to the right of the path, along-track is negative behind the start.
+func CrossTrack(lat, lon, bearing, pLat, pLon float64) (crossTrack, alongTrack float64) {
+	d13 := Distance(lat, lon, pLat, pLon) / earthRadius
+	theta := toRad(Bearing(lat, lon, pLat, pLon)) - toRad(bearing)
+
+	xt := math.Asin(clamp(math.Sin(d13)*math.Sin(theta), -1, 1))
+	at := math.Acos(clamp(math.Cos(d13)/math.Cos(xt), -1, 1))
+	if math.Cos(theta) < 0 {
+		at = -at
+	}
+
+	return xt * earthRadius, at * earthRadius
+}
+
+// Calculates the shortest distance in meters from a point to the great-circle segment between two points.
+func SegmentDistance(lat1, lon1, lat2, lon2, pLat, pLon float64) float64 {
+	length := Distance(lat1, lon1, lat2, lon2)
+	if length == 0 {
+		return Distance(lat1, lon1, pLat, pLon)
+	}
+
+	xt, at := CrossTrack(lat1, lon1, Bearing(lat1, lon1, lat2, lon2), pLat, pLon)
+	switch {
+	case at <= 0:
+		return Distance(lat1, lon1, pLat, pLon)
+	case at >= length:
+		return Distance(lat2, lon2, pLat, pLon)
+	default:
+		return math.Abs(xt)
+	}
+}
+
+func clamp(v, lo, hi float64) float64 {
+	return math.Max(lo, math.Min(hi, v))
+}
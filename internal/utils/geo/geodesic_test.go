@@ -0,0 +1,210 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBearing(t *testing.T) {
+	tests := []struct {
+		name       string
+		lat1, lon1 float64
+		lat2, lon2 float64
+		want       float64
+		delta      float64
+	}{
+		{
+			name: "North",
+			lat1: 10, lon1: 10,
+			lat2: 11, lon2: 10,
+			want:  0,
+			delta: 0.01,
+		},
+		{
+			name: "East on equator",
+			lat1: 0, lon1: 10,
+			lat2: 0, lon2: 11,
+			want:  90,
+			delta: 0.01,
+		},
+		{
+			name: "West on equator",
+			lat1: 0, lon1: 10,
+			lat2: 0, lon2: 9,
+			want:  270,
+			delta: 0.01,
+		},
+		{
+			name: "East across antimeridian",
+			lat1: 0, lon1: 179.9,
+			lat2: 0, lon2: -179.9,
+			want:  90,
+			delta: 0.01,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Bearing(tt.lat1, tt.lon1, tt.lat2, tt.lon2)
+			if math.Abs(got-tt.want) > tt.delta {
+				t.Errorf("Bearing() = %v, want %v (+/- %v)", got, tt.want, tt.delta)
+			}
+		})
+	}
+}
+
+func TestDestination(t *testing.T) {
+	tests := []struct {
+		name              string
+		lat, lon          float64
+		bearing, distance float64
+		wantLat, wantLon  float64
+		delta             float64 // degrees
+	}{
+		{
+			name: "Zero distance",
+			lat:  55.75, lon: 37.62,
+			bearing: 45, distance: 0,
+			wantLat: 55.75, wantLon: 37.62,
+			delta: 1e-9,
+		},
+		{
+			name: "One degree north",
+			lat:  10, lon: 10,
+			bearing: 0, distance: 111195,
+			wantLat: 11, wantLon: 10,
+			delta: 0.001,
+		},
+		{
+			name: "East across antimeridian",
+			lat:  0, lon: 179.99,
+			bearing: 90, distance: 2224,
+			wantLat: 0, wantLon: -179.99,
+			delta: 0.001,
+		},
+		{
+			name: "West across antimeridian",
+			lat:  0, lon: -179.99,
+			bearing: 270, distance: 2224,
+			wantLat: 0, wantLon: 179.99,
+			delta: 0.001,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotLat, gotLon := Destination(tt.lat, tt.lon, tt.bearing, tt.distance)
+			if math.Abs(gotLat-tt.wantLat) > tt.delta || math.Abs(gotLon-tt.wantLon) > tt.delta {
+				t.Errorf("Destination() = (%v, %v), want (%v, %v) (+/- %v)", gotLat, gotLon, tt.wantLat, tt.wantLon, tt.delta)
+			}
+			if back := Distance(tt.lat, tt.lon, gotLat, gotLon); math.Abs(back-tt.distance) > 1 {
+				t.Errorf("Distance() to destination = %v, want %v", back, tt.distance)
+			}
+		})
+	}
+}
+
+func TestCrossTrack(t *testing.T) {
+	tests := []struct {
+		name           string
+		lat, lon       float64
+		bearing        float64
+		pLat, pLon     float64
+		wantXT, wantAT float64
+		delta          float64
+	}{
+		{
+			name: "Point on the path",
+			lat:  0, lon: 0, bearing: 90,
+			pLat: 0, pLon: 0.01,
+			wantXT: 0, wantAT: 1112,
+			delta: 1,
+		},
+		{
+			name: "Point left of the path",
+			lat:  0, lon: 0, bearing: 90,
+			pLat: 0.001, pLon: 0.01,
+			wantXT: -111, wantAT: 1112,
+			delta: 1,
+		},
+		{
+			name: "Point behind the start",
+			lat:  0, lon: 0, bearing: 90,
+			pLat: -0.001, pLon: -0.01,
+			wantXT: 111, wantAT: -1112,
+			delta: 1,
+		},
+		{
+			name: "Path across antimeridian",
+			lat:  0, lon: 179.995, bearing: 90,
+			pLat: 0, pLon: -179.995,
+			wantXT: 0, wantAT: 1112,
+			delta: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			xt, at := CrossTrack(tt.lat, tt.lon, tt.bearing, tt.pLat, tt.pLon)
+			if math.Abs(xt-tt.wantXT) > tt.delta || math.Abs(at-tt.wantAT) > tt.delta {
+				t.Errorf("CrossTrack() = (%v, %v), want (%v, %v) (+/- %v)", xt, at, tt.wantXT, tt.wantAT, tt.delta)
+			}
+		})
+	}
+}
+
+func TestSegmentDistance(t *testing.T) {
+	tests := []struct {
+		name       string
+		lat1, lon1 float64
+		lat2, lon2 float64
+		pLat, pLon float64
+		want       float64
+		delta      float64
+	}{
+		{
+			name: "Point beside the middle",
+			lat1: 0, lon1: 0,
+			lat2: 0, lon2: 0.02,
+			pLat: 0.001, pLon: 0.01,
+			want: 111, delta: 1,
+		},
+		{
+			name: "Point before the start",
+			lat1: 0, lon1: 0,
+			lat2: 0, lon2: 0.02,
+			pLat: 0, pLon: -0.01,
+			want: 1112, delta: 1,
+		},
+		{
+			name: "Point past the end",
+			lat1: 0, lon1: 0,
+			lat2: 0, lon2: 0.02,
+			pLat: 0, pLon: 0.03,
+			want: 1112, delta: 1,
+		},
+		{
+			name: "Zero-length segment",
+			lat1: 0, lon1: 0,
+			lat2: 0, lon2: 0,
+			pLat: 0.001, pLon: 0,
+			want: 111, delta: 1,
+		},
+		{
+			name: "Segment across antimeridian",
+			lat1: 0, lon1: 179.99,
+			lat2: 0, lon2: -179.99,
+			pLat: 0.001, pLon: 180,
+			want: 111, delta: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SegmentDistance(tt.lat1, tt.lon1, tt.lat2, tt.lon2, tt.pLat, tt.pLon)
+			if math.Abs(got-tt.want) > tt.delta {
+				t.Errorf("SegmentDistance() = %v, want %v (+/- %v)", got, tt.want, tt.delta)
+			}
+		})
+	}
+}
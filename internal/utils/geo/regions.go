@@ -0,0 +1,37 @@
+package geo
+
+// InBBox reports whether the point is within the box. A box whose east edge has a lower longitude
+// than its west edge crosses the antimeridian.
+func InBBox(lat, lon, south, west, north, east float64) bool {
+	if lat < south || lat > north {
+		return false
+	}
+	if west <= east {
+		return lon >= west && lon <= east
+	}
+	return lon >= west || lon <= east
+}
+
+// InPolygon reports whether the point is inside the ring given as parallel latitude and longitude slices,
+// using ray casting on longitudes taken relative to the point so that rings spanning the antimeridian work.
+// Points exactly on an edge may fall either way.
+func InPolygon(lat, lon float64, lats, lons []float64) bool {
+	n := len(lats)
+	if n < 3 || len(lons) != n {
+		return false
+	}
+
+	inside := false
+	for i, j := 0, n-1; i < n; j, i = i, i+1 {
+		yi, yj := lats[i], lats[j]
+		if (yi > lat) == (yj > lat) {
+			continue
+		}
+		xi, xj := normalizeLon(lons[i]-lon), normalizeLon(lons[j]-lon)
+		// Longitude where the edge crosses the point's parallel, the ray runs east from the point.
+		if x := xi + (lat-yi)*(xj-xi)/(yj-yi); x > 0 {
+			inside = !inside
+		}
+	}
+	return inside
+}
@@ -0,0 +1,59 @@
+package geo
+
+import "testing"
+
+func TestInBBox(t *testing.T) {
+	tests := []struct {
+		name                     string
+		lat, lon                 float64
+		south, west, north, east float64
+		want                     bool
+	}{
+		{"Inside", 55.75, 37.6, 55, 37, 56, 38, true},
+		{"North of box", 56.5, 37.6, 55, 37, 56, 38, false},
+		{"West of box", 55.75, 36.9, 55, 37, 56, 38, false},
+		{"On edge", 55, 37, 55, 37, 56, 38, true},
+		{"Across antimeridian, east side", -17, 179.5, -20, 178, -15, -178, true},
+		{"Across antimeridian, west side", -17, -179.5, -20, 178, -15, -178, true},
+		{"Across antimeridian, outside", -17, 0, -20, 178, -15, -178, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := InBBox(tt.lat, tt.lon, tt.south, tt.west, tt.north, tt.east); got != tt.want {
+				t.Errorf("InBBox() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInPolygon(t *testing.T) {
+	// Concave "L" shape: the square 0..2 x 0..2 without its north-east quarter.
+	lShapeLats := []float64{0, 0, 1, 1, 2, 2}
+	lShapeLons := []float64{0, 2, 2, 1, 1, 0}
+
+	tests := []struct {
+		name     string
+		lat, lon float64
+		lats     []float64
+		lons     []float64
+		want     bool
+	}{
+		{"Inside square", 0.5, 0.5, []float64{0, 0, 1, 1}, []float64{0, 1, 1, 0}, true},
+		{"Outside square", 1.5, 0.5, []float64{0, 0, 1, 1}, []float64{0, 1, 1, 0}, false},
+		{"Inside concave part", 1.5, 0.5, lShapeLats, lShapeLons, true},
+		{"In the notch of concave shape", 1.5, 1.5, lShapeLats, lShapeLons, false},
+		{"Across antimeridian", 0.5, 179.9, []float64{0, 0, 1, 1}, []float64{179.5, -179.5, -179.5, 179.5}, true},
+		{"Across antimeridian, other side", 0.5, -179.9, []float64{0, 0, 1, 1}, []float64{179.5, -179.5, -179.5, 179.5}, true},
+		{"Across antimeridian, outside", 0.5, 179, []float64{0, 0, 1, 1}, []float64{179.5, -179.5, -179.5, 179.5}, false},
+		{"Degenerate ring", 0.5, 0.5, []float64{0, 1}, []float64{0, 1}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := InPolygon(tt.lat, tt.lon, tt.lats, tt.lons); got != tt.want {
+				t.Errorf("InPolygon() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
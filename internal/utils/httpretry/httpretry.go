@@ -0,0 +1,56 @@
+// Package httpretry decides whether a failed HTTP request to an outside receiver is worth retrying.
+package httpretry
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Failure classes of a request.
+const (
+	FailurePermanent   = "permanent"    // retrying won't help, e.g. 400 from a misconfigured receiver
+	FailureRateLimited = "rate_limited" // the receiver told us when to come back
+	FailureTransient   = "transient"    // worth retrying with backoff
+)
+
+// Classify decides what to do about a non-2xx response. The delay is set for rate limited responses only.
+func Classify(resp *http.Response, now time.Time) (class string, delay time.Duration) {
+	code := resp.StatusCode
+	switch {
+	case code == http.StatusTooManyRequests || code == http.StatusServiceUnavailable:
+		if delay, ok := parseRetryAfter(resp.Header.Get("Retry-After"), now); ok {
+			return FailureRateLimited, delay
+		}
+		return FailureTransient, 0
+	case code == http.StatusRequestTimeout || code == http.StatusTooEarly:
+		return FailureTransient, 0
+	case code >= 500:
+		return FailureTransient, 0
+	default:
+		// Other 4xx and redirects the client didn't follow.
+		return FailurePermanent, 0
+	}
+}
+
+// maxRetryAfter keeps a delay given in seconds from overflowing, the caller caps it further.
+const maxRetryAfter = math.MaxInt64 / int64(time.Second)
+
+// parseRetryAfter reads a Retry-After header given either in seconds or as an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil || errors.Is(err, strconv.ErrRange) {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(min(seconds, maxRetryAfter)) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(at.Sub(now), 0), true
+	}
+	return 0, false
+}
@@ -0,0 +1,48 @@
+package httpretry
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestClassify(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		code       int
+		retryAfter string
+		wantClass  string
+		wantDelay  time.Duration
+	}{
+		{"Bad request", http.StatusBadRequest, "", FailurePermanent, 0},
+		{"Gone", http.StatusGone, "", FailurePermanent, 0},
+		{"Unfollowed redirect", http.StatusNotModified, "", FailurePermanent, 0},
+		{"Request timeout", http.StatusRequestTimeout, "", FailureTransient, 0},
+		{"Server error", http.StatusInternalServerError, "", FailureTransient, 0},
+		{"Bad gateway", http.StatusBadGateway, "", FailureTransient, 0},
+		{"Too many requests in seconds", http.StatusTooManyRequests, "120", FailureRateLimited, 2 * time.Minute},
+		{"Too many requests as date", http.StatusTooManyRequests, "Fri, 02 Jan 2026 03:05:05 GMT", FailureRateLimited, time.Minute},
+		{"Date in the past", http.StatusTooManyRequests, "Fri, 02 Jan 2026 03:00:00 GMT", FailureRateLimited, 0},
+		{"Too many requests without header", http.StatusTooManyRequests, "", FailureTransient, 0},
+		{"Malformed header", http.StatusTooManyRequests, "soon", FailureTransient, 0},
+		{"Unavailable with header", http.StatusServiceUnavailable, "30", FailureRateLimited, 30 * time.Second},
+		{"Seconds past the duration range", http.StatusTooManyRequests, "99999999999999999999", FailureRateLimited, time.Duration(maxRetryAfter) * time.Second},
+		{"Negative seconds", http.StatusTooManyRequests, "-5", FailureTransient, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{StatusCode: tt.code, Header: http.Header{}}
+			if tt.retryAfter != "" {
+				resp.Header.Set("Retry-After", tt.retryAfter)
+			}
+
+			class, delay := Classify(resp, now)
+			if class != tt.wantClass || delay != tt.wantDelay {
+				t.Errorf("Classify() = (%q, %v), want (%q, %v)", class, delay, tt.wantClass, tt.wantDelay)
+			}
+		})
+	}
+}
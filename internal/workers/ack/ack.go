@@ -0,0 +1,82 @@
+package ack
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/hibiken/asynq"
+	"github.com/ocenb/geo-alerts/internal/domain/errs"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/logger/logattr"
+	"github.com/ocenb/geo-alerts/internal/queue"
+)
+
+type AlertRepo interface {
+	ClaimFollowUp(ctx context.Context, id string) (*models.Alert, error)
+	ReleaseFollowUp(ctx context.Context, id string) error
+}
+
+type QueueProducer interface {
+	ResendAlert(ctx context.Context, alert *models.Alert) error
+	EnqueueUnacknowledged(ctx context.Context, alert *models.Alert) error
+}
+
+type TaskHandler struct {
+	log       *slog.Logger
+	action    string
+	alertRepo AlertRepo
+	queue     QueueProducer
+}
+
+func New(log *slog.Logger, action string, alertRepo AlertRepo, queue QueueProducer) *TaskHandler {
+	return &TaskHandler{
+		log:       log,
+		action:    action,
+		alertRepo: alertRepo,
+		queue:     queue,
+	}
+}
+
+// ProcessTask follows up on an alert nobody has acknowledged: sends it again or tells the duty officers.
+// An alert is followed up once, nothing is sent if it has been acknowledged since.
+func (h *TaskHandler) ProcessTask(ctx context.Context, t *asynq.Task) error {
+	var task queue.AlertAckTask
+	if err := json.Unmarshal(t.Payload(), &task); err != nil {
+		h.log.Error("failed to unmarshal task payload", logattr.Err(err))
+		return fmt.Errorf("json.Unmarshal failed: %v: %w", err, asynq.SkipRetry)
+	}
+
+	log := h.log.With(slog.String("alert_id", task.AlertID), slog.String("action", h.action))
+
+	alert, err := h.alertRepo.ClaimFollowUp(ctx, task.AlertID)
+	if err != nil {
+		if errors.Is(err, errs.ErrAlertNotFound) {
+			log.Debug("alert has been acknowledged or followed up")
+			return nil
+		}
+		log.Error("failed to claim alert follow-up", logattr.Err(err))
+		return err
+	}
+
+	switch h.action {
+	case models.AckResend:
+		err = h.queue.ResendAlert(ctx, alert)
+	case models.AckEscalate:
+		err = h.queue.EnqueueUnacknowledged(ctx, alert)
+	default:
+		err = fmt.Errorf("unknown follow-up action %q", h.action)
+	}
+	if err != nil {
+		log.Error("failed to enqueue alert follow-up", logattr.Err(err))
+		if err := h.alertRepo.ReleaseFollowUp(ctx, task.AlertID); err != nil {
+			log.Error("failed to release alert follow-up", logattr.Err(err))
+		}
+		return err
+	}
+
+	log.Info("alert followed up", slog.String("user_id", alert.UserID))
+	return nil
+}
@@ -0,0 +1,107 @@
+package ack
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/ocenb/geo-alerts/internal/domain/errs"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/logger"
+	"github.com/ocenb/geo-alerts/internal/queue"
+)
+
+type fakeAlerts struct {
+	alert    *models.Alert
+	released []string
+}
+
+func (f *fakeAlerts) ClaimFollowUp(_ context.Context, _ string) (*models.Alert, error) {
+	if f.alert == nil || f.alert.AcknowledgedAt != nil || f.alert.FollowedUpAt != nil {
+		return nil, errs.ErrAlertNotFound
+	}
+	return f.alert, nil
+}
+
+func (f *fakeAlerts) ReleaseFollowUp(_ context.Context, id string) error {
+	f.released = append(f.released, id)
+	return nil
+}
+
+type fakeQueue struct {
+	err       error
+	resent    []string
+	escalated []string
+}
+
+func (f *fakeQueue) ResendAlert(_ context.Context, alert *models.Alert) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.resent = append(f.resent, alert.ID)
+	return nil
+}
+
+func (f *fakeQueue) EnqueueUnacknowledged(_ context.Context, alert *models.Alert) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.escalated = append(f.escalated, alert.ID)
+	return nil
+}
+
+func TestTaskHandler_ProcessTask(t *testing.T) {
+	now := time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+	pending := &models.Alert{ID: "a1", UserID: "u1"}
+	acked := &models.Alert{ID: "a1", UserID: "u1", AcknowledgedAt: &now}
+	followedUp := &models.Alert{ID: "a1", UserID: "u1", FollowedUpAt: &now}
+
+	tests := []struct {
+		name          string
+		action        string
+		alert         *models.Alert
+		queueErr      error
+		wantErr       bool
+		wantResent    int
+		wantEscalated int
+		wantReleased  int
+	}{
+		{"Resends the alert", models.AckResend, pending, nil, false, 1, 0, 0},
+		{"Escalates to duty officers", models.AckEscalate, pending, nil, false, 0, 1, 0},
+		{"Acknowledged", models.AckResend, acked, nil, false, 0, 0, 0},
+		{"Already followed up", models.AckEscalate, followedUp, nil, false, 0, 0, 0},
+		{"Unknown alert", models.AckResend, nil, nil, false, 0, 0, 0},
+		{"Enqueue failure releases the follow-up", models.AckResend, pending, errors.New("redis down"), true, 0, 0, 1},
+		{"Unknown action releases the follow-up", "call", pending, nil, true, 0, 0, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alerts := &fakeAlerts{alert: tt.alert}
+			q := &fakeQueue{err: tt.queueErr}
+			h := New(logger.NewDiscard(), tt.action, alerts, q)
+
+			payload, err := json.Marshal(queue.AlertAckTask{AlertID: "a1"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = h.ProcessTask(context.Background(), asynq.NewTask(queue.TypeAlertAckCheck, payload))
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ProcessTask() error = %v, want error: %v", err, tt.wantErr)
+			}
+			if len(q.resent) != tt.wantResent {
+				t.Errorf("resent = %d, want %d", len(q.resent), tt.wantResent)
+			}
+			if len(q.escalated) != tt.wantEscalated {
+				t.Errorf("escalations = %d, want %d", len(q.escalated), tt.wantEscalated)
+			}
+			if len(alerts.released) != tt.wantReleased {
+				t.Errorf("released = %d, want %d", len(alerts.released), tt.wantReleased)
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package broadcast
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/ocenb/geo-alerts/internal/domain/errs"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/logger/logattr"
+	"github.com/ocenb/geo-alerts/internal/queue"
+)
+
+type BroadcastRepo interface {
+	GetByID(ctx context.Context, id int64) (*models.Broadcast, error)
+	Fail(ctx context.Context, id int64, reason string) error
+	Resolve(ctx context.Context, id int64, users, unreachable int, recipients []models.BroadcastRecipient) ([]models.BroadcastRecipient, error)
+	ListRecipients(ctx context.Context, params *models.ListBroadcastRecipientsParams) ([]models.BroadcastRecipient, error)
+}
+
+type LocationRepo interface {
+	ListUsersInArea(ctx context.Context, area *models.Area, since time.Time) ([]string, error)
+}
+
+type DeviceRepo interface {
+	ListByUsers(ctx context.Context, userIDs []string) ([]models.Device, error)
+}
+
+type QueueProducer interface {
+	EnqueueBroadcastPush(ctx context.Context, b *models.Broadcast, r *models.BroadcastRecipient) error
+}
+
+type TaskHandler struct {
+	log           *slog.Logger
+	pushPlatforms []string
+	broadcastRepo BroadcastRepo
+	locationRepo  LocationRepo
+	deviceRepo    DeviceRepo
+	queue         QueueProducer
+}
+
+func New(log *slog.Logger, pushPlatforms []string, broadcastRepo BroadcastRepo, locationRepo LocationRepo, deviceRepo DeviceRepo, queue QueueProducer) *TaskHandler {
+	return &TaskHandler{
+		log:           log,
+		pushPlatforms: pushPlatforms,
+		broadcastRepo: broadcastRepo,
+		locationRepo:  locationRepo,
+		deviceRepo:    deviceRepo,
+		queue:         queue,
+	}
+}
+
+// ProcessTask looks up the devices of the users last seen in the broadcast area and queues a push to each.
+// The recipients are saved before anything is queued, so a retry only queues those still pending.
+func (h *TaskHandler) ProcessTask(ctx context.Context, t *asynq.Task) error {
+	var task queue.BroadcastTask
+	if err := json.Unmarshal(t.Payload(), &task); err != nil {
+		h.log.Error("failed to unmarshal task payload", logattr.Err(err))
+		return fmt.Errorf("json.Unmarshal failed: %v: %w", err, asynq.SkipRetry)
+	}
+
+	log := h.log.With(slog.Int64("broadcast_id", task.BroadcastID))
+
+	b, err := h.broadcastRepo.GetByID(ctx, task.BroadcastID)
+	if err != nil {
+		if errors.Is(err, errs.ErrBroadcastNotFound) {
+			log.Warn("broadcast no longer exists")
+			return nil
+		}
+		log.Error("failed to get broadcast", logattr.Err(err))
+		return err
+	}
+
+	var recipients []models.BroadcastRecipient
+	switch b.Status {
+	case models.BroadcastPending:
+		recipients, err = h.resolve(ctx, log, b)
+		if err != nil && !errors.Is(err, errs.ErrBroadcastNotFound) {
+			log.Error("failed to resolve broadcast recipients", logattr.Err(err))
+			if lastAttempt(ctx) {
+				if failErr := h.broadcastRepo.Fail(ctx, b.ID, err.Error()); failErr != nil {
+					log.Error("failed to mark broadcast failed", logattr.Err(failErr))
+				}
+			}
+			return err
+		}
+		if err == nil {
+			break
+		}
+		// Resolved in the meantime, queue whoever is still pending.
+		fallthrough
+	case models.BroadcastSending:
+		recipients, err = h.broadcastRepo.ListRecipients(ctx, &models.ListBroadcastRecipientsParams{
+			BroadcastID: b.ID,
+			Status:      models.RecipientPending,
+		})
+		if err != nil {
+			log.Error("failed to list pending recipients", logattr.Err(err))
+			return err
+		}
+	default:
+		log.Debug("broadcast is already finished", slog.String("status", b.Status))
+		return nil
+	}
+
+	var enqueueErrs []error
+	for i := range recipients {
+		if err := h.queue.EnqueueBroadcastPush(ctx, b, &recipients[i]); err != nil {
+			enqueueErrs = append(enqueueErrs, fmt.Errorf("recipient %d: %w", recipients[i].ID, err))
+		}
+	}
+	if err := errors.Join(enqueueErrs...); err != nil {
+		log.Error("failed to enqueue broadcast notifications", logattr.Err(err))
+		return err
+	}
+
+	log.Info("broadcast notifications enqueued", slog.Int("notifications", len(recipients)))
+	return nil
+}
+
+// resolve saves a recipient for every device with a push endpoint of the users found in the area.
+func (h *TaskHandler) resolve(ctx context.Context, log *slog.Logger, b *models.Broadcast) ([]models.BroadcastRecipient, error) {
+	since := time.Now().Add(-time.Duration(b.Recency) * time.Second)
+	users, err := h.locationRepo.ListUsersInArea(ctx, b.Area, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users in area: %w", err)
+	}
+
+	var devices []models.Device
+	if len(users) > 0 {
+		devices, err = h.deviceRepo.ListByUsers(ctx, users)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list devices: %w", err)
+		}
+	}
+
+	recipients, unreachable := buildRecipients(users, devices, h.pushPlatforms)
+	log.Info("broadcast recipients found",
+		slog.Int("users", len(users)),
+		slog.Int("unreachable", unreachable),
+		slog.Int("devices", len(recipients)),
+	)
+
+	return h.broadcastRepo.Resolve(ctx, b.ID, len(users), unreachable, recipients)
+}
+
+// buildRecipients picks the devices that can be pushed to and counts the users left without one.
+func buildRecipients(users []string, devices []models.Device, pushPlatforms []string) ([]models.BroadcastRecipient, int) {
+	recipients := make([]models.BroadcastRecipient, 0, len(devices))
+	reachable := make(map[string]bool)
+
+	for _, d := range devices {
+		if !slices.Contains(pushPlatforms, d.Platform) {
+			continue
+		}
+		reachable[d.UserID] = true
+		recipients = append(recipients, models.BroadcastRecipient{
+			UserID:   d.UserID,
+			DeviceID: d.ID,
+			Token:    d.Token,
+			Platform: d.Platform,
+		})
+	}
+
+	unreachable := 0
+	for _, u := range users {
+		if !reachable[u] {
+			unreachable++
+		}
+	}
+	return recipients, unreachable
+}
+
+// lastAttempt tells whether the task won't be retried after failing.
+func lastAttempt(ctx context.Context) bool {
+	retried, ok := asynq.GetRetryCount(ctx)
+	if !ok {
+		return false
+	}
+	maxRetry, ok := asynq.GetMaxRetry(ctx)
+	return ok && retried >= maxRetry
+}
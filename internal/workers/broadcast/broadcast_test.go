@@ -0,0 +1,54 @@
+package broadcast
+
+import (
+	"testing"
+
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+)
+
+func TestBuildRecipients(t *testing.T) {
+	devices := []models.Device{
+		{ID: 1, UserID: "u1", Token: "a1", Platform: models.PlatformAndroid},
+		{ID: 2, UserID: "u1", Token: "i1", Platform: models.PlatformIOS},
+		{ID: 3, UserID: "u2", Token: "i2", Platform: models.PlatformIOS},
+	}
+
+	tests := []struct {
+		name            string
+		users           []string
+		pushPlatforms   []string
+		wantDevices     []int64
+		wantUnreachable int
+	}{
+		{"All platforms", []string{"u1", "u2", "u3"}, []string{models.PlatformAndroid, models.PlatformIOS}, []int64{1, 2, 3}, 1},
+		{"Android only", []string{"u1", "u2", "u3"}, []string{models.PlatformAndroid}, []int64{1}, 2},
+		{"No push", []string{"u1", "u2"}, nil, []int64{}, 2},
+		{"Nobody in area", nil, []string{models.PlatformAndroid}, []int64{}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var inArea []models.Device
+			if len(tt.users) > 0 {
+				inArea = devices
+			}
+
+			recipients, unreachable := buildRecipients(tt.users, inArea, tt.pushPlatforms)
+
+			if unreachable != tt.wantUnreachable {
+				t.Errorf("unreachable = %d, want %d", unreachable, tt.wantUnreachable)
+			}
+			if len(recipients) != len(tt.wantDevices) {
+				t.Fatalf("got %d recipients, want %d", len(recipients), len(tt.wantDevices))
+			}
+			for i, r := range recipients {
+				if r.DeviceID != tt.wantDevices[i] {
+					t.Errorf("recipient %d device = %d, want %d", i, r.DeviceID, tt.wantDevices[i])
+				}
+				if r.Token == "" || r.Platform == "" || r.UserID == "" {
+					t.Errorf("recipient %d is incomplete: %+v", i, r)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package digest
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/ocenb/geo-alerts/internal/domain/errs"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/logger/logattr"
+	"github.com/ocenb/geo-alerts/internal/queue"
+)
+
+// maxBatchSize caps a digest whose subscriber didn't set max_alerts.
+const maxBatchSize = 1000
+
+type SubscriptionRepo interface {
+	GetByID(ctx context.Context, id int64) (*models.WebhookSubscription, error)
+}
+
+type DigestRepo interface {
+	Take(ctx context.Context, subID int64, limit int, newBatchID string) (string, [][]byte, int64, error)
+	Ack(ctx context.Context, subID int64) error
+	Discard(ctx context.Context, subID int64) error
+}
+
+type QueueProducer interface {
+	EnqueueDigest(ctx context.Context, subID int64, batchID string, d *queue.DigestPayload) error
+	EnqueueDigestFlush(ctx context.Context, subID int64, delay time.Duration) error
+}
+
+type TaskHandler struct {
+	log         *slog.Logger
+	sampleUsers int
+	subRepo     SubscriptionRepo
+	digestRepo  DigestRepo
+	queue       QueueProducer
+}
+
+func New(log *slog.Logger, sampleUsers int, subRepo SubscriptionRepo, digestRepo DigestRepo, queue QueueProducer) *TaskHandler {
+	return &TaskHandler{
+		log:         log,
+		sampleUsers: sampleUsers,
+		subRepo:     subRepo,
+		digestRepo:  digestRepo,
+		queue:       queue,
+	}
+}
+
+// ProcessTask turns a batch of buffered alerts into one webhook delivery. The batch stays stored
+// until the delivery is enqueued, so a failed flush is retried with the same alerts.
+func (h *TaskHandler) ProcessTask(ctx context.Context, t *asynq.Task) error {
+	var task queue.DigestFlushTask
+	if err := json.Unmarshal(t.Payload(), &task); err != nil {
+		h.log.Error("failed to unmarshal task payload", logattr.Err(err))
+		return fmt.Errorf("json.Unmarshal failed: %v: %w", err, asynq.SkipRetry)
+	}
+
+	log := h.log.With(slog.Int64("subscription_id", task.SubscriptionID))
+
+	sub, err := h.subRepo.GetByID(ctx, task.SubscriptionID)
+	if err != nil {
+		if errors.Is(err, errs.ErrSubscriptionNotFound) {
+			log.Warn("subscription no longer exists, dropping digest")
+			return h.digestRepo.Discard(ctx, task.SubscriptionID)
+		}
+		log.Error("failed to get subscription", logattr.Err(err))
+		return err
+	}
+
+	limit := sub.Digest.MaxAlerts
+	if limit <= 0 || limit > maxBatchSize {
+		limit = maxBatchSize
+	}
+
+	batchID, entries, remaining, err := h.digestRepo.Take(ctx, sub.ID, limit, uuid.NewString())
+	if err != nil {
+		log.Error("failed to take digest batch", logattr.Err(err))
+		return err
+	}
+	if batchID == "" {
+		log.Debug("digest buffer is empty")
+		return nil
+	}
+
+	digest := buildDigest(log, entries, h.sampleUsers)
+	if err := h.queue.EnqueueDigest(ctx, sub.ID, batchID, digest); err != nil {
+		log.Error("failed to enqueue digest", logattr.Err(err))
+		return err
+	}
+	if err := h.digestRepo.Ack(ctx, sub.ID); err != nil {
+		// The batch is taken again by the next flush, its task ID keeps it from being enqueued twice.
+		log.Warn("failed to ack digest batch", logattr.Err(err))
+	}
+
+	log.Info("digest enqueued", slog.String("batch_id", batchID), slog.Int("alerts", digest.Total), slog.Int64("remaining", remaining))
+
+	if remaining == 0 {
+		return nil
+	}
+	delay := time.Duration(sub.Digest.Interval) * time.Second
+	if remaining >= int64(limit) || !sub.Digest.Enabled() {
+		delay = 0
+	}
+	if err := h.queue.EnqueueDigestFlush(ctx, sub.ID, delay); err != nil {
+		log.Error("failed to schedule next digest flush", logattr.Err(err))
+		return err
+	}
+	return nil
+}
+
+// buildDigest counts alerts per incident and alert type and samples the users alerted.
+// Entries that can't be decoded are skipped, as are repeats of an alert, e.g. added again by a retried delivery.
+func buildDigest(log *slog.Logger, entries [][]byte, sampleUsers int) *queue.DigestPayload {
+	digest := &queue.DigestPayload{AlertType: "digest", Incidents: []queue.DigestIncident{}}
+	byID := make(map[int64]*queue.DigestIncident)
+	alerts := make(map[string]bool)
+
+	for _, raw := range entries {
+		var entry queue.DigestEntry
+		if err := json.Unmarshal(raw, &entry); err != nil {
+			log.Warn("skipping malformed digest entry", logattr.Err(err))
+			continue
+		}
+		if id := entry.Payload.AlertID; id != "" {
+			if alerts[id] {
+				continue
+			}
+			alerts[id] = true
+		}
+
+		digest.Total++
+		if digest.From.IsZero() || entry.Time.Before(digest.From) {
+			digest.From = entry.Time
+		}
+		if entry.Time.After(digest.To) {
+			digest.To = entry.Time
+		}
+
+		seen := make(map[int64]bool)
+		for _, inc := range entry.Payload.AllIncidents() {
+			if seen[inc.ID] {
+				continue
+			}
+			seen[inc.ID] = true
+
+			di, ok := byID[inc.ID]
+			if !ok {
+				di = &queue.DigestIncident{IncidentShort: inc, AlertTypes: map[string]int{}, SampleUsers: []string{}}
+				byID[inc.ID] = di
+			}
+			di.Count++
+			di.AlertTypes[entry.Payload.AlertType]++
+			if len(di.SampleUsers) < sampleUsers && !slices.Contains(di.SampleUsers, entry.Payload.UserID) {
+				di.SampleUsers = append(di.SampleUsers, entry.Payload.UserID)
+			}
+		}
+	}
+
+	for _, di := range byID {
+		digest.Incidents = append(digest.Incidents, *di)
+	}
+	// Busiest incidents first.
+	slices.SortFunc(digest.Incidents, func(a, b queue.DigestIncident) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return digest
+}
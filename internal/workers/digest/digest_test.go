@@ -0,0 +1,71 @@
+package digest
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/logger"
+	"github.com/ocenb/geo-alerts/internal/queue"
+)
+
+func TestBuildDigest(t *testing.T) {
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	fire := models.IncidentShort{ID: 1, Category: "fire"}
+	flood := models.IncidentShort{ID: 2, Category: "flood"}
+
+	entry := func(offset time.Duration, p queue.WebhookPayload) []byte {
+		raw, err := json.Marshal(queue.DigestEntry{Time: start.Add(offset), Payload: p})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return raw
+	}
+	entries := [][]byte{
+		entry(2*time.Second, queue.WebhookPayload{AlertID: "a1", UserID: "u1", AlertType: models.EventDanger, Incidents: []models.IncidentShort{fire}}),
+		entry(0, queue.WebhookPayload{UserID: "u2", AlertType: models.EventDanger, Incidents: []models.IncidentShort{fire, flood}}),
+		entry(time.Second, queue.WebhookPayload{UserID: "u1", AlertType: models.EventPathCrossing, Crossed: []models.IncidentShort{fire}}),
+		// Counted once even though it refers to the incident twice.
+		entry(3*time.Second, queue.WebhookPayload{UserID: "u3", AlertType: models.EventDanger, Incidents: []models.IncidentShort{fire}, Crossed: []models.IncidentShort{fire}}),
+		[]byte("not json"),
+		// Added again by a retried delivery.
+		entry(5*time.Second, queue.WebhookPayload{AlertID: "a1", UserID: "u1", AlertType: models.EventDanger, Incidents: []models.IncidentShort{fire}}),
+	}
+
+	d := buildDigest(logger.NewDiscard(), entries, 2)
+
+	if d.AlertType != "digest" || d.Total != 4 {
+		t.Errorf("alert_type, total = %q, %d, want digest, 4", d.AlertType, d.Total)
+	}
+	if !d.From.Equal(start) || !d.To.Equal(start.Add(3*time.Second)) {
+		t.Errorf("from, to = %v, %v", d.From, d.To)
+	}
+	if len(d.Incidents) != 2 {
+		t.Fatalf("incidents = %+v, want 2", d.Incidents)
+	}
+
+	first := d.Incidents[0]
+	if first.ID != fire.ID || first.Count != 4 {
+		t.Errorf("first incident = %d with %d alerts, want the busiest one", first.ID, first.Count)
+	}
+	if first.AlertTypes[models.EventDanger] != 3 || first.AlertTypes[models.EventPathCrossing] != 1 {
+		t.Errorf("alert types = %v", first.AlertTypes)
+	}
+	if len(first.SampleUsers) != 2 || first.SampleUsers[0] != "u1" || first.SampleUsers[1] != "u2" {
+		t.Errorf("sample users = %v, want [u1 u2]", first.SampleUsers)
+	}
+
+	second := d.Incidents[1]
+	if second.ID != flood.ID || second.Count != 1 || second.Category != "flood" {
+		t.Errorf("second incident = %+v", second)
+	}
+}
+
+func TestBuildDigest_Empty(t *testing.T) {
+	d := buildDigest(logger.NewDiscard(), nil, 10)
+
+	if d.Total != 0 || d.Incidents == nil {
+		t.Errorf("buildDigest(nil) = %+v, want no alerts and an empty incidents list", d)
+	}
+}
@@ -0,0 +1,146 @@
+package escalation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/hibiken/asynq"
+	"github.com/ocenb/geo-alerts/internal/domain/errs"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/logger/logattr"
+	"github.com/ocenb/geo-alerts/internal/queue"
+)
+
+type IncidentRepo interface {
+	GetByID(ctx context.Context, id int64) (*models.Incident, error)
+}
+
+type DwellRepo interface {
+	GetDwell(ctx context.Context, userID string, incidentID int64) (*models.ZoneDwell, error)
+	EndIncidentDwells(ctx context.Context, incidentID int64) ([]models.ZoneDwell, error)
+}
+
+type QueueProducer interface {
+	EnqueueDangerAlert(ctx context.Context, userID string, latitude, longitude float64, dangers []models.IncidentShort) error
+	EnqueueEscalation(ctx context.Context, dwell *models.ZoneDwell, incident models.IncidentShort) error
+	CancelEscalations(ctx context.Context, dwells []models.ZoneDwell) error
+}
+
+type TaskHandler struct {
+	log       *slog.Logger
+	incRepo   IncidentRepo
+	dwellRepo DwellRepo
+	queue     QueueProducer
+}
+
+func New(log *slog.Logger, incRepo IncidentRepo, dwellRepo DwellRepo, queue QueueProducer) *TaskHandler {
+	return &TaskHandler{
+		log:       log,
+		incRepo:   incRepo,
+		dwellRepo: dwellRepo,
+		queue:     queue,
+	}
+}
+
+// ProcessTask follows up on a user who is still inside the zone: alerts them again or tells the duty officers.
+// Nothing is sent if the user has left the zone since, even to come back, or the incident is no longer active.
+func (h *TaskHandler) ProcessTask(ctx context.Context, t *asynq.Task) error {
+	var task queue.EscalationTask
+	if err := json.Unmarshal(t.Payload(), &task); err != nil {
+		h.log.Error("failed to unmarshal task payload", logattr.Err(err))
+		return fmt.Errorf("json.Unmarshal failed: %v: %w", err, asynq.SkipRetry)
+	}
+
+	log := h.log.With(
+		slog.String("user_id", task.UserID),
+		slog.Int64("incident_id", task.IncidentID),
+		slog.String("step", task.Step),
+	)
+
+	dwell, err := h.dwellRepo.GetDwell(ctx, task.UserID, task.IncidentID)
+	if err != nil {
+		if errors.Is(err, errs.ErrDwellNotFound) {
+			log.Debug("user has left the zone")
+			return nil
+		}
+		log.Error("failed to get zone dwell", logattr.Err(err))
+		return err
+	}
+	if !dwell.EnteredAt.Equal(task.EnteredAt) {
+		log.Debug("user has left the zone and entered it again")
+		return nil
+	}
+
+	incident, err := h.incRepo.GetByID(ctx, task.IncidentID)
+	if err != nil {
+		if errors.Is(err, errs.ErrIncidentNotFound) {
+			log.Warn("incident no longer exists")
+			return nil
+		}
+		log.Error("failed to get incident", logattr.Err(err))
+		return err
+	}
+	if !incident.IsActive {
+		log.Debug("incident is no longer active")
+		return nil
+	}
+
+	switch task.Step {
+	case models.EscalationRealert:
+		err = h.queue.EnqueueDangerAlert(ctx, dwell.UserID, dwell.Latitude, dwell.Longitude, []models.IncidentShort{incident.Short()})
+	case models.EscalationDuty:
+		err = h.queue.EnqueueEscalation(ctx, dwell, incident.Short())
+	default:
+		log.Error("unknown escalation step")
+		return fmt.Errorf("unknown escalation step %q: %w", task.Step, asynq.SkipRetry)
+	}
+	if err != nil {
+		log.Error("failed to enqueue escalation", logattr.Err(err))
+		return err
+	}
+
+	log.Info("escalation enqueued", slog.Duration("inside_for", dwell.LastSeenAt.Sub(dwell.EnteredAt)))
+	return nil
+}
+
+type CancelHandler struct {
+	log       *slog.Logger
+	dwellRepo DwellRepo
+	queue     QueueProducer
+}
+
+func NewCancelHandler(log *slog.Logger, dwellRepo DwellRepo, queue QueueProducer) *CancelHandler {
+	return &CancelHandler{
+		log:       log,
+		dwellRepo: dwellRepo,
+		queue:     queue,
+	}
+}
+
+// ProcessTask forgets everyone inside the zone of a deactivated incident and drops their follow-ups.
+func (h *CancelHandler) ProcessTask(ctx context.Context, t *asynq.Task) error {
+	var task queue.EscalationCancelTask
+	if err := json.Unmarshal(t.Payload(), &task); err != nil {
+		h.log.Error("failed to unmarshal task payload", logattr.Err(err))
+		return fmt.Errorf("json.Unmarshal failed: %v: %w", err, asynq.SkipRetry)
+	}
+
+	log := h.log.With(slog.Int64("incident_id", task.IncidentID))
+
+	dwells, err := h.dwellRepo.EndIncidentDwells(ctx, task.IncidentID)
+	if err != nil {
+		log.Error("failed to end zone dwells", logattr.Err(err))
+		return err
+	}
+
+	// The stays are over, so follow-ups left behind do nothing. They are only removed early.
+	if err := h.queue.CancelEscalations(ctx, dwells); err != nil {
+		log.Warn("failed to cancel escalations", logattr.Err(err))
+	}
+
+	log.Info("escalations cancelled", slog.Int("users", len(dwells)))
+	return nil
+}
@@ -0,0 +1,124 @@
+package escalation
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/ocenb/geo-alerts/internal/domain/errs"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/logger"
+	"github.com/ocenb/geo-alerts/internal/queue"
+)
+
+type fakeIncidents struct {
+	incident *models.Incident
+}
+
+func (f *fakeIncidents) GetByID(_ context.Context, _ int64) (*models.Incident, error) {
+	return f.incident, nil
+}
+
+type fakeDwells struct {
+	dwell *models.ZoneDwell
+	ended []models.ZoneDwell
+}
+
+func (f *fakeDwells) GetDwell(_ context.Context, _ string, _ int64) (*models.ZoneDwell, error) {
+	if f.dwell == nil {
+		return nil, errs.ErrDwellNotFound
+	}
+	return f.dwell, nil
+}
+
+func (f *fakeDwells) EndIncidentDwells(_ context.Context, _ int64) ([]models.ZoneDwell, error) {
+	return f.ended, nil
+}
+
+type fakeQueue struct {
+	realerted []string
+	escalated []string
+	cancelled []models.ZoneDwell
+}
+
+func (f *fakeQueue) EnqueueDangerAlert(_ context.Context, userID string, _, _ float64, _ []models.IncidentShort) error {
+	f.realerted = append(f.realerted, userID)
+	return nil
+}
+
+func (f *fakeQueue) EnqueueEscalation(_ context.Context, dwell *models.ZoneDwell, _ models.IncidentShort) error {
+	f.escalated = append(f.escalated, dwell.UserID)
+	return nil
+}
+
+func (f *fakeQueue) CancelEscalations(_ context.Context, dwells []models.ZoneDwell) error {
+	f.cancelled = append(f.cancelled, dwells...)
+	return nil
+}
+
+func TestTaskHandler_ProcessTask(t *testing.T) {
+	entered := time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+	inside := &models.ZoneDwell{UserID: "u1", IncidentID: 1, EnteredAt: entered, LastSeenAt: entered.Add(10 * time.Minute)}
+	reentered := &models.ZoneDwell{UserID: "u1", IncidentID: 1, EnteredAt: entered.Add(5 * time.Minute)}
+
+	tests := []struct {
+		name          string
+		step          string
+		dwell         *models.ZoneDwell
+		active        bool
+		wantErr       bool
+		wantRealerted int
+		wantEscalated int
+	}{
+		{"Re-alerts the user", models.EscalationRealert, inside, true, false, 1, 0},
+		{"Escalates to duty officers", models.EscalationDuty, inside, true, false, 0, 1},
+		{"User has left", models.EscalationDuty, nil, true, false, 0, 0},
+		{"User has come back", models.EscalationDuty, reentered, true, false, 0, 0},
+		{"Inactive incident", models.EscalationRealert, inside, false, false, 0, 0},
+		{"Unknown step", "call", inside, true, true, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := &fakeQueue{}
+			incidents := &fakeIncidents{incident: &models.Incident{ID: 1, Radius: 100, Category: "fire", IsActive: tt.active}}
+			h := New(logger.NewDiscard(), incidents, &fakeDwells{dwell: tt.dwell}, q)
+
+			payload, err := json.Marshal(queue.EscalationTask{UserID: "u1", IncidentID: 1, Step: tt.step, EnteredAt: entered})
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = h.ProcessTask(context.Background(), asynq.NewTask(queue.TypeEscalation, payload))
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ProcessTask() error = %v, want error: %v", err, tt.wantErr)
+			}
+			if len(q.realerted) != tt.wantRealerted {
+				t.Errorf("re-alerts = %d, want %d", len(q.realerted), tt.wantRealerted)
+			}
+			if len(q.escalated) != tt.wantEscalated {
+				t.Errorf("escalations = %d, want %d", len(q.escalated), tt.wantEscalated)
+			}
+		})
+	}
+}
+
+func TestCancelHandler_ProcessTask(t *testing.T) {
+	dwells := []models.ZoneDwell{{UserID: "u1", IncidentID: 1}, {UserID: "u2", IncidentID: 1}}
+	q := &fakeQueue{}
+	h := NewCancelHandler(logger.NewDiscard(), &fakeDwells{ended: dwells}, q)
+
+	payload, err := json.Marshal(queue.EscalationCancelTask{IncidentID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := h.ProcessTask(context.Background(), asynq.NewTask(queue.TypeEscalationCancel, payload)); err != nil {
+		t.Fatalf("ProcessTask() error = %v", err)
+	}
+
+	if len(q.cancelled) != len(dwells) {
+		t.Errorf("cancelled %d stays, want %d", len(q.cancelled), len(dwells))
+	}
+}
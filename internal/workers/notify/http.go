@@ -0,0 +1,39 @@
+package notify
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/ocenb/geo-alerts/internal/queue"
+	"github.com/ocenb/geo-alerts/internal/utils/httpretry"
+)
+
+// responseDrainLimit is how much of a response is read so that the connection can be reused.
+const responseDrainLimit = 64 << 10
+
+// do sends the request and turns a non-2xx response into an error that tells whether to retry.
+// The status is 0 if there was no response.
+func do(client *http.Client, req *http.Request) (int, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, responseDrainLimit))
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp.StatusCode, nil
+	}
+
+	class, delay := httpretry.Classify(resp, time.Now())
+	err = fmt.Errorf("request failed with status: %d (%s)", resp.StatusCode, class)
+	switch class {
+	case httpretry.FailurePermanent:
+		return resp.StatusCode, fmt.Errorf("%w: %w", err, ErrPermanent)
+	case httpretry.FailureRateLimited:
+		return resp.StatusCode, &queue.RetryAfterError{Delay: delay, Err: err}
+	}
+	return resp.StatusCode, err
+}
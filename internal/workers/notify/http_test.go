@@ -0,0 +1,106 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ocenb/geo-alerts/internal/config"
+	"github.com/ocenb/geo-alerts/internal/queue"
+	"github.com/ocenb/geo-alerts/pkg/webhooksig"
+)
+
+func TestWebhookChannel_Send(t *testing.T) {
+	const secret = "notify-signing-secret"
+	n := &queue.Notification{ID: "n1", Event: "danger", Subject: "Danger", Text: "details"}
+
+	var got queue.Notification
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := webhooksig.NewVerifier(time.Minute, secret).VerifyRequest(r)
+		if err != nil {
+			t.Errorf("VerifyRequest() error = %v", err)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if err := json.Unmarshal(body, &got); err != nil {
+			t.Errorf("json.Unmarshal() error = %v", err)
+		}
+	}))
+	defer srv.Close()
+
+	n.Recipient = srv.URL
+	ch := NewWebhookChannel(config.NotifyConfig{RequestTimeout: time.Second, WebhookSecret: secret})
+	if err := ch.Send(context.Background(), n); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if got.ID != n.ID || got.Subject != n.Subject || got.Text != n.Text {
+		t.Errorf("received %+v, want %+v", got, *n)
+	}
+}
+
+func TestSMSChannel_Send(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		retryAfter    string
+		wantErr       bool
+		wantPermanent bool
+		wantDelay     time.Duration
+	}{
+		{"Accepted", http.StatusAccepted, "", false, false, 0},
+		{"Rejected number", http.StatusUnprocessableEntity, "", true, true, 0},
+		{"Gateway down", http.StatusBadGateway, "", true, false, 0},
+		{"Rate limited", http.StatusTooManyRequests, "30", true, false, 30 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got smsRequest
+			var auth string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				auth = r.Header.Get("Authorization")
+				body, _ := io.ReadAll(r.Body)
+				if err := json.Unmarshal(body, &got); err != nil {
+					t.Errorf("json.Unmarshal() error = %v", err)
+				}
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			ch := NewSMSChannel(config.NotifyConfig{
+				RequestTimeout:  time.Second,
+				SMSGatewayURL:   srv.URL,
+				SMSGatewayToken: "token",
+				SMSSender:       "GeoAlerts",
+			})
+			err := ch.Send(context.Background(), &queue.Notification{ID: "n1", Recipient: "+79990001122", Subject: "Danger", Text: "details"})
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Send() error = %v, want error: %v", err, tt.wantErr)
+			}
+			if got := errors.Is(err, ErrPermanent); got != tt.wantPermanent {
+				t.Errorf("permanent = %v, want %v", got, tt.wantPermanent)
+			}
+			var retryAfter *queue.RetryAfterError
+			if errors.As(err, &retryAfter) != (tt.wantDelay > 0) || (retryAfter != nil && retryAfter.Delay != tt.wantDelay) {
+				t.Errorf("error = %v, want retry after %v", err, tt.wantDelay)
+			}
+
+			want := smsRequest{From: "GeoAlerts", To: "+79990001122", Text: "Danger", Reference: "n1:+79990001122"}
+			if got != want {
+				t.Errorf("gateway got %+v, want %+v", got, want)
+			}
+			if auth != "Bearer token" {
+				t.Errorf("Authorization = %q, want bearer token", auth)
+			}
+		})
+	}
+}
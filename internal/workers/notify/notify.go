@@ -0,0 +1,106 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/hibiken/asynq"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/logger/logattr"
+	"github.com/ocenb/geo-alerts/internal/queue"
+)
+
+// ErrPermanent marks failures that retrying won't fix, e.g. a recipient the channel rejects.
+var ErrPermanent = errors.New("permanent failure")
+
+// Channel delivers notifications over one medium. Send returns an error wrapping ErrPermanent
+// when the notification should be given up on, and a queue.RetryAfterError when the receiver asked to wait.
+type Channel interface {
+	Send(ctx context.Context, n *queue.Notification) error
+}
+
+type BroadcastRepo interface {
+	RecordDelivery(ctx context.Context, recipientID int64, status string, attempts int, reason string) error
+}
+
+// TaskHandler processes the notification tasks of one channel.
+type TaskHandler struct {
+	log        *slog.Logger
+	channel    Channel
+	broadcasts BroadcastRepo
+}
+
+func New(log *slog.Logger, channel Channel, broadcasts BroadcastRepo) *TaskHandler {
+	return &TaskHandler{
+		log:        log,
+		channel:    channel,
+		broadcasts: broadcasts,
+	}
+}
+
+func (h *TaskHandler) ProcessTask(ctx context.Context, t *asynq.Task) error {
+	var n queue.Notification
+	if err := json.Unmarshal(t.Payload(), &n); err != nil {
+		h.log.Error("failed to unmarshal task payload", logattr.Err(err))
+		return fmt.Errorf("json.Unmarshal failed: %v: %w", err, asynq.SkipRetry)
+	}
+
+	log := h.log.With(
+		slog.String("task_type", t.Type()),
+		slog.String("notification_id", n.ID),
+		slog.String("event", n.Event),
+		slog.String("recipient", n.Recipient),
+	)
+	log.Debug("processing notification task")
+
+	if err := h.channel.Send(ctx, &n); err != nil {
+		if errors.Is(err, ErrPermanent) {
+			log.Error("notification rejected, giving up", logattr.Err(err))
+			h.reportDelivery(ctx, log, &n, models.RecipientFailed, err)
+			return fmt.Errorf("%w: %w", err, asynq.SkipRetry)
+		}
+		log.Warn("failed to send notification", logattr.Err(err))
+		if lastAttempt(ctx) && !errors.Is(err, queue.ErrDeferred) {
+			h.reportDelivery(ctx, log, &n, models.RecipientFailed, err)
+		}
+		return err
+	}
+
+	log.Info("notification sent")
+	h.reportDelivery(ctx, log, &n, models.RecipientSent, nil)
+	return nil
+}
+
+// reportDelivery records the outcome of a broadcast notification. The notification isn't sent again
+// if that fails, the recipient just stays pending in the report.
+func (h *TaskHandler) reportDelivery(ctx context.Context, log *slog.Logger, n *queue.Notification, status string, sendErr error) {
+	if n.BroadcastRecipientID == 0 {
+		return
+	}
+
+	var reason string
+	if sendErr != nil {
+		reason = sendErr.Error()
+	}
+	retried, _ := asynq.GetRetryCount(ctx)
+
+	if err := h.broadcasts.RecordDelivery(ctx, n.BroadcastRecipientID, status, retried+1, reason); err != nil {
+		log.Error("failed to record broadcast delivery",
+			slog.Int64("broadcast_recipient_id", n.BroadcastRecipientID),
+			logattr.Err(err),
+		)
+	}
+}
+
+// lastAttempt tells whether the task won't be retried after failing.
+func lastAttempt(ctx context.Context) bool {
+	retried, ok := asynq.GetRetryCount(ctx)
+	if !ok {
+		return false
+	}
+	maxRetry, ok := asynq.GetMaxRetry(ctx)
+	return ok && retried >= maxRetry
+}
@@ -0,0 +1,111 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/logger"
+	"github.com/ocenb/geo-alerts/internal/queue"
+)
+
+type fakeChannel struct {
+	err  error
+	sent []queue.Notification
+}
+
+func (c *fakeChannel) Send(_ context.Context, n *queue.Notification) error {
+	c.sent = append(c.sent, *n)
+	return c.err
+}
+
+type fakeBroadcasts struct {
+	recorded map[int64]string
+}
+
+func (b *fakeBroadcasts) RecordDelivery(_ context.Context, recipientID int64, status string, _ int, _ string) error {
+	if b.recorded == nil {
+		b.recorded = map[int64]string{}
+	}
+	b.recorded[recipientID] = status
+	return nil
+}
+
+func TestTaskHandler_ProcessTask(t *testing.T) {
+	n := queue.Notification{ID: "n1", Event: "danger", Recipient: "duty@example.com", Subject: "Danger"}
+	payload, err := json.Marshal(n)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		payload       []byte
+		sendErr       error
+		wantSent      int
+		wantErr       bool
+		wantSkipRetry bool
+	}{
+		{"Sent", payload, nil, 1, false, false},
+		{"Transient failure", payload, errors.New("connection refused"), 1, true, false},
+		{"Permanent failure", payload, fmt.Errorf("mailbox unavailable: %w", ErrPermanent), 1, true, true},
+		{"Rate limited", payload, &queue.RetryAfterError{Delay: time.Minute, Err: errors.New("429")}, 1, true, false},
+		{"Malformed payload", []byte("{"), nil, 0, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch := &fakeChannel{err: tt.sendErr}
+			h := New(logger.NewDiscard(), ch, &fakeBroadcasts{})
+
+			err := h.ProcessTask(context.Background(), asynq.NewTask(queue.TypeEmailNotification, tt.payload))
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ProcessTask() error = %v, want error: %v", err, tt.wantErr)
+			}
+			if got := errors.Is(err, asynq.SkipRetry); got != tt.wantSkipRetry {
+				t.Errorf("SkipRetry = %v, want %v", got, tt.wantSkipRetry)
+			}
+			if len(ch.sent) != tt.wantSent {
+				t.Fatalf("sent %d notifications, want %d", len(ch.sent), tt.wantSent)
+			}
+			if tt.wantSent > 0 && ch.sent[0].Recipient != n.Recipient {
+				t.Errorf("recipient = %q, want %q", ch.sent[0].Recipient, n.Recipient)
+			}
+		})
+	}
+}
+
+func TestTaskHandler_ProcessTask_BroadcastDelivery(t *testing.T) {
+	tests := []struct {
+		name       string
+		sendErr    error
+		wantStatus string
+	}{
+		{"Sent", nil, models.RecipientSent},
+		{"Permanent failure", fmt.Errorf("unregistered: %w", ErrPermanent), models.RecipientFailed},
+		{"Transient failure", errors.New("connection refused"), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload, err := json.Marshal(queue.Notification{ID: "broadcast-1", Event: queue.EventBroadcast, Recipient: "token", BroadcastRecipientID: 7})
+			if err != nil {
+				t.Fatal(err)
+			}
+			broadcasts := &fakeBroadcasts{}
+			h := New(logger.NewDiscard(), &fakeChannel{err: tt.sendErr}, broadcasts)
+
+			_ = h.ProcessTask(context.Background(), asynq.NewTask(queue.TypePushNotification, payload))
+
+			if got := broadcasts.recorded[7]; got != tt.wantStatus {
+				t.Errorf("recorded status = %q, want %q", got, tt.wantStatus)
+			}
+		})
+	}
+}
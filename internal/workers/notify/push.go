@@ -0,0 +1,174 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ocenb/geo-alerts/internal/config"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/queue"
+)
+
+type DeviceRepo interface {
+	DeleteByToken(ctx context.Context, token string) error
+}
+
+// PushChannel sends notifications to mobile devices, through the FCM HTTP v1 API for android
+// and the APNs provider API for ios. The endpoints are configurable so that a mock can stand in.
+type PushChannel struct {
+	client     *http.Client
+	fcmURL     string
+	fcmProject string
+	fcmToken   string
+	apnsURL    string
+	apnsTopic  string
+	apnsToken  string
+	devices    DeviceRepo
+}
+
+// fcmRequest is a message of the FCM HTTP v1 API. Data values have to be strings.
+type fcmRequest struct {
+	Message fcmMessage `json:"message"`
+}
+
+type fcmMessage struct {
+	Token        string            `json:"token"`
+	Notification pushAlert         `json:"notification"`
+	Data         map[string]string `json:"data"`
+	Android      fcmAndroid        `json:"android"`
+}
+
+type fcmAndroid struct {
+	Priority string `json:"priority"`
+}
+
+type pushAlert struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+// apnsRequest is an APNs payload, custom keys go next to aps.
+type apnsRequest struct {
+	APS            apnsAPS         `json:"aps"`
+	NotificationID string          `json:"notification_id"`
+	Event          string          `json:"event"`
+	Data           json.RawMessage `json:"data,omitempty"`
+}
+
+type apnsAPS struct {
+	Alert             pushAlert `json:"alert"`
+	Sound             string    `json:"sound"`
+	InterruptionLevel string    `json:"interruption-level"`
+}
+
+func NewPushChannel(cfg config.NotifyConfig, devices DeviceRepo) *PushChannel {
+	return &PushChannel{
+		client:     &http.Client{Timeout: cfg.RequestTimeout},
+		fcmURL:     strings.TrimSuffix(cfg.FCMURL, "/"),
+		fcmProject: cfg.FCMProject,
+		fcmToken:   cfg.FCMToken,
+		apnsURL:    strings.TrimSuffix(cfg.APNsURL, "/"),
+		apnsTopic:  cfg.APNsTopic,
+		apnsToken:  cfg.APNsToken,
+		devices:    devices,
+	}
+}
+
+// Send pushes the notification to the device with the recipient token. A token the push service
+// no longer knows, because the app was uninstalled or the token refreshed, is dropped from the registry.
+func (c *PushChannel) Send(ctx context.Context, n *queue.Notification) error {
+	var req *http.Request
+	var err error
+	switch n.Platform {
+	case models.PlatformAndroid:
+		req, err = c.fcmRequest(ctx, n)
+	case models.PlatformIOS:
+		req, err = c.apnsRequest(ctx, n)
+	default:
+		return fmt.Errorf("unknown platform %q: %w", n.Platform, ErrPermanent)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to build push request: %v: %w", err, ErrPermanent)
+	}
+
+	status, err := do(c.client, req)
+	if status == http.StatusNotFound || status == http.StatusGone {
+		if delErr := c.devices.DeleteByToken(context.WithoutCancel(ctx), n.Recipient); delErr != nil {
+			return fmt.Errorf("%w, and failed to drop the device: %v", err, delErr)
+		}
+		return fmt.Errorf("device token is no longer valid: %w", err)
+	}
+	return err
+}
+
+func (c *PushChannel) fcmRequest(ctx context.Context, n *queue.Notification) (*http.Request, error) {
+	if c.fcmURL == "" {
+		return nil, fmt.Errorf("fcm isn't configured")
+	}
+
+	body, err := json.Marshal(fcmRequest{Message: fcmMessage{
+		Token:        n.Recipient,
+		Notification: pushAlert{Title: n.Subject, Body: n.Text},
+		Data: map[string]string{
+			"notification_id": n.ID,
+			"event":           n.Event,
+			"data":            string(n.Data),
+		},
+		Android: fcmAndroid{Priority: "high"},
+	}})
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := c.fcmURL + "/v1/projects/" + url.PathEscape(c.fcmProject) + "/messages:send"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.fcmToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.fcmToken)
+	}
+	return req, nil
+}
+
+func (c *PushChannel) apnsRequest(ctx context.Context, n *queue.Notification) (*http.Request, error) {
+	if c.apnsURL == "" {
+		return nil, fmt.Errorf("apns isn't configured")
+	}
+
+	body, err := json.Marshal(apnsRequest{
+		APS: apnsAPS{
+			Alert:             pushAlert{Title: n.Subject, Body: n.Text},
+			Sound:             "default",
+			InterruptionLevel: "time-sensitive",
+		},
+		NotificationID: n.ID,
+		Event:          n.Event,
+		Data:           n.Data,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := c.apnsURL + "/3/device/" + url.PathEscape(n.Recipient)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("apns-topic", c.apnsTopic)
+	req.Header.Set("apns-push-type", "alert")
+	req.Header.Set("apns-priority", "10")
+	// APNs reports errors about the push under this ID.
+	req.Header.Set("apns-id", n.ID)
+	if c.apnsToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apnsToken)
+	}
+	return req, nil
+}
@@ -0,0 +1,159 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ocenb/geo-alerts/internal/config"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/queue"
+)
+
+type fakeDeviceRepo struct {
+	deleted []string
+}
+
+func (r *fakeDeviceRepo) DeleteByToken(_ context.Context, token string) error {
+	r.deleted = append(r.deleted, token)
+	return nil
+}
+
+type pushRequest struct {
+	path   string
+	header http.Header
+	body   []byte
+}
+
+func newPushServer(t *testing.T, status int) (*httptest.Server, *[]pushRequest) {
+	t.Helper()
+	var got []pushRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		got = append(got, pushRequest{path: r.URL.Path, header: r.Header.Clone(), body: body})
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &got
+}
+
+func pushConfig(url string) config.NotifyConfig {
+	return config.NotifyConfig{
+		RequestTimeout: time.Second,
+		FCMURL:         url + "/",
+		FCMProject:     "geo-alerts",
+		FCMToken:       "fcm-token",
+		APNsURL:        url,
+		APNsTopic:      "com.example.geoalerts",
+		APNsToken:      "apns-token",
+	}
+}
+
+func TestPushChannel_SendFCM(t *testing.T) {
+	srv, got := newPushServer(t, http.StatusOK)
+	n := &queue.Notification{ID: "n1", Event: "danger", Recipient: "android-token", Platform: models.PlatformAndroid,
+		Subject: "You are in a danger zone", Text: "Leave the area: fire (severity 4).", Data: json.RawMessage(`{"user_id":"u1"}`)}
+
+	if err := NewPushChannel(pushConfig(srv.URL), &fakeDeviceRepo{}).Send(context.Background(), n); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	if len(*got) != 1 {
+		t.Fatalf("got %d requests, want 1", len(*got))
+	}
+	req := (*got)[0]
+	if req.path != "/v1/projects/geo-alerts/messages:send" {
+		t.Errorf("path = %q", req.path)
+	}
+	if auth := req.header.Get("Authorization"); auth != "Bearer fcm-token" {
+		t.Errorf("Authorization = %q", auth)
+	}
+
+	var msg fcmRequest
+	if err := json.Unmarshal(req.body, &msg); err != nil {
+		t.Fatal(err)
+	}
+	if msg.Message.Token != n.Recipient || msg.Message.Notification.Title != n.Subject || msg.Message.Notification.Body != n.Text {
+		t.Errorf("message = %+v", msg.Message)
+	}
+	if msg.Message.Data["notification_id"] != "n1" || msg.Message.Data["data"] != `{"user_id":"u1"}` {
+		t.Errorf("data = %v", msg.Message.Data)
+	}
+}
+
+func TestPushChannel_SendAPNs(t *testing.T) {
+	srv, got := newPushServer(t, http.StatusOK)
+	n := &queue.Notification{ID: "7c9e6679-7425-40de-944b-e07fc1f90ae7", Event: "danger", Recipient: "ios-token",
+		Platform: models.PlatformIOS, Subject: "Вы в опасной зоне", Text: "Покиньте район."}
+
+	if err := NewPushChannel(pushConfig(srv.URL), &fakeDeviceRepo{}).Send(context.Background(), n); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	if len(*got) != 1 {
+		t.Fatalf("got %d requests, want 1", len(*got))
+	}
+	req := (*got)[0]
+	if req.path != "/3/device/ios-token" {
+		t.Errorf("path = %q", req.path)
+	}
+	for name, want := range map[string]string{
+		"Authorization":  "Bearer apns-token",
+		"apns-topic":     "com.example.geoalerts",
+		"apns-push-type": "alert",
+		"apns-id":        n.ID,
+	} {
+		if v := req.header.Get(name); v != want {
+			t.Errorf("%s = %q, want %q", name, v, want)
+		}
+	}
+
+	var payload apnsRequest
+	if err := json.Unmarshal(req.body, &payload); err != nil {
+		t.Fatal(err)
+	}
+	if payload.APS.Alert.Title != n.Subject || payload.NotificationID != n.ID {
+		t.Errorf("payload = %+v", payload)
+	}
+}
+
+func TestPushChannel_SendFailures(t *testing.T) {
+	tests := []struct {
+		name          string
+		platform      string
+		status        int
+		wantPermanent bool
+		wantDeleted   bool
+	}{
+		{"FCM unregistered token", models.PlatformAndroid, http.StatusNotFound, true, true},
+		{"APNs unregistered token", models.PlatformIOS, http.StatusGone, true, true},
+		{"Bad request", models.PlatformIOS, http.StatusBadRequest, true, false},
+		{"Service down", models.PlatformAndroid, http.StatusServiceUnavailable, false, false},
+		{"Unknown platform", "symbian", http.StatusOK, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, _ := newPushServer(t, tt.status)
+			devices := &fakeDeviceRepo{}
+
+			err := NewPushChannel(pushConfig(srv.URL), devices).Send(context.Background(),
+				&queue.Notification{ID: "n1", Recipient: "token", Platform: tt.platform})
+
+			if err == nil {
+				t.Fatal("Send() error = nil, want error")
+			}
+			if got := errors.Is(err, ErrPermanent); got != tt.wantPermanent {
+				t.Errorf("permanent = %v, want %v (error: %v)", got, tt.wantPermanent, err)
+			}
+			if got := len(devices.deleted) == 1 && devices.deleted[0] == "token"; got != tt.wantDeleted {
+				t.Errorf("deleted = %v, want token deleted: %v", devices.deleted, tt.wantDeleted)
+			}
+		})
+	}
+}
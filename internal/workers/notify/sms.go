@@ -0,0 +1,61 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ocenb/geo-alerts/internal/config"
+	"github.com/ocenb/geo-alerts/internal/queue"
+)
+
+// SMSChannel sends the subject of notifications as text messages through an HTTP gateway.
+type SMSChannel struct {
+	client *http.Client
+	url    string
+	token  string
+	sender string
+}
+
+// smsRequest is what the gateway gets. Reference lets it drop a message resent after a lost response.
+type smsRequest struct {
+	From      string `json:"from,omitempty"`
+	To        string `json:"to"`
+	Text      string `json:"text"`
+	Reference string `json:"reference"`
+}
+
+func NewSMSChannel(cfg config.NotifyConfig) *SMSChannel {
+	return &SMSChannel{
+		client: &http.Client{Timeout: cfg.RequestTimeout},
+		url:    cfg.SMSGatewayURL,
+		token:  cfg.SMSGatewayToken,
+		sender: cfg.SMSSender,
+	}
+}
+
+func (c *SMSChannel) Send(ctx context.Context, n *queue.Notification) error {
+	body, err := json.Marshal(smsRequest{
+		From:      c.sender,
+		To:        n.Recipient,
+		Text:      n.Subject,
+		Reference: n.ID + ":" + n.Recipient,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal sms request: %v: %w", err, ErrPermanent)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create http request: %v: %w", err, ErrPermanent)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	_, err = do(c.client, req)
+	return err
+}
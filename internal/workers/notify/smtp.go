@@ -0,0 +1,154 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"github.com/ocenb/geo-alerts/internal/config"
+	"github.com/ocenb/geo-alerts/internal/queue"
+)
+
+// SMTPChannel emails notifications as plain text. STARTTLS is used whenever the server offers it.
+type SMTPChannel struct {
+	host     string
+	addr     string
+	from     string
+	auth     smtp.Auth
+	startTLS bool // required rather than only used when offered
+	timeout  time.Duration
+}
+
+func NewSMTPChannel(cfg config.NotifyConfig) *SMTPChannel {
+	var auth smtp.Auth
+	if cfg.SMTPUsername != "" {
+		// Refuses to send the password over a connection that isn't encrypted, unless to localhost.
+		auth = smtp.PlainAuth("", cfg.SMTPUsername, cfg.SMTPPassword, cfg.SMTPHost)
+	}
+
+	return &SMTPChannel{
+		host:     cfg.SMTPHost,
+		addr:     net.JoinHostPort(cfg.SMTPHost, cfg.SMTPPort),
+		from:     cfg.SMTPFrom,
+		auth:     auth,
+		startTLS: cfg.SMTPStartTLS,
+		timeout:  cfg.RequestTimeout,
+	}
+}
+
+func (c *SMTPChannel) Send(ctx context.Context, n *queue.Notification) error {
+	msg, err := buildEmail(c.from, n)
+	if err != nil {
+		return fmt.Errorf("failed to build email: %v: %w", err, ErrPermanent)
+	}
+
+	dialer := net.Dialer{Timeout: c.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", c.addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to smtp server: %w", err)
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(c.timeout)
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to set smtp deadline: %w", err)
+	}
+
+	client, err := smtp.NewClient(conn, c.host)
+	if err != nil {
+		conn.Close()
+		return smtpError("greeting", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: c.host}); err != nil {
+			return smtpError("STARTTLS", err)
+		}
+	} else if c.startTLS {
+		return errors.New("smtp server doesn't offer STARTTLS")
+	}
+
+	if c.auth != nil {
+		if err := client.Auth(c.auth); err != nil {
+			return smtpError("AUTH", err)
+		}
+	}
+	if err := client.Mail(c.from); err != nil {
+		return smtpError("MAIL", err)
+	}
+	if err := client.Rcpt(n.Recipient); err != nil {
+		return smtpError("RCPT", err)
+	}
+	w, err := client.Data()
+	if err != nil {
+		return smtpError("DATA", err)
+	}
+	if _, err := w.Write(msg); err != nil {
+		return smtpError("DATA", err)
+	}
+	if err := w.Close(); err != nil {
+		return smtpError("DATA", err)
+	}
+	// The message is accepted by now, a failed QUIT doesn't make it worth sending again.
+	_ = client.Quit()
+	return nil
+}
+
+// smtpError marks 5xx replies, which the server won't change its mind about, as permanent.
+func smtpError(cmd string, err error) error {
+	var reply *textproto.Error
+	if errors.As(err, &reply) && reply.Code >= 500 {
+		return fmt.Errorf("smtp %s failed: %w: %w", cmd, err, ErrPermanent)
+	}
+	return fmt.Errorf("smtp %s failed: %w", cmd, err)
+}
+
+// buildEmail renders the notification as a plain text message. Header values are encoded,
+// so that nothing in a notification can add headers of its own.
+func buildEmail(from string, n *queue.Notification) ([]byte, error) {
+	to, err := mail.ParseAddress(n.Recipient)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recipient: %w", err)
+	}
+	sender, err := mail.ParseAddress(from)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender: %w", err)
+	}
+	domain := sender.Address[strings.LastIndex(sender.Address, "@")+1:]
+
+	var b bytes.Buffer
+	header := func(name, value string) {
+		b.WriteString(name + ": " + value + "\r\n")
+	}
+	header("From", sender.String())
+	header("To", to.String())
+	header("Subject", mime.QEncoding.Encode("utf-8", n.Subject))
+	header("Date", n.Time.Format(time.RFC1123Z))
+	header("Message-ID", "<"+n.ID+"@"+domain+">")
+	header("MIME-Version", "1.0")
+	header("Content-Type", "text/plain; charset=utf-8")
+	header("Content-Transfer-Encoding", "quoted-printable")
+	b.WriteString("\r\n")
+
+	qp := quotedprintable.NewWriter(&b)
+	if _, err := qp.Write([]byte(strings.ReplaceAll(n.Text, "\n", "\r\n"))); err != nil {
+		return nil, err
+	}
+	if err := qp.Close(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
@@ -0,0 +1,208 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ocenb/geo-alerts/internal/config"
+	"github.com/ocenb/geo-alerts/internal/queue"
+)
+
+// fakeSMTP is a local SMTP server that accepts one message per connection,
+// or rejects the recipient with rcptReply.
+type fakeSMTP struct {
+	ln        net.Listener
+	rcptReply string
+
+	mu       sync.Mutex
+	from     string
+	rcpt     string
+	messages []string
+}
+
+func newFakeSMTP(t *testing.T, rcptReply string) *fakeSMTP {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &fakeSMTP{ln: ln, rcptReply: rcptReply}
+	t.Cleanup(func() { ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *fakeSMTP) serve(conn net.Conn) {
+	defer conn.Close()
+	tp := textproto.NewConn(conn)
+	reply := func(line string) { _ = tp.PrintfLine("%s", line) }
+
+	reply("220 fake ESMTP")
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		switch cmd {
+		case "EHLO", "HELO":
+			reply("250 fake")
+		case "MAIL":
+			s.mu.Lock()
+			s.from = line
+			s.mu.Unlock()
+			reply("250 OK")
+		case "RCPT":
+			if s.rcptReply != "" {
+				reply(s.rcptReply)
+				continue
+			}
+			s.mu.Lock()
+			s.rcpt = line
+			s.mu.Unlock()
+			reply("250 OK")
+		case "DATA":
+			reply("354 go ahead")
+			data, err := tp.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.messages = append(s.messages, string(data))
+			s.mu.Unlock()
+			reply("250 queued")
+		case "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("502 not implemented")
+		}
+	}
+}
+
+func (s *fakeSMTP) config(startTLS bool) config.NotifyConfig {
+	host, port, _ := net.SplitHostPort(s.ln.Addr().String())
+	return config.NotifyConfig{
+		RequestTimeout: time.Second,
+		SMTPHost:       host,
+		SMTPPort:       port,
+		SMTPFrom:       "alerts@geo-alerts.example",
+		SMTPStartTLS:   startTLS,
+	}
+}
+
+func TestSMTPChannel_Send(t *testing.T) {
+	n := &queue.Notification{
+		ID:        "n1",
+		Event:     "danger",
+		Time:      time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Recipient: "duty@example.com",
+		Subject:   "Danger: user u1 is inside 1 incident zone",
+		Text:      "User u1 at 55.750000, 37.620000\nInside:\n- incident 1 (fire, severity 4)\n",
+	}
+
+	srv := newFakeSMTP(t, "")
+	if err := NewSMTPChannel(srv.config(false)).Send(context.Background(), n); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	if srv.from != "MAIL FROM:<alerts@geo-alerts.example>" || srv.rcpt != "RCPT TO:<duty@example.com>" {
+		t.Errorf("envelope = %q, %q", srv.from, srv.rcpt)
+	}
+	if len(srv.messages) != 1 {
+		t.Fatalf("got %d messages, want 1", len(srv.messages))
+	}
+
+	msg, err := mail.ReadMessage(strings.NewReader(srv.messages[0]))
+	if err != nil {
+		t.Fatalf("mail.ReadMessage() error = %v", err)
+	}
+	if got := msg.Header.Get("Subject"); got != n.Subject {
+		t.Errorf("Subject = %q, want %q", got, n.Subject)
+	}
+	if got := msg.Header.Get("To"); got != "<duty@example.com>" {
+		t.Errorf("To = %q", got)
+	}
+	if got := msg.Header.Get("Message-ID"); got != "<n1@geo-alerts.example>" {
+		t.Errorf("Message-ID = %q", got)
+	}
+	body, err := io.ReadAll(quotedprintable.NewReader(msg.Body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.ReplaceAll(string(body), "\r\n", "\n"); got != n.Text {
+		t.Errorf("body = %q, want %q", got, n.Text)
+	}
+}
+
+func TestSMTPChannel_SendFailures(t *testing.T) {
+	n := &queue.Notification{ID: "n1", Recipient: "duty@example.com", Subject: "Danger"}
+
+	tests := []struct {
+		name          string
+		rcptReply     string
+		startTLS      bool
+		wantPermanent bool
+	}{
+		{"Mailbox unavailable", "550 no such user", false, true},
+		{"Greylisted", "451 try again later", false, false},
+		{"STARTTLS required", "", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newFakeSMTP(t, tt.rcptReply)
+
+			err := NewSMTPChannel(srv.config(tt.startTLS)).Send(context.Background(), n)
+
+			if err == nil {
+				t.Fatal("Send() error = nil, want error")
+			}
+			if got := errors.Is(err, ErrPermanent); got != tt.wantPermanent {
+				t.Errorf("permanent = %v, want %v (error: %v)", got, tt.wantPermanent, err)
+			}
+			srv.mu.Lock()
+			defer srv.mu.Unlock()
+			if len(srv.messages) != 0 {
+				t.Errorf("got %d messages, want none", len(srv.messages))
+			}
+		})
+	}
+}
+
+func TestBuildEmail_HeaderInjection(t *testing.T) {
+	n := &queue.Notification{ID: "n1", Recipient: "duty@example.com", Subject: "Danger\r\nBcc: someone@example.com"}
+
+	msg, err := buildEmail("alerts@geo-alerts.example", n)
+	if err != nil {
+		t.Fatalf("buildEmail() error = %v", err)
+	}
+
+	parsed, err := mail.ReadMessage(strings.NewReader(string(msg)))
+	if err != nil {
+		t.Fatalf("mail.ReadMessage() error = %v", err)
+	}
+	if got := parsed.Header.Get("Bcc"); got != "" {
+		t.Errorf("Bcc = %q, want none", got)
+	}
+}
@@ -0,0 +1,47 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ocenb/geo-alerts/internal/config"
+	"github.com/ocenb/geo-alerts/internal/queue"
+	"github.com/ocenb/geo-alerts/pkg/webhooksig"
+)
+
+// WebhookChannel posts notifications as JSON to the recipient URL. With a secret they are signed
+// the same way as subscription webhooks, the notification ID serving as the delivery ID.
+type WebhookChannel struct {
+	client *http.Client
+	secret string
+}
+
+func NewWebhookChannel(cfg config.NotifyConfig) *WebhookChannel {
+	return &WebhookChannel{
+		client: &http.Client{Timeout: cfg.RequestTimeout},
+		secret: cfg.WebhookSecret,
+	}
+}
+
+func (c *WebhookChannel) Send(ctx context.Context, n *queue.Notification) error {
+	body, err := json.Marshal(n)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification: %v: %w", err, ErrPermanent)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.Recipient, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create http request: %v: %w", err, ErrPermanent)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.secret != "" {
+		webhooksig.SetHeaders(req.Header, n.ID, time.Now(), body, c.secret)
+	}
+
+	_, err = do(c.client, req)
+	return err
+}
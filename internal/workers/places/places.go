@@ -0,0 +1,68 @@
+package places
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/hibiken/asynq"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/logger/logattr"
+	"github.com/ocenb/geo-alerts/internal/queue"
+)
+
+type PlaceRepo interface {
+	ClaimAffected(ctx context.Context, userID string, incidentID int64) ([]models.PlaceMatch, error)
+	ReleaseAlerts(ctx context.Context, matches []models.PlaceMatch) error
+}
+
+type QueueProducer interface {
+	EnqueuePlaceAlerts(ctx context.Context, matches []models.PlaceMatch) ([]models.PlaceMatch, error)
+}
+
+type TaskHandler struct {
+	log       *slog.Logger
+	placeRepo PlaceRepo
+	queue     QueueProducer
+}
+
+func New(log *slog.Logger, placeRepo PlaceRepo, queue QueueProducer) *TaskHandler {
+	return &TaskHandler{
+		log:       log,
+		placeRepo: placeRepo,
+		queue:     queue,
+	}
+}
+
+// ProcessTask alerts the owners of the saved places the zone of a new or changed incident reaches into.
+// Places alerted about the incident before are left out.
+func (h *TaskHandler) ProcessTask(ctx context.Context, t *asynq.Task) error {
+	var task queue.PlaceAlertTask
+	if err := json.Unmarshal(t.Payload(), &task); err != nil {
+		h.log.Error("failed to unmarshal task payload", logattr.Err(err))
+		return fmt.Errorf("json.Unmarshal failed: %v: %w", err, asynq.SkipRetry)
+	}
+
+	log := h.log.With(slog.Int64("incident_id", task.IncidentID))
+
+	// Only active incidents match, so a deactivated incident claims nothing.
+	matches, err := h.placeRepo.ClaimAffected(ctx, "", task.IncidentID)
+	if err != nil {
+		log.Error("failed to find places in zone", logattr.Err(err))
+		return err
+	}
+
+	failed, err := h.queue.EnqueuePlaceAlerts(ctx, matches)
+	if err != nil {
+		log.Error("failed to enqueue place alerts", slog.Int("failed", len(failed)), logattr.Err(err))
+		// The retry claims them again.
+		if relErr := h.placeRepo.ReleaseAlerts(ctx, failed); relErr != nil {
+			log.Error("failed to release unsent alerts", logattr.Err(relErr))
+		}
+		return err
+	}
+
+	log.Info("place alerts enqueued", slog.Int("places", len(matches)))
+	return nil
+}
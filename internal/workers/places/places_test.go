@@ -0,0 +1,97 @@
+package places
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/hibiken/asynq"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/logger"
+	"github.com/ocenb/geo-alerts/internal/queue"
+)
+
+type fakePlaces struct {
+	matches  []models.PlaceMatch
+	claimed  int64
+	released []int64
+}
+
+func (f *fakePlaces) ClaimAffected(_ context.Context, _ string, incidentID int64) ([]models.PlaceMatch, error) {
+	f.claimed = incidentID
+	return f.matches, nil
+}
+
+func (f *fakePlaces) ReleaseAlerts(_ context.Context, matches []models.PlaceMatch) error {
+	for _, m := range matches {
+		f.released = append(f.released, m.Place.ID)
+	}
+	return nil
+}
+
+type fakeQueue struct {
+	failFor int64
+	alerted []int64
+}
+
+func (f *fakeQueue) EnqueuePlaceAlerts(_ context.Context, matches []models.PlaceMatch) ([]models.PlaceMatch, error) {
+	var failed []models.PlaceMatch
+	for _, m := range matches {
+		if m.Place.ID == f.failFor {
+			failed = append(failed, m)
+			continue
+		}
+		f.alerted = append(f.alerted, m.Place.ID)
+	}
+	if len(failed) > 0 {
+		return failed, errors.New("redis down")
+	}
+	return nil, nil
+}
+
+func TestTaskHandler_ProcessTask(t *testing.T) {
+	matches := []models.PlaceMatch{
+		{Place: models.SavedPlace{ID: 1, UserID: "u1"}, Incident: models.IncidentShort{ID: 7}},
+		{Place: models.SavedPlace{ID: 2, UserID: "u2"}, Incident: models.IncidentShort{ID: 7}},
+	}
+
+	tests := []struct {
+		name         string
+		failFor      int64
+		wantErr      bool
+		wantAlerted  []int64
+		wantReleased []int64
+	}{
+		{"Alerts places in zone", 0, false, []int64{1, 2}, nil},
+		{"Enqueue failure releases the place", 2, true, []int64{1}, []int64{2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			places := &fakePlaces{matches: matches}
+			q := &fakeQueue{failFor: tt.failFor}
+			h := New(logger.NewDiscard(), places, q)
+
+			payload, err := json.Marshal(queue.PlaceAlertTask{IncidentID: 7})
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = h.ProcessTask(context.Background(), asynq.NewTask(queue.TypePlaceAlert, payload))
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ProcessTask() error = %v, want error: %v", err, tt.wantErr)
+			}
+			if places.claimed != 7 {
+				t.Errorf("claimed for incident %d, want 7", places.claimed)
+			}
+			if !slices.Equal(q.alerted, tt.wantAlerted) {
+				t.Errorf("alerted = %v, want %v", q.alerted, tt.wantAlerted)
+			}
+			if !slices.Equal(places.released, tt.wantReleased) {
+				t.Errorf("released = %v, want %v", places.released, tt.wantReleased)
+			}
+		})
+	}
+}
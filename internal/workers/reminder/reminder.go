@@ -0,0 +1,110 @@
+package reminder
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/hibiken/asynq"
+	"github.com/ocenb/geo-alerts/internal/config"
+	"github.com/ocenb/geo-alerts/internal/domain/errs"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/logger/logattr"
+	"github.com/ocenb/geo-alerts/internal/queue"
+)
+
+type IncidentRepo interface {
+	GetByID(ctx context.Context, id int64) (*models.Incident, error)
+}
+
+type CheckinRepo interface {
+	ListUnaccounted(ctx context.Context, params *models.ListUnaccountedParams) ([]models.UnaccountedUser, error)
+}
+
+type DeviceRepo interface {
+	ListByUsers(ctx context.Context, userIDs []string) ([]models.Device, error)
+}
+
+type QueueProducer interface {
+	EnqueueCheckinReminderPushes(ctx context.Context, incident *models.Incident, devices []models.Device) error
+}
+
+type TaskHandler struct {
+	log         *slog.Logger
+	cfg         config.AppConfig
+	incRepo     IncidentRepo
+	checkinRepo CheckinRepo
+	deviceRepo  DeviceRepo
+	queue       QueueProducer
+}
+
+func New(log *slog.Logger, cfg config.AppConfig, incRepo IncidentRepo, checkinRepo CheckinRepo, deviceRepo DeviceRepo, queue QueueProducer) *TaskHandler {
+	return &TaskHandler{
+		log:         log,
+		cfg:         cfg,
+		incRepo:     incRepo,
+		checkinRepo: checkinRepo,
+		deviceRepo:  deviceRepo,
+		queue:       queue,
+	}
+}
+
+// ProcessTask pushes a reminder to check in to the devices of the users seen in the incident zone
+// who haven't checked in yet.
+func (h *TaskHandler) ProcessTask(ctx context.Context, t *asynq.Task) error {
+	var task queue.CheckinReminderTask
+	if err := json.Unmarshal(t.Payload(), &task); err != nil {
+		h.log.Error("failed to unmarshal task payload", logattr.Err(err))
+		return fmt.Errorf("json.Unmarshal failed: %v: %w", err, asynq.SkipRetry)
+	}
+
+	log := h.log.With(slog.Int64("incident_id", task.IncidentID))
+
+	incident, err := h.incRepo.GetByID(ctx, task.IncidentID)
+	if err != nil {
+		if errors.Is(err, errs.ErrIncidentNotFound) {
+			log.Warn("incident no longer exists")
+			return nil
+		}
+		log.Error("failed to get incident", logattr.Err(err))
+		return err
+	}
+	if !incident.IsActive {
+		log.Debug("incident is no longer active")
+		return nil
+	}
+
+	users, err := h.checkinRepo.ListUnaccounted(ctx, &models.ListUnaccountedParams{
+		IncidentID:          incident.ID,
+		Window:              h.cfg.StatsTimeWindow,
+		CountPossiblyInside: h.cfg.StatsCountPossiblyInside,
+	})
+	if err != nil {
+		log.Error("failed to list unaccounted users", logattr.Err(err))
+		return err
+	}
+	if len(users) == 0 {
+		log.Info("everyone seen in the zone has checked in")
+		return nil
+	}
+
+	userIDs := make([]string, 0, len(users))
+	for _, u := range users {
+		userIDs = append(userIDs, u.UserID)
+	}
+	devices, err := h.deviceRepo.ListByUsers(ctx, userIDs)
+	if err != nil {
+		log.Error("failed to list devices", logattr.Err(err))
+		return err
+	}
+
+	if err := h.queue.EnqueueCheckinReminderPushes(ctx, incident, devices); err != nil {
+		log.Error("failed to enqueue check-in reminders", logattr.Err(err))
+		return err
+	}
+
+	log.Info("check-in reminders enqueued", slog.Int("users", len(users)), slog.Int("devices", len(devices)))
+	return nil
+}
@@ -0,0 +1,93 @@
+package reminder
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/ocenb/geo-alerts/internal/config"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/logger"
+	"github.com/ocenb/geo-alerts/internal/queue"
+)
+
+type fakeIncidents struct {
+	incident *models.Incident
+}
+
+func (f *fakeIncidents) GetByID(_ context.Context, _ int64) (*models.Incident, error) {
+	return f.incident, nil
+}
+
+type fakeCheckins struct {
+	users  []models.UnaccountedUser
+	params *models.ListUnaccountedParams
+}
+
+func (f *fakeCheckins) ListUnaccounted(_ context.Context, params *models.ListUnaccountedParams) ([]models.UnaccountedUser, error) {
+	f.params = params
+	return f.users, nil
+}
+
+type fakeDevices struct {
+	devices []models.Device
+}
+
+func (f *fakeDevices) ListByUsers(_ context.Context, _ []string) ([]models.Device, error) {
+	return f.devices, nil
+}
+
+type fakeQueue struct {
+	pushed []models.Device
+}
+
+func (f *fakeQueue) EnqueueCheckinReminderPushes(_ context.Context, _ *models.Incident, devices []models.Device) error {
+	f.pushed = append(f.pushed, devices...)
+	return nil
+}
+
+func TestTaskHandler_ProcessTask(t *testing.T) {
+	devices := []models.Device{{ID: 1, UserID: "u1"}, {ID: 2, UserID: "u2"}}
+
+	tests := []struct {
+		name       string
+		active     bool
+		users      []models.UnaccountedUser
+		wantListed bool
+		wantPushed int
+	}{
+		{"Reminds the unaccounted", true, []models.UnaccountedUser{{UserID: "u1"}, {UserID: "u2"}}, true, 2},
+		{"Everyone checked in", true, nil, true, 0},
+		{"Inactive incident", false, []models.UnaccountedUser{{UserID: "u1"}}, false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkins := &fakeCheckins{users: tt.users}
+			q := &fakeQueue{}
+			cfg := config.AppConfig{StatsTimeWindow: 15 * time.Minute}
+			incidents := &fakeIncidents{incident: &models.Incident{ID: 1, IsActive: tt.active}}
+			h := New(logger.NewDiscard(), cfg, incidents, checkins, &fakeDevices{devices: devices}, q)
+
+			payload, err := json.Marshal(queue.CheckinReminderTask{IncidentID: 1})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := h.ProcessTask(context.Background(), asynq.NewTask(queue.TypeCheckinReminder, payload)); err != nil {
+				t.Fatalf("ProcessTask() error = %v", err)
+			}
+
+			if listed := checkins.params != nil; listed != tt.wantListed {
+				t.Fatalf("listed = %v, want %v", listed, tt.wantListed)
+			}
+			if tt.wantListed && (checkins.params.Window != cfg.StatsTimeWindow || checkins.params.Limit != 0) {
+				t.Errorf("params = %+v, want the stats window and no limit", checkins.params)
+			}
+			if len(q.pushed) != tt.wantPushed {
+				t.Errorf("pushed to %d devices, want %d", len(q.pushed), tt.wantPushed)
+			}
+		})
+	}
+}
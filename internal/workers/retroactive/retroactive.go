@@ -0,0 +1,100 @@
+package retroactive
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/ocenb/geo-alerts/internal/domain/errs"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/logger/logattr"
+	"github.com/ocenb/geo-alerts/internal/queue"
+)
+
+type IncidentRepo interface {
+	GetByID(ctx context.Context, id int64) (*models.Incident, error)
+}
+
+type LocationRepo interface {
+	ClaimRecentInZone(ctx context.Context, incident *models.IncidentShort, since time.Time) ([]models.LastCheck, error)
+	ReleaseAlerts(ctx context.Context, incidentID int64, userIDs []string) error
+}
+
+type QueueProducer interface {
+	EnqueueDangerAlert(ctx context.Context, userID string, latitude, longitude float64, dangers []models.IncidentShort) error
+}
+
+type TaskHandler struct {
+	log          *slog.Logger
+	incRepo      IncidentRepo
+	locationRepo LocationRepo
+	queue        QueueProducer
+}
+
+func New(log *slog.Logger, incRepo IncidentRepo, locationRepo LocationRepo, queue QueueProducer) *TaskHandler {
+	return &TaskHandler{
+		log:          log,
+		incRepo:      incRepo,
+		locationRepo: locationRepo,
+		queue:        queue,
+	}
+}
+
+// ProcessTask alerts the users last seen in the zone of a new or expanded incident, who would otherwise
+// hear of it only with their next check. Users alerted about the incident before are left out.
+func (h *TaskHandler) ProcessTask(ctx context.Context, t *asynq.Task) error {
+	var task queue.RetroactiveAlertTask
+	if err := json.Unmarshal(t.Payload(), &task); err != nil {
+		h.log.Error("failed to unmarshal task payload", logattr.Err(err))
+		return fmt.Errorf("json.Unmarshal failed: %v: %w", err, asynq.SkipRetry)
+	}
+
+	log := h.log.With(slog.Int64("incident_id", task.IncidentID))
+
+	incident, err := h.incRepo.GetByID(ctx, task.IncidentID)
+	if err != nil {
+		if errors.Is(err, errs.ErrIncidentNotFound) {
+			log.Warn("incident no longer exists")
+			return nil
+		}
+		log.Error("failed to get incident", logattr.Err(err))
+		return err
+	}
+	if !incident.IsActive {
+		log.Debug("incident is no longer active")
+		return nil
+	}
+
+	short := incident.Short()
+	checks, err := h.locationRepo.ClaimRecentInZone(ctx, &short, task.Since)
+	if err != nil {
+		log.Error("failed to find users in zone", logattr.Err(err))
+		return err
+	}
+
+	dangers := []models.IncidentShort{short}
+	var failed []string
+	var enqueueErrs []error
+	for _, c := range checks {
+		if err := h.queue.EnqueueDangerAlert(ctx, c.UserID, c.Latitude, c.Longitude, dangers); err != nil {
+			failed = append(failed, c.UserID)
+			enqueueErrs = append(enqueueErrs, fmt.Errorf("user %s: %w", c.UserID, err))
+		}
+	}
+
+	if err := errors.Join(enqueueErrs...); err != nil {
+		log.Error("failed to enqueue retroactive alerts", slog.Int("failed", len(failed)), logattr.Err(err))
+		// The retry claims them again.
+		if relErr := h.locationRepo.ReleaseAlerts(ctx, incident.ID, failed); relErr != nil {
+			log.Error("failed to release unsent alerts", logattr.Err(relErr))
+		}
+		return err
+	}
+
+	log.Info("retroactive alerts enqueued", slog.Int("users", len(checks)))
+	return nil
+}
@@ -0,0 +1,101 @@
+package retroactive
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/logger"
+	"github.com/ocenb/geo-alerts/internal/queue"
+)
+
+type fakeIncidents struct {
+	incident *models.Incident
+}
+
+func (f *fakeIncidents) GetByID(_ context.Context, _ int64) (*models.Incident, error) {
+	return f.incident, nil
+}
+
+type fakeLocations struct {
+	checks   []models.LastCheck
+	claimed  bool
+	released []string
+}
+
+func (f *fakeLocations) ClaimRecentInZone(_ context.Context, _ *models.IncidentShort, _ time.Time) ([]models.LastCheck, error) {
+	f.claimed = true
+	return f.checks, nil
+}
+
+func (f *fakeLocations) ReleaseAlerts(_ context.Context, _ int64, userIDs []string) error {
+	f.released = append(f.released, userIDs...)
+	return nil
+}
+
+type fakeQueue struct {
+	failFor string
+	alerted []string
+}
+
+func (f *fakeQueue) EnqueueDangerAlert(_ context.Context, userID string, _, _ float64, dangers []models.IncidentShort) error {
+	if userID == f.failFor {
+		return errors.New("redis down")
+	}
+	if len(dangers) != 1 || dangers[0].ID != 1 {
+		return errors.New("unexpected dangers")
+	}
+	f.alerted = append(f.alerted, userID)
+	return nil
+}
+
+func TestTaskHandler_ProcessTask(t *testing.T) {
+	checks := []models.LastCheck{{UserID: "u1"}, {UserID: "u2"}}
+
+	tests := []struct {
+		name         string
+		active       bool
+		failFor      string
+		wantErr      bool
+		wantClaimed  bool
+		wantAlerted  []string
+		wantReleased []string
+	}{
+		{"Alerts users in zone", true, "", false, true, []string{"u1", "u2"}, nil},
+		{"Inactive incident", false, "", false, false, nil, nil},
+		{"Enqueue failure releases the user", true, "u2", true, true, []string{"u1"}, []string{"u2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			locations := &fakeLocations{checks: checks}
+			q := &fakeQueue{failFor: tt.failFor}
+			incidents := &fakeIncidents{incident: &models.Incident{ID: 1, Radius: 100, IsActive: tt.active}}
+			h := New(logger.NewDiscard(), incidents, locations, q)
+
+			payload, err := json.Marshal(queue.RetroactiveAlertTask{IncidentID: 1, Since: time.Now().Add(-time.Minute)})
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = h.ProcessTask(context.Background(), asynq.NewTask(queue.TypeRetroactiveAlert, payload))
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ProcessTask() error = %v, want error: %v", err, tt.wantErr)
+			}
+			if locations.claimed != tt.wantClaimed {
+				t.Errorf("claimed = %v, want %v", locations.claimed, tt.wantClaimed)
+			}
+			if !slices.Equal(q.alerted, tt.wantAlerted) {
+				t.Errorf("alerted = %v, want %v", q.alerted, tt.wantAlerted)
+			}
+			if !slices.Equal(locations.released, tt.wantReleased) {
+				t.Errorf("released = %v, want %v", locations.released, tt.wantReleased)
+			}
+		})
+	}
+}
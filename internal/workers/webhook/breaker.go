@@ -0,0 +1,121 @@
+package webhook
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+)
+
+// breakers keeps a circuit breaker per destination host, so that a receiver which is down
+// doesn't keep workers busy waiting for timeouts while deliveries to other receivers queue up.
+type breakers struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	byDest    map[string]*breaker
+}
+
+type breaker struct {
+	state    string
+	failures int // consecutive
+	openedAt time.Time
+	probing  bool // a half-open breaker lets one request through at a time
+}
+
+func newBreakers(threshold int, cooldown time.Duration) *breakers {
+	return &breakers{
+		threshold: threshold,
+		cooldown:  cooldown,
+		byDest:    make(map[string]*breaker),
+	}
+}
+
+// allow reports whether a request to dest may be sent now and, if not, how long to wait.
+// Every allowed request must be followed by record.
+func (b *breakers) allow(dest string, now time.Time) (bool, time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	br := b.get(dest)
+	switch br.state {
+	case models.BreakerOpen:
+		if wait := br.openedAt.Add(b.cooldown).Sub(now); wait > 0 {
+			return false, wait
+		}
+		br.state = models.BreakerHalfOpen
+		br.probing = true
+		return true, 0
+	case models.BreakerHalfOpen:
+		if br.probing {
+			return false, b.cooldown
+		}
+		br.probing = true
+		return true, 0
+	}
+	return true, 0
+}
+
+// cancel gives up a request allow let through without sending it. If it was the probe,
+// the next request probes instead.
+func (b *breakers) cancel(dest string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.get(dest).probing = false
+}
+
+// record counts the outcome of a request. A failed probe opens the breaker again right away,
+// a successful one closes it.
+func (b *breakers) record(dest string, failed bool, now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	br := b.get(dest)
+	br.probing = false
+	if !failed {
+		br.state = models.BreakerClosed
+		br.failures = 0
+		return
+	}
+
+	br.failures++
+	if br.state == models.BreakerHalfOpen || br.failures >= b.threshold {
+		br.state = models.BreakerOpen
+		br.openedAt = now
+	}
+}
+
+func (b *breakers) get(dest string) *breaker {
+	br, ok := b.byDest[dest]
+	if !ok {
+		br = &breaker{state: models.BreakerClosed}
+		b.byDest[dest] = br
+	}
+	return br
+}
+
+func (b *breakers) stats() []models.BreakerStats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	stats := make([]models.BreakerStats, 0, len(b.byDest))
+	for dest, br := range b.byDest {
+		s := models.BreakerStats{
+			Destination: dest,
+			State:       br.state,
+			Failures:    br.failures,
+		}
+		if br.state != models.BreakerClosed {
+			openedAt, retryAt := br.openedAt, br.openedAt.Add(b.cooldown)
+			s.OpenedAt, s.RetryAt = &openedAt, &retryAt
+		}
+		stats = append(stats, s)
+	}
+	slices.SortFunc(stats, func(a, b models.BreakerStats) int {
+		return strings.Compare(a.Destination, b.Destination)
+	})
+	return stats
+}
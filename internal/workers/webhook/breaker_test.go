@@ -0,0 +1,77 @@
+package webhook
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+)
+
+func TestBreakers(t *testing.T) {
+	const dest = "example.com"
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	b := newBreakers(3, 30*time.Second)
+
+	mustAllow := func(at time.Time) {
+		t.Helper()
+		if ok, wait := b.allow(dest, at); !ok {
+			t.Fatalf("allow() = (false, %v), want true", wait)
+		}
+	}
+	mustReject := func(at time.Time, wantWait time.Duration) {
+		t.Helper()
+		if ok, wait := b.allow(dest, at); ok || wait != wantWait {
+			t.Fatalf("allow() = (%v, %v), want (false, %v)", ok, wait, wantWait)
+		}
+	}
+	wantState := func(state string, failures int) {
+		t.Helper()
+		stats := b.stats()
+		if len(stats) == 0 || stats[0].State != state || stats[0].Failures != failures {
+			t.Fatalf("stats() = %+v, want %s with %d failures first", stats, state, failures)
+		}
+	}
+
+	// A success resets the count of consecutive failures.
+	for range 2 {
+		mustAllow(now)
+		b.record(dest, true, now)
+	}
+	mustAllow(now)
+	b.record(dest, false, now)
+	wantState(models.BreakerClosed, 0)
+
+	for range 3 {
+		mustAllow(now)
+		b.record(dest, true, now)
+	}
+	wantState(models.BreakerOpen, 3)
+	mustReject(now.Add(10*time.Second), 20*time.Second)
+
+	if ok, _ := b.allow("other.example.com", now); !ok {
+		t.Fatal("breaker of another destination is open")
+	}
+
+	// After the cooldown one probe goes through, the rest wait for its outcome.
+	probeAt := now.Add(30 * time.Second)
+	mustAllow(probeAt)
+	wantState(models.BreakerHalfOpen, 3)
+	mustReject(probeAt, 30*time.Second)
+
+	// A probe given up before it was sent lets the next request probe.
+	b.cancel(dest)
+	mustAllow(probeAt)
+	wantState(models.BreakerHalfOpen, 3)
+	mustReject(probeAt, 30*time.Second)
+
+	// A failed probe opens the breaker for another cooldown.
+	b.record(dest, true, probeAt)
+	wantState(models.BreakerOpen, 4)
+	mustReject(probeAt.Add(time.Second), 29*time.Second)
+
+	probeAt = probeAt.Add(30 * time.Second)
+	mustAllow(probeAt)
+	b.record(dest, false, probeAt)
+	wantState(models.BreakerClosed, 0)
+	mustAllow(probeAt)
+}
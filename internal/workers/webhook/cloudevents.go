@@ -0,0 +1,104 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/queue"
+)
+
+// CloudEvents 1.0, see https://github.com/cloudevents/spec/blob/v1.0.2/cloudevents/spec.md.
+const (
+	ceSpecVersion     = "1.0"
+	ceContentType     = "application/cloudevents+json"
+	ceDataContentType = "application/json"
+)
+
+type eventType struct {
+	ceType string
+	schema string // name of the data schema, versioned separately from the event type
+}
+
+// eventTypes maps task types to the events they deliver.
+var eventTypes = map[string]eventType{
+	queue.TypeDangerWebhook:    {"geoalerts.danger.detected", "alert/v1"},
+	queue.TypeProximityWebhook: {"geoalerts.proximity.detected", "alert/v1"},
+	queue.TypePredictedWebhook: {"geoalerts.danger.predicted", "alert/v1"},
+	queue.TypeCrossingWebhook:  {"geoalerts.path.crossed", "alert/v1"},
+	queue.TypePlaceWebhook:     {"geoalerts.place.danger", "alert/v1"},
+
+	queue.TypeIncidentCreatedWebhook:     {"geoalerts.incident.created", "incident/v1"},
+	queue.TypeIncidentUpdatedWebhook:     {"geoalerts.incident.updated", "incident/v1"},
+	queue.TypeIncidentDeactivatedWebhook: {"geoalerts.incident.deactivated", "incident/v1"},
+
+	queue.TypeDigestWebhook: {"geoalerts.alerts.digest", "digest/v1"},
+}
+
+type cloudEvent struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Time            time.Time       `json:"time"`
+	DataSchema      string          `json:"dataschema"`
+	DataContentType string          `json:"datacontenttype"`
+	Data            json.RawMessage `json:"data"`
+}
+
+// newCloudEvent wraps the event data of a task. Tasks enqueued before events had IDs
+// fall back to the task ID, which is stable across retries too.
+func (h *TaskHandler) newCloudEvent(taskType, taskID string, task *queue.WebhookTask, data []byte, now time.Time) (*cloudEvent, error) {
+	et, ok := eventTypes[taskType]
+	if !ok {
+		return nil, fmt.Errorf("no event type for task type %q", taskType)
+	}
+
+	ev := &cloudEvent{
+		SpecVersion:     ceSpecVersion,
+		ID:              task.EventID,
+		Source:          h.eventSource,
+		Type:            et.ceType,
+		Time:            task.EventTime,
+		DataSchema:      strings.TrimSuffix(h.eventSchemaBase, "/") + "/" + et.schema,
+		DataContentType: ceDataContentType,
+		Data:            data,
+	}
+	if ev.ID == "" {
+		ev.ID = taskID
+	}
+	if ev.Time.IsZero() {
+		ev.Time = now.UTC()
+	}
+	return ev, nil
+}
+
+// encodeEvent returns the request body and headers of the event in the given payload format.
+func encodeEvent(format string, ev *cloudEvent) ([]byte, http.Header, error) {
+	header := http.Header{}
+
+	switch format {
+	case models.PayloadFormatCloudEventsStructured:
+		body, err := json.Marshal(ev)
+		if err != nil {
+			return nil, nil, err
+		}
+		header.Set("Content-Type", ceContentType)
+		return body, header, nil
+	case models.PayloadFormatCloudEventsBinary:
+		header.Set("Content-Type", ev.DataContentType)
+		header.Set("Ce-Specversion", ev.SpecVersion)
+		header.Set("Ce-Id", ev.ID)
+		header.Set("Ce-Source", ev.Source)
+		header.Set("Ce-Type", ev.Type)
+		header.Set("Ce-Time", ev.Time.Format(time.RFC3339Nano))
+		header.Set("Ce-Dataschema", ev.DataSchema)
+		return ev.Data, header, nil
+	default:
+		header.Set("Content-Type", "application/json")
+		return ev.Data, header, nil
+	}
+}
@@ -0,0 +1,104 @@
+package webhook
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/queue"
+)
+
+func TestNewCloudEvent(t *testing.T) {
+	h := &TaskHandler{eventSource: "/geo-alerts", eventSchemaBase: "https://example.com/schemas/"}
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	data := []byte(`{"user_id":"u1"}`)
+
+	task := &queue.WebhookTask{EventID: "event-1", EventTime: now.Add(-time.Minute)}
+	ev, err := h.newCloudEvent(queue.TypeDangerWebhook, "task-1", task, data, now)
+	if err != nil {
+		t.Fatalf("newCloudEvent() error = %v", err)
+	}
+	if ev.ID != "event-1" || !ev.Time.Equal(now.Add(-time.Minute)) {
+		t.Errorf("id, time = %q, %v, want the event's", ev.ID, ev.Time)
+	}
+	if ev.Type != "geoalerts.danger.detected" || ev.DataSchema != "https://example.com/schemas/alert/v1" {
+		t.Errorf("type, dataschema = %q, %q", ev.Type, ev.DataSchema)
+	}
+
+	// Tasks enqueued before events had IDs.
+	ev, err = h.newCloudEvent(queue.TypeCrossingWebhook, "task-1", &queue.WebhookTask{}, data, now)
+	if err != nil {
+		t.Fatalf("newCloudEvent() error = %v", err)
+	}
+	if ev.ID != "task-1" || !ev.Time.Equal(now) {
+		t.Errorf("id, time = %q, %v, want the task ID and now", ev.ID, ev.Time)
+	}
+
+	if _, err := h.newCloudEvent("webhook:unknown", "task-1", task, data, now); err == nil {
+		t.Error("newCloudEvent() with unknown task type: want error")
+	}
+}
+
+func TestEncodeEvent(t *testing.T) {
+	ev := &cloudEvent{
+		SpecVersion:     ceSpecVersion,
+		ID:              "event-1",
+		Source:          "/geo-alerts",
+		Type:            "geoalerts.danger.detected",
+		Time:            time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		DataSchema:      "urn:geo-alerts:schemas/alert/v1",
+		DataContentType: ceDataContentType,
+		Data:            json.RawMessage(`{"user_id":"u1"}`),
+	}
+
+	t.Run("Legacy", func(t *testing.T) {
+		body, header, err := encodeEvent(models.PayloadFormatLegacy, ev)
+		if err != nil {
+			t.Fatalf("encodeEvent() error = %v", err)
+		}
+		if string(body) != `{"user_id":"u1"}` || header.Get("Content-Type") != "application/json" || header.Get("Ce-Id") != "" {
+			t.Errorf("encodeEvent() = %s, %v", body, header)
+		}
+	})
+
+	t.Run("Structured", func(t *testing.T) {
+		body, header, err := encodeEvent(models.PayloadFormatCloudEventsStructured, ev)
+		if err != nil {
+			t.Fatalf("encodeEvent() error = %v", err)
+		}
+		want := `{"specversion":"1.0","id":"event-1","source":"/geo-alerts","type":"geoalerts.danger.detected",` +
+			`"time":"2026-01-02T03:04:05Z","dataschema":"urn:geo-alerts:schemas/alert/v1",` +
+			`"datacontenttype":"application/json","data":{"user_id":"u1"}}`
+		if string(body) != want {
+			t.Errorf("body = %s, want %s", body, want)
+		}
+		if got := header.Get("Content-Type"); got != ceContentType {
+			t.Errorf("Content-Type = %q, want %q", got, ceContentType)
+		}
+	})
+
+	t.Run("Binary", func(t *testing.T) {
+		body, header, err := encodeEvent(models.PayloadFormatCloudEventsBinary, ev)
+		if err != nil {
+			t.Fatalf("encodeEvent() error = %v", err)
+		}
+		if string(body) != `{"user_id":"u1"}` {
+			t.Errorf("body = %s, want the event data", body)
+		}
+		wantHeaders := map[string]string{
+			"Content-Type":   "application/json",
+			"ce-specversion": "1.0",
+			"ce-id":          "event-1",
+			"ce-source":      "/geo-alerts",
+			"ce-type":        "geoalerts.danger.detected",
+			"ce-time":        "2026-01-02T03:04:05Z",
+			"ce-dataschema":  "urn:geo-alerts:schemas/alert/v1",
+		}
+		for name, want := range wantHeaders {
+			if got := header.Get(name); got != want {
+				t.Errorf("%s = %q, want %q", name, got, want)
+			}
+		}
+	})
+}
@@ -0,0 +1,101 @@
+package webhook
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"golang.org/x/time/rate"
+)
+
+// limiterMaxWait is how long a worker waits for its turn before putting the task off instead.
+const limiterMaxWait = time.Second
+
+// limiters keeps a token bucket per subscriber, so that a burst of alerts doesn't flood a receiver.
+type limiters struct {
+	mu         sync.Mutex
+	defaultRPS float64
+	burst      int
+	bySub      map[int64]*limiter
+}
+
+type limiter struct {
+	rps       float64
+	lim       *rate.Limiter
+	allowed   int64
+	throttled int64
+}
+
+func newLimiters(defaultRPS float64, burst int) *limiters {
+	return &limiters{
+		defaultRPS: defaultRPS,
+		burst:      burst,
+		bySub:      make(map[int64]*limiter),
+	}
+}
+
+// reserve takes a token for the subscriber and returns how long to wait before sending.
+// A nil rps uses the default, 0 means unlimited. If the wait would be longer than limiterMaxWait
+// no token is taken and ok is false, the returned wait is then when to try again.
+func (l *limiters) reserve(subID int64, rps *float64, now time.Time) (wait time.Duration, ok bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	limit := l.defaultRPS
+	if rps != nil {
+		limit = *rps
+	}
+
+	lm, exists := l.bySub[subID]
+	if !exists {
+		lm = &limiter{rps: limit, lim: rate.NewLimiter(toLimit(limit), l.burst)}
+		l.bySub[subID] = lm
+	} else if lm.rps != limit {
+		lm.rps = limit
+		lm.lim.SetLimitAt(now, toLimit(limit))
+	}
+
+	r := lm.lim.ReserveN(now, 1)
+	wait = r.DelayFrom(now)
+	if wait > limiterMaxWait {
+		r.CancelAt(now)
+		lm.throttled++
+		return wait, false
+	}
+	lm.allowed++
+	return wait, true
+}
+
+func (l *limiters) stats(now time.Time) []models.LimiterStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	stats := make([]models.LimiterStats, 0, len(l.bySub))
+	for subID, lm := range l.bySub {
+		s := models.LimiterStats{
+			SubscriptionID: subID,
+			RPS:            lm.rps,
+			Burst:          l.burst,
+			Tokens:         float64(l.burst),
+			Allowed:        lm.allowed,
+			Throttled:      lm.throttled,
+		}
+		if lm.rps > 0 {
+			s.Tokens = lm.lim.TokensAt(now)
+		}
+		stats = append(stats, s)
+	}
+	slices.SortFunc(stats, func(a, b models.LimiterStats) int {
+		return cmp.Compare(a.SubscriptionID, b.SubscriptionID)
+	})
+	return stats
+}
+
+func toLimit(rps float64) rate.Limit {
+	if rps <= 0 {
+		return rate.Inf
+	}
+	return rate.Limit(rps)
+}
@@ -0,0 +1,61 @@
+package webhook
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLimiters(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	ptr := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name      string
+		rps       *float64
+		requests  int
+		wantWaits []time.Duration // of the allowed requests, in order
+		wantRetry time.Duration   // of the first throttled request, 0 = none throttled
+	}{
+		{"Burst goes through at once", nil, 2, []time.Duration{0, 0}, 0},
+		{"Beyond the burst waits its turn", nil, 4, []time.Duration{0, 0, 500 * time.Millisecond, time.Second}, 0},
+		{"Longer waits are put off", nil, 5, []time.Duration{0, 0, 500 * time.Millisecond, time.Second}, 1500 * time.Millisecond},
+		{"Subscription overrides the default", ptr(1), 4, []time.Duration{0, 0, time.Second}, 2 * time.Second},
+		{"Zero is unlimited", ptr(0), 10, []time.Duration{0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newLimiters(2, 2)
+
+			var waits []time.Duration
+			var retry time.Duration
+			for range tt.requests {
+				wait, ok := l.reserve(1, tt.rps, now)
+				if !ok {
+					if retry == 0 {
+						retry = wait
+					}
+					continue
+				}
+				waits = append(waits, wait)
+			}
+
+			if len(waits) != len(tt.wantWaits) {
+				t.Fatalf("allowed waits = %v, want %v", waits, tt.wantWaits)
+			}
+			for i := range waits {
+				if waits[i] != tt.wantWaits[i] {
+					t.Fatalf("allowed waits = %v, want %v", waits, tt.wantWaits)
+				}
+			}
+			if retry != tt.wantRetry {
+				t.Errorf("retry after = %v, want %v", retry, tt.wantRetry)
+			}
+
+			stats := l.stats(now)
+			if len(stats) != 1 || stats[0].Allowed != int64(len(tt.wantWaits)) || stats[0].Throttled != int64(tt.requests-len(tt.wantWaits)) {
+				t.Errorf("stats() = %+v", stats)
+			}
+		})
+	}
+}
@@ -3,28 +3,71 @@ package webhook
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/hibiken/asynq"
 	"github.com/ocenb/geo-alerts/internal/config"
+	"github.com/ocenb/geo-alerts/internal/domain/errs"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
 	"github.com/ocenb/geo-alerts/internal/logger/logattr"
 	"github.com/ocenb/geo-alerts/internal/queue"
+	"github.com/ocenb/geo-alerts/internal/utils/httpretry"
+	"github.com/ocenb/geo-alerts/pkg/webhooksig"
 )
 
+// responseBodyLimit is how much of a receiver's response is kept in the delivery log.
+const responseBodyLimit = 1024
+
+type SubscriptionRepo interface {
+	GetByID(ctx context.Context, id int64) (*models.WebhookSubscription, error)
+}
+
+type DeliveryRepo interface {
+	SaveDelivery(ctx context.Context, d *models.WebhookDelivery) error
+}
+
 type TaskHandler struct {
-	log        *slog.Logger
-	webhookURL string
-	httpClient *http.Client
+	log             *slog.Logger
+	webhookURL      string
+	webhookSecrets  []string
+	payloadFormat   string
+	eventSource     string
+	eventSchemaBase string
+	subRepo         SubscriptionRepo
+	deliveryRepo    DeliveryRepo
+	httpClient      *http.Client
+	breakers        *breakers
+	limiters        *limiters
 }
 
-func New(log *slog.Logger, cfg config.WebhookConfig) *TaskHandler {
+func New(log *slog.Logger, cfg config.WebhookConfig, subRepo SubscriptionRepo, deliveryRepo DeliveryRepo) *TaskHandler {
+	// Deliveries to WEBHOOK_URL are signed only once a secret is set, so receivers can start verifying at their own pace.
+	var secrets []string
+	if cfg.Secret != "" {
+		secrets = append(secrets, cfg.Secret)
+		if cfg.PreviousSecret != "" {
+			secrets = append(secrets, cfg.PreviousSecret)
+		}
+	}
+
 	return &TaskHandler{
-		log:        log,
-		webhookURL: cfg.URL,
+		log:             log,
+		webhookURL:      cfg.URL,
+		webhookSecrets:  secrets,
+		payloadFormat:   cfg.PayloadFormat,
+		eventSource:     cfg.EventSource,
+		eventSchemaBase: cfg.EventSchemaBase,
+		subRepo:         subRepo,
+		deliveryRepo:    deliveryRepo,
 		httpClient: &http.Client{
 			Timeout: cfg.RequestTimeout,
 			Transport: &http.Transport{
@@ -33,39 +76,139 @@ func New(log *slog.Logger, cfg config.WebhookConfig) *TaskHandler {
 				IdleConnTimeout:     cfg.ClientIdleConnTimeout,
 			},
 		},
+		breakers: newBreakers(cfg.BreakerFailureThreshold, cfg.BreakerCooldown),
+		limiters: newLimiters(cfg.RateLimitRPS, cfg.RateLimitBurst),
+	}
+}
+
+// OutboundStats returns the state of this worker's circuit breakers and rate limiters.
+func (h *TaskHandler) OutboundStats() *models.OutboundStats {
+	return &models.OutboundStats{
+		Breakers: h.breakers.stats(),
+		Limiters: h.limiters.stats(time.Now()),
 	}
 }
 
 func (h *TaskHandler) ProcessTask(ctx context.Context, t *asynq.Task) error {
-	var payload queue.WebhookPayload
-	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+	var task queue.WebhookTask
+	if err := json.Unmarshal(t.Payload(), &task); err != nil {
 		h.log.Error("failed to unmarshal task payload", logattr.Err(err))
 		return fmt.Errorf("json.Unmarshal failed: %v: %w", err, asynq.SkipRetry)
 	}
 
 	log := h.log.With(
-		slog.String("user_id", payload.UserID),
+		slog.Int64("subscription_id", task.SubscriptionID),
 		slog.String("task_type", t.Type()),
 	)
+	switch {
+	case task.Incident != nil:
+		log = log.With(slog.Int64("incident_id", task.Incident.Incident.ID))
+	case task.Digest != nil:
+		log = log.With(slog.Int("alerts", task.Digest.Total))
+	default:
+		log = log.With(slog.String("user_id", task.Payload.UserID))
+	}
 
 	log.Debug("processing webhook task")
 
-	reqBody, err := json.Marshal(payload)
+	now := time.Now()
+	webhookURL, secrets, format := h.webhookURL, h.webhookSecrets, h.payloadFormat
+	var rateLimit *float64
+	if task.SubscriptionID != queue.DefaultSubscriptionID {
+		sub, err := h.subRepo.GetByID(ctx, task.SubscriptionID)
+		if err != nil {
+			if errors.Is(err, errs.ErrSubscriptionNotFound) {
+				log.Warn("subscription no longer exists, dropping webhook")
+				return nil
+			}
+			log.Error("failed to get subscription", logattr.Err(err))
+			return err
+		}
+		if !sub.IsEnabled {
+			log.Info("subscription is disabled, dropping webhook")
+			return nil
+		}
+		webhookURL, secrets, rateLimit, format = sub.URL, signingSecrets(sub, now), sub.RateLimit, sub.PayloadFormat
+	}
+
+	// The task ID stays the same across retries, so receivers can use it to deduplicate.
+	deliveryID, _ := asynq.GetTaskID(ctx)
+	retried, _ := asynq.GetRetryCount(ctx)
+	delivery := &models.WebhookDelivery{
+		TaskID:         deliveryID,
+		TaskType:       t.Type(),
+		SubscriptionID: task.SubscriptionID,
+		RedeliveryOf:   task.RedeliveryOf,
+		URL:            webhookURL,
+		Payload:        json.RawMessage("{}"),
+		Attempt:        retried + 1,
+	}
+
+	data, err := task.Data()
+	if err != nil {
+		log.Error("failed to marshal event data", logattr.Err(err))
+		return h.dropDelivery(ctx, log, delivery, fmt.Errorf("json.Marshal failed: %w", err))
+	}
+	delivery.Payload = data
+
+	event, err := h.newCloudEvent(t.Type(), deliveryID, &task, data, now)
+	if err != nil {
+		log.Error("failed to build event", logattr.Err(err))
+		return h.dropDelivery(ctx, log, delivery, fmt.Errorf("newCloudEvent failed: %w", err))
+	}
+	delivery.EventID, delivery.EventTime = event.ID, &event.Time
+
+	reqBody, header, err := encodeEvent(format, event)
 	if err != nil {
-		log.Error("failed to marshal request body", logattr.Err(err))
-		return fmt.Errorf("json.Marshal failed: %v: %w", err, asynq.SkipRetry)
+		log.Error("failed to encode event", slog.String("payload_format", format), logattr.Err(err))
+		return h.dropDelivery(ctx, log, delivery, fmt.Errorf("encodeEvent failed: %w", err))
 	}
+	hash := sha256.Sum256(reqBody)
+	delivery.PayloadHash = hex.EncodeToString(hash[:])
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.webhookURL, bytes.NewBuffer(reqBody))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewBuffer(reqBody))
 	if err != nil {
+		// The URL is broken, retrying won't fix it.
 		log.Error("failed to create http request", logattr.Err(err))
+		return h.dropDelivery(ctx, log, delivery, fmt.Errorf("http.NewRequest failed: %w", err))
+	}
+	req.Header = header
+
+	// Tasks put off here don't use up retries, see queue.ErrDeferred. The breaker goes first,
+	// so that tasks put off while it is open don't use up the subscriber's rate limit.
+	dest := req.URL.Host
+	if ok, wait := h.breakers.allow(dest, time.Now()); !ok {
+		log.Info("circuit breaker is open, putting webhook off", slog.String("destination", dest), slog.Duration("retry_after", wait))
+		return &queue.RetryAfterError{Delay: wait, Err: fmt.Errorf("circuit breaker open for %s: %w", dest, queue.ErrDeferred)}
+	}
+
+	wait, ok := h.limiters.reserve(task.SubscriptionID, rateLimit, time.Now())
+	if !ok {
+		h.breakers.cancel(dest)
+		log.Info("subscriber rate limit reached, putting webhook off", slog.Duration("retry_after", wait))
+		return &queue.RetryAfterError{Delay: wait, Err: fmt.Errorf("rate limit reached: %w", queue.ErrDeferred)}
+	}
+	if err := sleep(ctx, wait); err != nil {
+		h.breakers.cancel(dest)
 		return err
 	}
-	req.Header.Set("Content-Type", "application/json")
 
+	if len(secrets) > 0 {
+		webhooksig.SetHeaders(req.Header, deliveryID, now, reqBody, secrets...)
+	} else {
+		req.Header.Set(webhooksig.HeaderDeliveryID, deliveryID)
+	}
+
+	// Tasks put off above aren't attempts, everything from here on is logged.
+	defer h.saveDelivery(ctx, log, delivery)
+
+	start := time.Now()
 	resp, err := h.httpClient.Do(req)
 	if err != nil {
-		log.Warn("failed to send webhook", logattr.Err(err))
+		delivery.LatencyMs = time.Since(start).Milliseconds()
+		delivery.Error = err.Error()
+		h.breakers.record(dest, true, time.Now())
+		log.Warn("failed to send webhook", slog.String("failure", httpretry.FailureTransient), logattr.Err(err))
 		return fmt.Errorf("webhook request failed: %w", err)
 	}
 	defer func() {
@@ -74,13 +217,81 @@ func (h *TaskHandler) ProcessTask(ctx context.Context, t *asynq.Task) error {
 		}
 	}()
 
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, responseBodyLimit))
 	_, _ = io.Copy(io.Discard, resp.Body)
 
+	delivery.LatencyMs = time.Since(start).Milliseconds()
+	delivery.StatusCode = &resp.StatusCode
+	delivery.ResponseBody = sanitizeText(respBody)
+
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		log.Warn("webhook returned non-success status", slog.Int("status", resp.StatusCode))
-		return fmt.Errorf("webhook request failed with status: %d", resp.StatusCode)
+		class, delay := httpretry.Classify(resp, time.Now())
+		// Only failures that suggest the receiver is down count towards opening its breaker.
+		h.breakers.record(dest, class == httpretry.FailureTransient, time.Now())
+		err := fmt.Errorf("webhook request failed with status: %d (%s)", resp.StatusCode, class)
+		delivery.Error = err.Error()
+		log.Warn("webhook returned non-success status",
+			slog.Int("status", resp.StatusCode),
+			slog.String("failure", class),
+			slog.Duration("retry_after", delay),
+		)
+
+		switch class {
+		case httpretry.FailurePermanent:
+			return fmt.Errorf("%w: %w", err, asynq.SkipRetry)
+		case httpretry.FailureRateLimited:
+			return &queue.RetryAfterError{Delay: delay, Err: err}
+		}
+		return err
 	}
 
+	h.breakers.record(dest, false, time.Now())
+	delivery.Success = true
 	log.Info("webhook sent successfully")
 	return nil
 }
+
+// saveDelivery records the attempt. A failure to record it doesn't fail the delivery itself.
+func (h *TaskHandler) saveDelivery(ctx context.Context, log *slog.Logger, d *models.WebhookDelivery) {
+	if err := h.deliveryRepo.SaveDelivery(context.WithoutCancel(ctx), d); err != nil {
+		log.Error("failed to save webhook delivery", logattr.Err(err))
+	}
+}
+
+// dropDelivery logs an attempt that failed before the request could be sent. Retrying won't fix it,
+// so the task is dropped, and the delivery can be resent from the log once the cause is fixed.
+func (h *TaskHandler) dropDelivery(ctx context.Context, log *slog.Logger, d *models.WebhookDelivery, err error) error {
+	d.Error = err.Error()
+	h.saveDelivery(ctx, log, d)
+	return fmt.Errorf("%w: %w", err, asynq.SkipRetry)
+}
+
+// sleep waits for d unless ctx is done first.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// sanitizeText makes an arbitrary response body storable as text: a truncated body may end mid-rune,
+// and PostgreSQL rejects NUL bytes.
+func sanitizeText(b []byte) string {
+	return strings.ReplaceAll(strings.ToValidUTF8(string(b), ""), "\x00", "")
+}
+
+// signingSecrets returns the current secret and, until it expires, the one it replaced.
+func signingSecrets(sub *models.WebhookSubscription, now time.Time) []string {
+	secrets := []string{sub.Secret}
+	if sub.PreviousSecret != "" && sub.PreviousSecretExpiresAt != nil && now.Before(*sub.PreviousSecretExpiresAt) {
+		secrets = append(secrets, sub.PreviousSecret)
+	}
+	return secrets
+}
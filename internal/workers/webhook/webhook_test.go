@@ -0,0 +1,82 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/ocenb/geo-alerts/internal/config"
+	"github.com/ocenb/geo-alerts/internal/domain/models"
+	"github.com/ocenb/geo-alerts/internal/logger"
+	"github.com/ocenb/geo-alerts/internal/queue"
+)
+
+type fakeSubscriptions struct {
+	sub *models.WebhookSubscription
+}
+
+func (f *fakeSubscriptions) GetByID(_ context.Context, _ int64) (*models.WebhookSubscription, error) {
+	return f.sub, nil
+}
+
+type fakeDeliveries struct {
+	saved []models.WebhookDelivery
+}
+
+func (f *fakeDeliveries) SaveDelivery(_ context.Context, d *models.WebhookDelivery) error {
+	f.saved = append(f.saved, *d)
+	return nil
+}
+
+func TestTaskHandler_ProcessTask_LogsUnsendable(t *testing.T) {
+	cfg := config.WebhookConfig{
+		RequestTimeout:          time.Second,
+		BreakerFailureThreshold: 5,
+		BreakerCooldown:         time.Second,
+		RateLimitBurst:          5,
+		PayloadFormat:           "legacy",
+		EventSource:             "/geo-alerts",
+		EventSchemaBase:         "urn:geo-alerts:schemas",
+	}
+	sub := &models.WebhookSubscription{ID: 7, URL: "http://bad host/", Secret: "0123456789abcdef", IsEnabled: true, PayloadFormat: "legacy"}
+
+	tests := []struct {
+		name      string
+		taskType  string
+		wantError string
+	}{
+		{"Broken URL", queue.TypeDangerWebhook, "http.NewRequest failed"},
+		{"Unknown event type", "webhook:unknown", "newCloudEvent failed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deliveries := &fakeDeliveries{}
+			h := New(logger.NewDiscard(), cfg, &fakeSubscriptions{sub: sub}, deliveries)
+
+			payload, err := json.Marshal(queue.WebhookTask{SubscriptionID: sub.ID, Payload: queue.WebhookPayload{UserID: "u1"}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = h.ProcessTask(context.Background(), asynq.NewTask(tt.taskType, payload))
+
+			if !errors.Is(err, asynq.SkipRetry) {
+				t.Fatalf("ProcessTask() error = %v, want SkipRetry", err)
+			}
+			if len(deliveries.saved) != 1 {
+				t.Fatalf("saved %d deliveries, want 1", len(deliveries.saved))
+			}
+			d := deliveries.saved[0]
+			if d.SubscriptionID != sub.ID || d.URL != sub.URL || d.Success || d.Attempt != 1 {
+				t.Errorf("delivery = %+v", d)
+			}
+			if !strings.HasPrefix(d.Error, tt.wantError) {
+				t.Errorf("delivery error = %q, want it to start with %q", d.Error, tt.wantError)
+			}
+		})
+	}
+}
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE incidents
+    ADD COLUMN approach_buffer_meters INTEGER CHECK (approach_buffer_meters >= 0);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE incidents DROP COLUMN IF EXISTS approach_buffer_meters;
+-- +goose StatementEnd
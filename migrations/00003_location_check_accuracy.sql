@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE location_checks
+    ADD COLUMN accuracy_meters DOUBLE PRECISION CHECK (accuracy_meters >= 0);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE location_checks DROP COLUMN IF EXISTS accuracy_meters;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE webhook_subscriptions
+    ADD COLUMN area JSONB, -- NULL = anywhere
+    ADD COLUMN min_severity SMALLINT NOT NULL DEFAULT 0 CHECK (min_severity BETWEEN 0 AND 5),
+    ADD COLUMN categories TEXT[] NOT NULL DEFAULT '{}'; -- empty = all categories
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE webhook_subscriptions
+    DROP COLUMN IF EXISTS categories,
+    DROP COLUMN IF EXISTS min_severity,
+    DROP COLUMN IF EXISTS area;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE webhook_subscriptions
+    ADD COLUMN previous_secret TEXT NOT NULL DEFAULT '', -- still signed with until previous_secret_expires_at
+    ADD COLUMN previous_secret_expires_at TIMESTAMP WITH TIME ZONE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE webhook_subscriptions
+    DROP COLUMN IF EXISTS previous_secret_expires_at,
+    DROP COLUMN IF EXISTS previous_secret;
+-- +goose StatementEnd
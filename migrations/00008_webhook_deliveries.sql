@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    task_id TEXT NOT NULL,
+    task_type TEXT NOT NULL,
+    subscription_id BIGINT NOT NULL, -- 0 = WEBHOOK_URL receiver, not a foreign key so the log outlives subscriptions
+    redelivery_of BIGINT REFERENCES webhook_deliveries (id) ON DELETE SET NULL,
+    url TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    payload_hash TEXT NOT NULL,
+    attempt INTEGER NOT NULL,
+    success BOOLEAN NOT NULL,
+    status_code INTEGER,
+    latency_ms BIGINT NOT NULL,
+    response_body TEXT NOT NULL DEFAULT '',
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_webhook_deliveries_created_at ON webhook_deliveries (created_at DESC);
+CREATE INDEX idx_webhook_deliveries_subscription_created_at ON webhook_deliveries (subscription_id, created_at DESC);
+CREATE INDEX idx_webhook_deliveries_task_id ON webhook_deliveries (task_id);
+CREATE INDEX idx_webhook_deliveries_payload_hash ON webhook_deliveries (payload_hash);
+CREATE INDEX idx_webhook_deliveries_user_id ON webhook_deliveries ((payload->>'user_id'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS webhook_deliveries;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE webhook_subscriptions
+    ADD COLUMN rate_limit_rps DOUBLE PRECISION CHECK (rate_limit_rps >= 0); -- NULL = WEBHOOK_RATE_LIMIT_RPS, 0 = unlimited
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE webhook_subscriptions
+    DROP COLUMN IF EXISTS rate_limit_rps;
+-- +goose StatementEnd
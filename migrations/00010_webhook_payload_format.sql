@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE webhook_subscriptions
+    ADD COLUMN payload_format TEXT NOT NULL DEFAULT 'legacy'
+        CHECK (payload_format IN ('legacy', 'cloudevents_structured', 'cloudevents_binary'));
+
+ALTER TABLE webhook_deliveries
+    ADD COLUMN event_id TEXT NOT NULL DEFAULT '', -- CloudEvents id, shared by retries and redeliveries of an event
+    ADD COLUMN event_time TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_webhook_deliveries_event_id ON webhook_deliveries (event_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_webhook_deliveries_event_id;
+
+ALTER TABLE webhook_deliveries
+    DROP COLUMN IF EXISTS event_time,
+    DROP COLUMN IF EXISTS event_id;
+
+ALTER TABLE webhook_subscriptions
+    DROP COLUMN IF EXISTS payload_format;
+-- +goose StatementEnd
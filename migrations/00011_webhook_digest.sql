@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE webhook_subscriptions
+    ADD COLUMN digest_interval_seconds INTEGER NOT NULL DEFAULT 0 CHECK (digest_interval_seconds >= 0), -- 0 = alerts are sent one by one
+    ADD COLUMN digest_max_alerts INTEGER NOT NULL DEFAULT 0 CHECK (digest_max_alerts >= 0);            -- 0 = flushed on interval only
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE webhook_subscriptions
+    DROP COLUMN IF EXISTS digest_max_alerts,
+    DROP COLUMN IF EXISTS digest_interval_seconds;
+-- +goose StatementEnd
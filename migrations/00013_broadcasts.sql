@@ -0,0 +1,52 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS broadcasts (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    incident_id BIGINT REFERENCES incidents (id) ON DELETE SET NULL,
+    area JSONB NOT NULL, -- the incident zone when targeted by incident
+    title TEXT NOT NULL,
+    message TEXT NOT NULL,
+    recency_seconds INTEGER NOT NULL CHECK (recency_seconds > 0),
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sending', 'completed', 'failed')),
+    users INTEGER NOT NULL DEFAULT 0,
+    unreachable INTEGER NOT NULL DEFAULT 0, -- users without a device to push to
+    total INTEGER NOT NULL DEFAULT 0,
+    sent INTEGER NOT NULL DEFAULT 0,
+    failed INTEGER NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_broadcasts_created_at ON broadcasts (created_at DESC);
+
+CREATE TRIGGER update_broadcasts_updated_at
+    BEFORE UPDATE ON broadcasts
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TABLE IF NOT EXISTS broadcast_recipients (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    broadcast_id BIGINT NOT NULL REFERENCES broadcasts (id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL,
+    device_id BIGINT NOT NULL, -- not a foreign key so the report outlives unregistered devices
+    token TEXT NOT NULL,
+    platform TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sent', 'failed')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_broadcast_recipients_broadcast_status ON broadcast_recipients (broadcast_id, status);
+CREATE INDEX idx_location_checks_user_created_at ON location_checks (user_id, created_at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_location_checks_user_created_at;
+DROP TABLE IF EXISTS broadcast_recipients;
+DROP TRIGGER IF EXISTS update_broadcasts_updated_at ON broadcasts;
+DROP TABLE IF EXISTS broadcasts;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS incident_checkins (
+    incident_id BIGINT NOT NULL REFERENCES incidents (id) ON DELETE CASCADE,
+    user_id VARCHAR(255) NOT NULL,
+    status TEXT NOT NULL CHECK (status IN ('safe', 'need_help', 'evacuated')),
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (incident_id, user_id)
+);
+
+CREATE TRIGGER update_incident_checkins_updated_at
+    BEFORE UPDATE ON incident_checkins
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS update_incident_checkins_updated_at ON incident_checkins;
+DROP TABLE IF EXISTS incident_checkins;
+-- +goose StatementEnd
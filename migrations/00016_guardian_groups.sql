@@ -0,0 +1,52 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS guardian_groups (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    name TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER update_guardian_groups_updated_at
+    BEFORE UPDATE ON guardian_groups
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TABLE IF NOT EXISTS guardians (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    group_id BIGINT NOT NULL REFERENCES guardian_groups (id) ON DELETE CASCADE,
+    name TEXT NOT NULL DEFAULT '',
+    channel TEXT NOT NULL CHECK (channel IN ('webhook', 'email', 'sms')),
+    address TEXT NOT NULL, -- URL, email address or phone number, depending on the channel
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_guardians_group_id ON guardians (group_id);
+
+-- Members are alerted on only once they consent, and can revoke it at any time.
+CREATE TABLE IF NOT EXISTS guardian_group_members (
+    group_id BIGINT NOT NULL REFERENCES guardian_groups (id) ON DELETE CASCADE,
+    user_id VARCHAR(255) NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'consented', 'revoked')),
+    consented_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (group_id, user_id)
+);
+
+CREATE INDEX idx_guardian_group_members_consented ON guardian_group_members (user_id) WHERE status = 'consented';
+
+CREATE TRIGGER update_guardian_group_members_updated_at
+    BEFORE UPDATE ON guardian_group_members
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS update_guardian_group_members_updated_at ON guardian_group_members;
+DROP TABLE IF EXISTS guardian_group_members;
+DROP TABLE IF EXISTS guardians;
+DROP TRIGGER IF EXISTS update_guardian_groups_updated_at ON guardian_groups;
+DROP TABLE IF EXISTS guardian_groups;
+-- +goose StatementEnd
@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS user_preferences (
+    user_id VARCHAR(255) PRIMARY KEY,
+    opted_out BOOLEAN NOT NULL DEFAULT FALSE,
+    min_severity INT NOT NULL DEFAULT 0 CHECK (min_severity BETWEEN 0 AND 5),
+    muted_categories TEXT[] NOT NULL DEFAULT '{}',
+    quiet_start TEXT, -- HH:MM local time, quiet hours may wrap around midnight
+    quiet_end TEXT,
+    timezone TEXT NOT NULL DEFAULT 'UTC',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CHECK ((quiet_start IS NULL) = (quiet_end IS NULL))
+);
+
+CREATE TRIGGER update_user_preferences_updated_at
+    BEFORE UPDATE ON user_preferences
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Alerts held back by the preferences of the user they were about.
+CREATE TABLE IF NOT EXISTS alert_suppressions (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    alert_type TEXT NOT NULL,
+    incident_ids BIGINT[] NOT NULL,
+    reason TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_alert_suppressions_user_id ON alert_suppressions (user_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS alert_suppressions;
+DROP TRIGGER IF EXISTS update_user_preferences_updated_at ON user_preferences;
+DROP TABLE IF EXISTS user_preferences;
+-- +goose StatementEnd
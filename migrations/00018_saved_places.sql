@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS saved_places (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    name TEXT NOT NULL, -- home, work, school or any other
+    location GEOMETRY(Point, 4326) NOT NULL,
+    radius_meters INT NOT NULL CHECK (radius_meters > 0),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, name)
+);
+
+CREATE INDEX idx_saved_places_location ON saved_places USING GIST (location);
+
+CREATE TRIGGER update_saved_places_updated_at
+    BEFORE UPDATE ON saved_places
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Owners already alerted about an incident near the place.
+CREATE TABLE IF NOT EXISTS saved_place_alerts (
+    place_id BIGINT NOT NULL REFERENCES saved_places (id) ON DELETE CASCADE,
+    incident_id BIGINT NOT NULL REFERENCES incidents (id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (place_id, incident_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS saved_place_alerts;
+DROP TRIGGER IF EXISTS update_saved_places_updated_at ON saved_places;
+DROP TABLE IF EXISTS saved_places;
+-- +goose StatementEnd
@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS escalation_policies (
+    severity SMALLINT PRIMARY KEY CHECK (severity BETWEEN 1 AND 5),
+    realert_after_seconds INT NOT NULL DEFAULT 0 CHECK (realert_after_seconds >= 0),    -- 0 = no re-alert
+    escalate_after_seconds INT NOT NULL DEFAULT 0 CHECK (escalate_after_seconds >= 0),  -- 0 = no escalation
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER update_escalation_policies_updated_at
+    BEFORE UPDATE ON escalation_policies
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Users inside an incident zone since their first of a run of checks inside it.
+CREATE TABLE IF NOT EXISTS zone_dwells (
+    user_id VARCHAR(255) NOT NULL,
+    incident_id BIGINT NOT NULL REFERENCES incidents (id) ON DELETE CASCADE,
+    entered_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_seen_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    location GEOMETRY(Point, 4326) NOT NULL, -- of the last check
+    PRIMARY KEY (user_id, incident_id)
+);
+
+CREATE INDEX idx_zone_dwells_incident_id ON zone_dwells (incident_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS zone_dwells;
+DROP TRIGGER IF EXISTS update_escalation_policies_updated_at ON escalation_policies;
+DROP TABLE IF EXISTS escalation_policies;
+-- +goose StatementEnd
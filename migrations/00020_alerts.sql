@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS alerts (
+    id UUID PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    alert_type TEXT NOT NULL,
+    incident_ids BIGINT[] NOT NULL,
+    payload JSONB NOT NULL, -- as sent, to resend it
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    acknowledged_at TIMESTAMP WITH TIME ZONE,
+    time_to_ack_seconds DOUBLE PRECISION,
+    followed_up_at TIMESTAMP WITH TIME ZONE -- resent or escalated for being unacknowledged
+);
+
+CREATE INDEX idx_alerts_incident_ids ON alerts USING GIN (incident_ids);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS alerts;
+-- +goose StatementEnd
@@ -0,0 +1,151 @@
+// Package webhooksig signs geo-alerts webhook deliveries and verifies them on the receiving side.
+//
+// A signature is HMAC-SHA256 keyed with the subscription secret over "<timestamp>.<body>", where the timestamp
+// is in Unix seconds, sent hex-encoded as "v1=<signature>". While a secret is being rotated the signature
+// header carries one signature per secret separated by commas, so a receiver holding either secret accepts it.
+package webhooksig
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	HeaderSignature  = "X-Geo-Alerts-Signature"
+	HeaderTimestamp  = "X-Geo-Alerts-Timestamp"
+	HeaderDeliveryID = "X-Geo-Alerts-Delivery-Id"
+
+	// DefaultTolerance is how far a delivery timestamp may be from the receiver's clock.
+	DefaultTolerance = 5 * time.Minute
+
+	signaturePrefix = "v1="
+	maxBodySize     = 1 << 20
+)
+
+var (
+	ErrMissingHeaders   = errors.New("missing signature, timestamp or delivery id header")
+	ErrInvalidTimestamp = errors.New("invalid timestamp")
+	ErrExpired          = errors.New("timestamp is outside the tolerance")
+	ErrInvalidSignature = errors.New("no valid signature")
+	ErrReplayed         = errors.New("delivery has already been received")
+)
+
+// Sign returns the hex-encoded signature of the body sent at the timestamp.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// SetHeaders signs the body with every secret and sets the signature, timestamp and delivery id headers.
+func SetHeaders(h http.Header, deliveryID string, timestamp time.Time, body []byte, secrets ...string) {
+	ts := timestamp.Unix()
+
+	signatures := make([]string, 0, len(secrets))
+	for _, secret := range secrets {
+		signatures = append(signatures, signaturePrefix+Sign(secret, ts, body))
+	}
+
+	h.Set(HeaderSignature, strings.Join(signatures, ","))
+	h.Set(HeaderTimestamp, strconv.FormatInt(ts, 10))
+	h.Set(HeaderDeliveryID, deliveryID)
+}
+
+// Verifier checks signatures with any of its secrets and rejects stale or replayed deliveries.
+// A retried delivery keeps its id but is signed with a new timestamp, so only an exact repeat counts as a replay.
+// It is safe for concurrent use.
+type Verifier struct {
+	secrets   []string
+	tolerance time.Duration
+	now       func() time.Time
+
+	mu   sync.Mutex
+	seen map[string]time.Time // delivery id and timestamp -> when it can be forgotten
+}
+
+func NewVerifier(tolerance time.Duration, secrets ...string) *Verifier {
+	return &Verifier{
+		secrets:   secrets,
+		tolerance: tolerance,
+		now:       time.Now,
+		seen:      make(map[string]time.Time),
+	}
+}
+
+// Verify checks the headers of a delivery against its raw body.
+func (v *Verifier) Verify(h http.Header, body []byte) error {
+	header, rawTS, deliveryID := h.Get(HeaderSignature), h.Get(HeaderTimestamp), h.Get(HeaderDeliveryID)
+	if header == "" || rawTS == "" || deliveryID == "" {
+		return ErrMissingHeaders
+	}
+
+	ts, err := strconv.ParseInt(rawTS, 10, 64)
+	if err != nil {
+		return ErrInvalidTimestamp
+	}
+
+	now := v.now()
+	sentAt := time.Unix(ts, 0)
+	if now.Sub(sentAt).Abs() > v.tolerance {
+		return ErrExpired
+	}
+
+	if !v.validSignature(header, ts, body) {
+		return ErrInvalidSignature
+	}
+
+	return v.markSeen(deliveryID+"."+rawTS, sentAt.Add(v.tolerance), now)
+}
+
+// VerifyRequest reads the request body and verifies it, the body is returned for further processing.
+func (v *Verifier) VerifyRequest(r *http.Request) ([]byte, error) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read body: %w", err)
+	}
+	if len(body) > maxBodySize {
+		return nil, fmt.Errorf("body exceeds %d bytes", maxBodySize)
+	}
+	return body, v.Verify(r.Header, body)
+}
+
+func (v *Verifier) validSignature(header string, ts int64, body []byte) bool {
+	for _, secret := range v.secrets {
+		expected := []byte(signaturePrefix + Sign(secret, ts, body))
+		for _, got := range strings.Split(header, ",") {
+			if hmac.Equal([]byte(strings.TrimSpace(got)), expected) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// markSeen records the delivery until its timestamp leaves the tolerance, after which Verify rejects it anyway.
+func (v *Verifier) markSeen(key string, until, now time.Time) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	for k, exp := range v.seen {
+		if now.After(exp) {
+			delete(v.seen, k)
+		}
+	}
+
+	if _, ok := v.seen[key]; ok {
+		return ErrReplayed
+	}
+	v.seen[key] = until
+	return nil
+}
@@ -0,0 +1,143 @@
+package webhooksig
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestVerify(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	body := []byte(`{"user_id":"u1"}`)
+
+	signed := func(ts time.Time, secrets ...string) http.Header {
+		h := http.Header{}
+		SetHeaders(h, "d1", ts, body, secrets...)
+		return h
+	}
+
+	tests := []struct {
+		name    string
+		secrets []string
+		header  http.Header
+		body    []byte
+		wantErr error
+	}{
+		{
+			name:    "Valid",
+			secrets: []string{"current"},
+			header:  signed(now, "current"),
+			body:    body,
+		},
+		{
+			name:    "Receiver still on the previous secret",
+			secrets: []string{"previous"},
+			header:  signed(now, "current", "previous"),
+			body:    body,
+		},
+		{
+			name:    "Receiver already on the new secret",
+			secrets: []string{"current", "previous"},
+			header:  signed(now, "current"),
+			body:    body,
+		},
+		{
+			name:    "Wrong secret",
+			secrets: []string{"other"},
+			header:  signed(now, "current"),
+			body:    body,
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "Tampered body",
+			secrets: []string{"current"},
+			header:  signed(now, "current"),
+			body:    []byte(`{"user_id":"u2"}`),
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "Tampered timestamp",
+			secrets: []string{"current"},
+			header: func() http.Header {
+				h := signed(now, "current")
+				h.Set(HeaderTimestamp, strconv.FormatInt(now.Unix()+1, 10))
+				return h
+			}(),
+			body:    body,
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "Too old",
+			secrets: []string{"current"},
+			header:  signed(now.Add(-DefaultTolerance-time.Second), "current"),
+			body:    body,
+			wantErr: ErrExpired,
+		},
+		{
+			name:    "Too far in the future",
+			secrets: []string{"current"},
+			header:  signed(now.Add(DefaultTolerance+time.Second), "current"),
+			body:    body,
+			wantErr: ErrExpired,
+		},
+		{
+			name:    "Missing delivery id",
+			secrets: []string{"current"},
+			header: func() http.Header {
+				h := signed(now, "current")
+				h.Del(HeaderDeliveryID)
+				return h
+			}(),
+			body:    body,
+			wantErr: ErrMissingHeaders,
+		},
+		{
+			name:    "Malformed timestamp",
+			secrets: []string{"current"},
+			header: func() http.Header {
+				h := signed(now, "current")
+				h.Set(HeaderTimestamp, "yesterday")
+				return h
+			}(),
+			body:    body,
+			wantErr: ErrInvalidTimestamp,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := NewVerifier(DefaultTolerance, tt.secrets...)
+			v.now = func() time.Time { return now }
+
+			if err := v.Verify(tt.header, tt.body); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerify_Replay(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	body := []byte(`{"user_id":"u1"}`)
+
+	v := NewVerifier(DefaultTolerance, "current")
+	v.now = func() time.Time { return now }
+
+	first := http.Header{}
+	SetHeaders(first, "d1", now, body, "current")
+	if err := v.Verify(first, body); err != nil {
+		t.Fatalf("first delivery: unexpected error %v", err)
+	}
+	if err := v.Verify(first, body); !errors.Is(err, ErrReplayed) {
+		t.Errorf("replayed delivery: error = %v, want %v", err, ErrReplayed)
+	}
+
+	// A retry of the same delivery is signed anew and must get through.
+	retry := http.Header{}
+	SetHeaders(retry, "d1", now.Add(30*time.Second), body, "current")
+	if err := v.Verify(retry, body); err != nil {
+		t.Errorf("retried delivery: unexpected error %v", err)
+	}
+}
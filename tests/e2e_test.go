@@ -162,3 +162,75 @@ func TestFullFlow(t *testing.T) {
 	}
 	assert.False(t, hasDangerBool, "Should be safe after deactivation")
 }
+
+func TestStatsAccuracyWiderThanZone(t *testing.T) {
+	client := resty.New().
+		SetTimeout(5 * time.Second).
+		SetRetryCount(3).
+		SetRetryWaitTime(1 * time.Second)
+
+	// 1. Create a small incident
+	var res map[string]any
+	resp, err := client.R().
+		SetHeader("X-API-Key", *apiKey).
+		SetBody(map[string]any{
+			"latitude":  48.8566,
+			"longitude": 2.3522,
+			"radius":    50,
+		}).
+		SetResult(&res).
+		Post(*apiURL + "/incidents")
+
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode())
+	incIdFloat, ok := res["id"].(float64)
+	if !ok {
+		t.Fatalf("'id' is not a float64. Got: %v", res)
+	}
+	incidentID := int64(incIdFloat)
+
+	defer func() {
+		resp, err := client.R().
+			SetHeader("X-API-Key", *apiKey).
+			Delete(fmt.Sprintf("%s/incidents/%d", *apiURL, incidentID))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNoContent, resp.StatusCode())
+	}()
+
+	// 2. Check one user whose accuracy circle is wider than the zone and one wholly inside it
+	for userID, accuracy := range map[string]float64{"wide-accuracy": 200, "precise": 10} {
+		resp, err = client.R().
+			SetBody(map[string]any{
+				"user_id":         userID,
+				"latitude":        48.8566,
+				"longitude":       2.3522,
+				"accuracy_meters": accuracy,
+			}).
+			Post(*apiURL + "/location/check")
+
+		require.NoError(t, err)
+		require.Equal(t, http.StatusCreated, resp.StatusCode())
+	}
+
+	// 3. Only the precise check counts while possibly inside checks aren't counted
+	require.Eventually(t, func() bool {
+		var statsRes []map[string]any
+
+		resp, err := client.R().
+			SetHeader("X-API-Key", *apiKey).
+			SetResult(&statsRes).
+			Get(*apiURL + "/incidents/stats")
+
+		if err != nil || resp.StatusCode() != http.StatusOK {
+			return false
+		}
+
+		for _, s := range statsRes {
+			if idFloat, ok := s["incident_id"].(float64); ok && int64(idFloat) == incidentID {
+				count, ok := s["user_count"].(float64)
+				return ok && count == 1.0
+			}
+		}
+		return false
+	}, 5*time.Second, 500*time.Millisecond, "Stats should count only the check wholly inside the zone")
+}